@@ -0,0 +1,89 @@
+package cochange
+
+import (
+	"fmt"
+	"sort"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies the co-change analyzer in merged dependency output
+const Name = "co-change"
+
+// DefaultMaxCommits bounds how much history is mined
+const DefaultMaxCommits = 500
+
+const (
+	minCommits    = 3   // Pairs must have changed together at least this often
+	minConfidence = 0.5 // ...in at least this share of the commits touching the rarer file
+	maxCommitSize = 30  // Larger commits are sweeping refactors or merges and say little about coupling
+)
+
+// Analyze emits weak dependencies between changed files that historically change together.
+// commits holds the file paths of each historical commit.
+func Analyze(changes []types.FileChange, commits [][]string) []types.Dependency {
+	changed := make(map[string]bool)
+	for _, change := range changes {
+		if change.IsChanged && change.ChangeType != types.ChangeTypeDelete {
+			changed[change.Path] = true
+		}
+	}
+
+	type pair struct{ a, b string }
+	together := make(map[pair]int)
+	touched := make(map[string]int)
+
+	for _, files := range commits {
+		if len(files) > maxCommitSize {
+			continue
+		}
+
+		var relevant []string
+		for _, file := range files {
+			if changed[file] {
+				relevant = append(relevant, file)
+				touched[file]++
+			}
+		}
+		sort.Strings(relevant)
+
+		for i := 0; i < len(relevant); i++ {
+			for j := i + 1; j < len(relevant); j++ {
+				together[pair{relevant[i], relevant[j]}]++
+			}
+		}
+	}
+
+	pairs := make([]pair, 0, len(together))
+	for p := range together {
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].a != pairs[j].a {
+			return pairs[i].a < pairs[j].a
+		}
+		return pairs[i].b < pairs[j].b
+	})
+
+	var dependencies []types.Dependency
+	for _, p := range pairs {
+		count := together[p]
+		rarer := touched[p.a]
+		if touched[p.b] < rarer {
+			rarer = touched[p.b]
+		}
+		confidence := float64(count) / float64(rarer)
+		if count < minCommits || confidence < minConfidence {
+			continue
+		}
+
+		context := fmt.Sprintf("changed together in %d commits (%.0f%%)", count, confidence*100)
+		// Co-change has no direction, so both edges are emitted
+		dependencies = append(dependencies,
+			types.Dependency{From: p.a, To: p.b, Type: "co-change", Strength: types.StrengthWeak, Context: context, Analyzers: []string{Name}},
+			types.Dependency{From: p.b, To: p.a, Type: "co-change", Strength: types.StrengthWeak, Context: context, Analyzers: []string{Name}},
+		)
+	}
+
+	return dependencies
+}
@@ -0,0 +1,117 @@
+package cpp
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies the built-in C/C++ analyzer
+const Name = "cpp-analyzer"
+
+// Version of the built-in C/C++ analyzer
+const Version = "1.0.0"
+
+// includePattern matches #include "x" and #include <x>, allowing whitespace after '#'
+var includePattern = regexp.MustCompile(`^\s*#\s*include\s*([<"])([^>"]+)[>"]`)
+
+// defaultIncludeDirs are searched after configured include paths when they exist in the change set
+var defaultIncludeDirs = []string{"include", "src"}
+
+// Analyzer resolves #include directives against the repository tree
+type Analyzer struct {
+	files        map[string]bool
+	includePaths []string
+}
+
+// New creates a new C/C++ analyzer
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+// Extensions returns the file extensions handled by the analyzer
+func (a *Analyzer) Extensions() []string {
+	return []string{".c", ".cc", ".cpp", ".cxx", ".h", ".hh", ".hpp", ".hxx", ".inl", ".ipp"}
+}
+
+// Analyze finds include dependencies between C/C++ files
+func (a *Analyzer) Analyze(input types.PluginInput) (*types.PluginOutput, error) {
+	startTime := time.Now()
+
+	a.files = make(map[string]bool)
+	for _, file := range append(input.ChangedFiles, input.ProjectFiles...) {
+		a.files[file.Path] = true
+	}
+
+	a.includePaths = nil
+	for _, dir := range append(append([]string{}, input.IncludePaths...), defaultIncludeDirs...) {
+		a.includePaths = append(a.includePaths, path.Clean(strings.TrimPrefix(dir, "./")))
+	}
+
+	var dependencies []types.Dependency
+	analyzed := 0
+
+	for _, file := range input.ChangedFiles {
+		if file.ChangeType == types.ChangeTypeDelete {
+			continue
+		}
+		analyzed++
+
+		seen := make(map[string]bool)
+		for lineNum, line := range strings.Split(file.Content, "\n") {
+			match := includePattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			target := a.resolve(match[2], file.Path, match[1] == `"`)
+			if target == "" || target == file.Path || seen[target] {
+				continue
+			}
+			seen[target] = true
+
+			dependencies = append(dependencies, types.Dependency{
+				From:     file.Path,
+				To:       target,
+				Type:     "include",
+				Strength: types.StrengthCritical,
+				Line:     lineNum + 1,
+				Context:  strings.TrimSpace(line),
+			})
+		}
+	}
+
+	return &types.PluginOutput{
+		Dependencies: dependencies,
+		Errors:       []string{},
+		Metadata: types.PluginMetadata{
+			FilesAnalyzed: analyzed,
+			AnalysisTime:  time.Since(startTime).String(),
+			PluginName:    Name,
+			PluginVersion: Version,
+		},
+	}, nil
+}
+
+// resolve follows the compiler search order: the including file's directory (quoted form only),
+// then include paths, then the repository root
+func (a *Analyzer) resolve(header, fromFile string, quoted bool) string {
+	var searchDirs []string
+	if quoted {
+		searchDirs = append(searchDirs, path.Dir(fromFile))
+	}
+	searchDirs = append(searchDirs, a.includePaths...)
+	searchDirs = append(searchDirs, ".")
+
+	for _, dir := range searchDirs {
+		candidate := path.Clean(path.Join(dir, header))
+		if a.files[candidate] {
+			return candidate
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,196 @@
+package golang
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies the built-in Go analyzer
+const Name = "go-analyzer"
+
+// Version of the built-in Go analyzer
+const Version = "1.0.0"
+
+// Analyzer resolves Go import dependencies using go/parser and go.mod module paths
+type Analyzer struct {
+	projectRoot string
+	modules     map[string]*module // go.mod directory -> module, cached per run
+	moduleDirs  map[string]string  // package directory -> go.mod directory
+}
+
+// module is a Go module rooted at a go.mod file
+type module struct {
+	dir  string // directory relative to project root, "." for the root
+	path string // module path declared in go.mod
+}
+
+// New creates a new Go analyzer
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+// Extensions returns the file extensions handled by the analyzer
+func (a *Analyzer) Extensions() []string {
+	return []string{".go"}
+}
+
+// Analyze finds import dependencies between Go files in the input
+func (a *Analyzer) Analyze(input types.PluginInput) (*types.PluginOutput, error) {
+	startTime := time.Now()
+
+	a.projectRoot = input.ProjectRoot
+	a.modules = make(map[string]*module)
+	a.moduleDirs = make(map[string]string)
+
+	// Index non-test Go files by package directory
+	packageFiles := make(map[string][]string)
+	for _, file := range append(input.ChangedFiles, input.ProjectFiles...) {
+		if isGoSource(file.Path) && !strings.HasSuffix(file.Path, "_test.go") {
+			dir := path.Dir(file.Path)
+			packageFiles[dir] = append(packageFiles[dir], file.Path)
+		}
+	}
+
+	var dependencies []types.Dependency
+	var errors []string
+	analyzed := 0
+
+	for _, file := range input.ChangedFiles {
+		if !isGoSource(file.Path) {
+			continue
+		}
+		analyzed++
+
+		fileDeps, err := a.analyzeFile(file, packageFiles)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", file.Path, err))
+			continue
+		}
+		dependencies = append(dependencies, fileDeps...)
+	}
+
+	return &types.PluginOutput{
+		Dependencies: dependencies,
+		Errors:       errors,
+		Metadata: types.PluginMetadata{
+			FilesAnalyzed: analyzed,
+			AnalysisTime:  time.Since(startTime).String(),
+			PluginName:    Name,
+			PluginVersion: Version,
+		},
+	}, nil
+}
+
+// analyzeFile parses a file's imports and maps them to files in the same module
+func (a *Analyzer) analyzeFile(file types.FileChange, packageFiles map[string][]string) ([]types.Dependency, error) {
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, file.Path, file.Content, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse imports: %w", err)
+	}
+
+	mod := a.moduleFor(path.Dir(file.Path))
+	if mod == nil {
+		return nil, nil
+	}
+
+	var dependencies []types.Dependency
+	for _, spec := range parsed.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		pkgDir, ok := mod.packageDir(importPath)
+		if !ok {
+			continue // Standard library or external module
+		}
+
+		line := fset.Position(spec.Pos()).Line
+		for _, target := range packageFiles[pkgDir] {
+			if target == file.Path {
+				continue
+			}
+			dependencies = append(dependencies, types.Dependency{
+				From:     file.Path,
+				To:       target,
+				Type:     "import",
+				Strength: types.StrengthCritical,
+				Line:     line,
+				Context:  importPath,
+			})
+		}
+	}
+
+	return dependencies, nil
+}
+
+// moduleFor finds the nearest enclosing module of a package directory
+func (a *Analyzer) moduleFor(dir string) *module {
+	if modDir, cached := a.moduleDirs[dir]; cached {
+		return a.modules[modDir]
+	}
+
+	current := dir
+	for {
+		if mod, loaded := a.modules[current]; loaded {
+			a.moduleDirs[dir] = current
+			return mod
+		}
+
+		if modPath := readModulePath(filepath.Join(a.projectRoot, filepath.FromSlash(current), "go.mod")); modPath != "" {
+			a.modules[current] = &module{dir: current, path: modPath}
+			a.moduleDirs[dir] = current
+			return a.modules[current]
+		}
+
+		if current == "." || current == "/" || current == "" {
+			break
+		}
+		current = path.Dir(current)
+	}
+
+	a.moduleDirs[dir] = ""
+	return nil
+}
+
+// packageDir maps an import path within the module to a directory relative to the project root
+func (m *module) packageDir(importPath string) (string, bool) {
+	if importPath != m.path && !strings.HasPrefix(importPath, m.path+"/") {
+		return "", false
+	}
+
+	rel := strings.TrimPrefix(strings.TrimPrefix(importPath, m.path), "/")
+	return path.Clean(path.Join(m.dir, rel)), true
+}
+
+// readModulePath returns the module path declared in a go.mod file
+func readModulePath(goModPath string) string {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+
+	return ""
+}
+
+// isGoSource checks if a path is a Go source file
+func isGoSource(filePath string) bool {
+	return strings.HasSuffix(strings.ToLower(filePath), ".go")
+}
@@ -0,0 +1,306 @@
+package jvm
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies the built-in Java/Kotlin analyzer
+const Name = "jvm-analyzer"
+
+// Version of the built-in Java/Kotlin analyzer
+const Version = "1.0.0"
+
+var (
+	packagePattern  = regexp.MustCompile(`^\s*package\s+([\w.]+)`)
+	importPattern   = regexp.MustCompile(`^\s*import\s+(static\s+)?([\w.]+)(\.\*)?(?:\s+as\s+\w+)?\s*;?\s*$`)
+	typeDeclPattern = regexp.MustCompile(`\b(?:class|interface|enum|record|object)\s+([A-Z]\w*)`)
+	kotlinFunPrefix = regexp.MustCompile(`\b(?:fun|val|var)\s+(?:<[^>]*>\s*)?(?:[\w.]+\.)?`)
+)
+
+// Analyzer maps package/import declarations to files, independent of the Gradle/Maven module layout
+type Analyzer struct {
+	types    map[string][]string // fully qualified type name -> declaring files
+	packages map[string][]string // package name -> files
+	sources  map[string]*sourceFile
+}
+
+// sourceFile is the parsed header of one Java or Kotlin file
+type sourceFile struct {
+	path     string
+	pkg      string
+	imports  []importDecl
+	declared []string
+	content  string
+}
+
+// importDecl is a single import statement
+type importDecl struct {
+	name     string // imported name without ".*"
+	wildcard bool
+	line     int
+}
+
+// New creates a new Java/Kotlin analyzer
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+// Extensions returns the file extensions handled by the analyzer
+func (a *Analyzer) Extensions() []string {
+	return []string{".java", ".kt"}
+}
+
+// Analyze finds import and same-package dependencies between JVM source files
+func (a *Analyzer) Analyze(input types.PluginInput) (*types.PluginOutput, error) {
+	startTime := time.Now()
+
+	a.types = make(map[string][]string)
+	a.packages = make(map[string][]string)
+	a.sources = make(map[string]*sourceFile)
+
+	for _, file := range append(input.ChangedFiles, input.ProjectFiles...) {
+		if !isJVMSource(file.Path) || file.ChangeType == types.ChangeTypeDelete {
+			continue
+		}
+		a.index(parseSource(file.Path, file.Content))
+	}
+
+	var dependencies []types.Dependency
+	analyzed := 0
+
+	for _, file := range input.ChangedFiles {
+		source, ok := a.sources[file.Path]
+		if !ok {
+			continue
+		}
+		analyzed++
+		dependencies = append(dependencies, a.fileDependencies(source)...)
+	}
+
+	return &types.PluginOutput{
+		Dependencies: dependencies,
+		Errors:       []string{},
+		Metadata: types.PluginMetadata{
+			FilesAnalyzed: analyzed,
+			AnalysisTime:  time.Since(startTime).String(),
+			PluginName:    Name,
+			PluginVersion: Version,
+		},
+	}, nil
+}
+
+// index records the package and declared types of a file
+func (a *Analyzer) index(source *sourceFile) {
+	a.sources[source.path] = source
+	a.packages[source.pkg] = append(a.packages[source.pkg], source.path)
+	for _, name := range source.declared {
+		qualified := qualify(source.pkg, name)
+		a.types[qualified] = append(a.types[qualified], source.path)
+	}
+}
+
+// fileDependencies resolves a file's imports and same-package references
+func (a *Analyzer) fileDependencies(source *sourceFile) []types.Dependency {
+	var dependencies []types.Dependency
+	seen := make(map[string]bool)
+
+	add := func(target, depType string, strength types.DependencyStrength, line int, context string) {
+		if target == source.path || seen[target] {
+			return
+		}
+		seen[target] = true
+		dependencies = append(dependencies, types.Dependency{
+			From:     source.path,
+			To:       target,
+			Type:     depType,
+			Strength: strength,
+			Line:     line,
+			Context:  context,
+		})
+	}
+
+	for _, imp := range source.imports {
+		for _, target := range a.resolveImport(source, imp) {
+			add(target, "import", types.StrengthCritical, imp.line, imp.name)
+		}
+	}
+
+	// Types in the same package are visible without an import
+	for _, other := range a.packages[source.pkg] {
+		if other == source.path {
+			continue
+		}
+		for _, name := range a.sources[other].declared {
+			if containsWord(source.content, name) {
+				add(other, "same-package", types.StrengthStrong, 0, qualify(source.pkg, name))
+				break
+			}
+		}
+	}
+
+	return dependencies
+}
+
+// resolveImport maps an import to the files declaring the imported type, package or member
+func (a *Analyzer) resolveImport(source *sourceFile, imp importDecl) []string {
+	if imp.wildcard {
+		// import com.example.model.* or a static import of all members of a class
+		if files := a.packages[imp.name]; len(files) > 0 {
+			return files
+		}
+		return a.preferSameModule(source.path, a.types[imp.name])
+	}
+
+	// Try the longest qualified type prefix so nested types and static members resolve to their outer type
+	parts := strings.Split(imp.name, ".")
+	for i := len(parts); i > 1; i-- {
+		if files := a.types[strings.Join(parts[:i], ".")]; len(files) > 0 {
+			return a.preferSameModule(source.path, files)
+		}
+	}
+
+	// Kotlin top-level function or property: import com.example.util.formatDate
+	pkg, member := parts[:len(parts)-1], parts[len(parts)-1]
+	var declaring []string
+	for _, candidate := range a.packages[strings.Join(pkg, ".")] {
+		if strings.HasSuffix(candidate, ".kt") && declaresMember(a.sources[candidate].content, member) {
+			declaring = append(declaring, candidate)
+		}
+	}
+	return declaring
+}
+
+// preferSameModule narrows duplicate declarations to those in the importing file's Gradle/Maven module
+func (a *Analyzer) preferSameModule(fromPath string, files []string) []string {
+	if len(files) <= 1 {
+		return files
+	}
+
+	module := moduleRoot(fromPath)
+	var sameModule []string
+	for _, file := range files {
+		if moduleRoot(file) == module {
+			sameModule = append(sameModule, file)
+		}
+	}
+
+	if len(sameModule) > 0 {
+		return sameModule
+	}
+	return files
+}
+
+// parseSource extracts the package, imports and declared types of a file
+func parseSource(filePath, content string) *sourceFile {
+	source := &sourceFile{path: filePath, content: content}
+	declared := make(map[string]bool)
+
+	// The file name is the primary type in Java and the common convention in Kotlin
+	base := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	declared[base] = true
+
+	inComment := false
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if inComment {
+			if strings.Contains(trimmed, "*/") {
+				inComment = false
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "/*") && !strings.Contains(trimmed, "*/") {
+			inComment = true
+			continue
+		}
+		if strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+
+		if source.pkg == "" {
+			if match := packagePattern.FindStringSubmatch(trimmed); match != nil {
+				source.pkg = match[1]
+				continue
+			}
+		}
+
+		if match := importPattern.FindStringSubmatch(trimmed); match != nil {
+			source.imports = append(source.imports, importDecl{
+				name:     match[2],
+				wildcard: match[3] != "",
+				line:     i + 1,
+			})
+			continue
+		}
+
+		for _, match := range typeDeclPattern.FindAllStringSubmatch(trimmed, -1) {
+			declared[match[1]] = true
+		}
+	}
+
+	for name := range declared {
+		source.declared = append(source.declared, name)
+	}
+	sort.Strings(source.declared)
+
+	return source
+}
+
+// declaresMember reports whether Kotlin source declares a top-level fun/val/var with the given name
+func declaresMember(content, member string) bool {
+	for _, loc := range kotlinFunPrefix.FindAllStringIndex(content, -1) {
+		rest := content[loc[1]:]
+		if strings.HasPrefix(rest, member) && (len(rest) == len(member) || !isWordChar(rest[len(member)])) {
+			return true
+		}
+	}
+	return false
+}
+
+// moduleRoot returns the build module directory of a source path, e.g. "services/api" for
+// "services/api/src/main/kotlin/...", or the parent directory when there is no src/ segment
+func moduleRoot(filePath string) string {
+	if idx := strings.Index(filePath, "src/"); idx >= 0 && (idx == 0 || filePath[idx-1] == '/') {
+		return strings.TrimSuffix(filePath[:idx], "/")
+	}
+	return path.Dir(filePath)
+}
+
+// containsWord reports whether word occurs in content as a whole identifier
+func containsWord(content, word string) bool {
+	for start := 0; ; {
+		idx := strings.Index(content[start:], word)
+		if idx < 0 {
+			return false
+		}
+		idx += start
+		end := idx + len(word)
+		if (idx == 0 || !isWordChar(content[idx-1])) && (end == len(content) || !isWordChar(content[end])) {
+			return true
+		}
+		start = end
+	}
+}
+
+func qualify(pkg, name string) string {
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isJVMSource checks if a path is a Java or Kotlin source file
+func isJVMSource(filePath string) bool {
+	ext := strings.ToLower(path.Ext(filePath))
+	return ext == ".java" || ext == ".kt"
+}
@@ -0,0 +1,60 @@
+package lockfile
+
+import (
+	"path"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies lockfile pairing in merged dependency output
+const Name = "lockfile"
+
+// DefaultRules pair the lockfiles of common package managers with their manifests
+var DefaultRules = []types.PairingRule{
+	{File: "package-lock.json", With: "package.json"},
+	{File: "npm-shrinkwrap.json", With: "package.json"},
+	{File: "yarn.lock", With: "package.json"},
+	{File: "pnpm-lock.yaml", With: "package.json"},
+	{File: "go.sum", With: "go.mod"},
+	{File: "Cargo.lock", With: "Cargo.toml"},
+	{File: "poetry.lock", With: "pyproject.toml"},
+	{File: "Pipfile.lock", With: "Pipfile"},
+	{File: "Gemfile.lock", With: "Gemfile"},
+	{File: "composer.lock", With: "composer.json"},
+}
+
+// Pair links each changed file named by a rule with the changed companion file in the same
+// directory, e.g. yarn.lock with package.json, so both land in the same partition; a lockfile
+// split from its manifest does not install or build.
+func Pair(files []types.FileChange, rules []types.PairingRule) []types.Dependency {
+	changed := make(map[string]bool)
+	for _, file := range files {
+		if file.IsChanged {
+			changed[file.Path] = true
+		}
+	}
+
+	var dependencies []types.Dependency
+	for _, file := range files {
+		if !file.IsChanged {
+			continue
+		}
+		for _, rule := range rules {
+			if path.Base(file.Path) != rule.File {
+				continue
+			}
+			companion := path.Join(path.Dir(file.Path), rule.With)
+			if !changed[companion] {
+				continue
+			}
+
+			// Edges in both directions make the pair a cycle, which the partitioner keeps together
+			dependencies = append(dependencies,
+				types.Dependency{From: file.Path, To: companion, Type: "lockfile", Strength: types.StrengthCritical, Context: rule.With},
+				types.Dependency{From: companion, To: file.Path, Type: "lockfile", Strength: types.StrengthCritical, Context: rule.File},
+			)
+			break
+		}
+	}
+	return dependencies
+}
@@ -0,0 +1,311 @@
+package projectgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies project graph dependencies in merged dependency output
+const Name = "project-graph"
+
+// Tool is a monorepo build tool that exports the dependencies between its projects
+type Tool string
+
+const (
+	ToolNx    Tool = "nx"
+	ToolTurbo Tool = "turbo"
+)
+
+// exportTimeout bounds a project graph export, which computes the graph when no cache is warm
+const exportTimeout = 2 * time.Minute
+
+// Project is a project of an Nx or Turborepo workspace
+type Project struct {
+	Name      string
+	Root      string   // Directory relative to the repository root, "." for a root project
+	DependsOn []string // Names of the projects it depends on directly
+}
+
+// Detect returns the tool configured at the repository root by nx.json or turbo.json, or an
+// empty Tool when there is none
+func Detect(root string) Tool {
+	for _, tool := range []Tool{ToolNx, ToolTurbo} {
+		if _, err := os.Stat(filepath.Join(root, string(tool)+".json")); err == nil {
+			return tool
+		}
+	}
+	return ""
+}
+
+// Export runs the tool in the checkout at root and returns its projects: 'nx graph --file' for
+// Nx, and for Turborepo a dry run of the build task, whose "^build" dependencies follow the
+// package graph
+func Export(ctx context.Context, root string, tool Tool) ([]Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	switch tool {
+	case ToolNx:
+		file, err := os.CreateTemp("", "pr-split-nx-graph-*.json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create graph file: %w", err)
+		}
+		file.Close()
+		defer os.Remove(file.Name())
+
+		if _, err := run(ctx, root, tool, "graph", "--file="+file.Name()); err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read nx graph: %w", err)
+		}
+		return parseNxGraph(data)
+	case ToolTurbo:
+		data, err := run(ctx, root, tool, "run", "build", "--dry-run=json")
+		if err != nil {
+			return nil, err
+		}
+		return parseTurboDryRun(data)
+	}
+	return nil, fmt.Errorf("unknown project graph tool: %s", tool)
+}
+
+// run runs the workspace's own copy of the tool from node_modules/.bin, falling back to one on
+// the PATH and then to npx, which never installs it
+func run(ctx context.Context, root string, tool Tool, args ...string) ([]byte, error) {
+	var cmd *exec.Cmd
+	local := filepath.Join(root, "node_modules", ".bin", string(tool))
+	if _, err := os.Stat(local); err == nil {
+		cmd = exec.CommandContext(ctx, local, args...)
+	} else if _, err := exec.LookPath(string(tool)); err == nil {
+		cmd = exec.CommandContext(ctx, string(tool), args...)
+	} else {
+		cmd = exec.CommandContext(ctx, "npx", append([]string{"--no-install", string(tool)}, args...)...)
+	}
+	cmd.Dir = root
+	// Keep Nx from starting a background daemon for a one-off export
+	cmd.Env = append(os.Environ(), "NX_DAEMON=false")
+
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s timed out after %s", tool, exportTimeout)
+		}
+		if exitError, ok := err.(*exec.ExitError); ok && len(exitError.Stderr) > 0 {
+			return nil, fmt.Errorf("%s failed: %s\nStderr: %s", tool, err, strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return nil, fmt.Errorf("%s failed: %w", tool, err)
+	}
+	return output, nil
+}
+
+// parseNxGraph reads the projects of an 'nx graph --file' export, leaving out external npm nodes
+func parseNxGraph(data []byte) ([]Project, error) {
+	var export struct {
+		Graph struct {
+			Nodes map[string]struct {
+				Name string `json:"name"`
+				Data struct {
+					Root string `json:"root"`
+				} `json:"data"`
+			} `json:"nodes"`
+			Dependencies map[string][]struct {
+				Target string `json:"target"`
+			} `json:"dependencies"`
+		} `json:"graph"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid nx graph: %w", err)
+	}
+
+	var projects []Project
+	for name, node := range export.Graph.Nodes {
+		project := Project{Name: name, Root: cleanRoot(node.Data.Root)}
+		for _, dependency := range export.Graph.Dependencies[name] {
+			if _, ok := export.Graph.Nodes[dependency.Target]; ok && dependency.Target != name {
+				project.DependsOn = append(project.DependsOn, dependency.Target)
+			}
+		}
+		projects = append(projects, project)
+	}
+	return sortProjects(projects), nil
+}
+
+// parseTurboDryRun reads the packages of a 'turbo run --dry-run=json' plan from its tasks, whose
+// dependencies are task IDs like "ui#build"
+func parseTurboDryRun(data []byte) ([]Project, error) {
+	var plan struct {
+		Tasks []struct {
+			Package      string   `json:"package"`
+			Directory    string   `json:"directory"`
+			Dependencies []string `json:"dependencies"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("invalid turbo dry run: %w", err)
+	}
+
+	byName := make(map[string]*Project)
+	var names []string
+	for _, task := range plan.Tasks {
+		project := byName[task.Package]
+		if project == nil {
+			project = &Project{Name: task.Package, Root: cleanRoot(task.Directory)}
+			byName[task.Package] = project
+			names = append(names, task.Package)
+		}
+		for _, taskID := range task.Dependencies {
+			name, _, _ := strings.Cut(taskID, "#")
+			if name != task.Package && !contains(project.DependsOn, name) {
+				project.DependsOn = append(project.DependsOn, name)
+			}
+		}
+	}
+
+	var projects []Project
+	for _, name := range names {
+		projects = append(projects, *byName[name])
+	}
+	return sortProjects(projects), nil
+}
+
+// Graph answers which project a file belongs to and whether one project depends on another
+type Graph struct {
+	projects []Project
+	reaches  map[string]map[string]bool // Project name to the projects it depends on, transitively
+}
+
+// NewGraph builds the graph of the exported projects
+func NewGraph(projects []Project) *Graph {
+	byName := make(map[string]Project)
+	for _, project := range projects {
+		byName[project.Name] = project
+	}
+
+	reaches := make(map[string]map[string]bool)
+	for _, project := range projects {
+		reached := make(map[string]bool)
+		queue := append([]string{}, project.DependsOn...)
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if reached[current] {
+				continue
+			}
+			reached[current] = true
+			queue = append(queue, byName[current].DependsOn...)
+		}
+		reaches[project.Name] = reached
+	}
+	return &Graph{projects: projects, reaches: reaches}
+}
+
+// Owner returns the name of the project whose root holds filePath most closely, or an empty
+// string for files outside every project
+func (g *Graph) Owner(filePath string) string {
+	owner, ownerRoot := "", ""
+	for _, project := range g.projects {
+		root := project.Root
+		if root != "." && !strings.HasPrefix(filePath, root+"/") {
+			continue
+		}
+		if owner == "" || ownerRoot == "." || (root != "." && len(root) > len(ownerRoot)) {
+			owner, ownerRoot = project.Name, root
+		}
+	}
+	return owner
+}
+
+// Dependencies links the changed files of projects that depend on each other, one edge per
+// project dependency, so partitions follow the project graph. Linking every file pair would
+// give millions of edges for a codemod across two large projects.
+func (g *Graph) Dependencies(changes []types.FileChange) []types.Dependency {
+	// First changed file of each project, in path order, stands for the project
+	var paths []string
+	for _, change := range changes {
+		if change.IsChanged {
+			paths = append(paths, change.Path)
+		}
+	}
+	sort.Strings(paths)
+	representative := make(map[string]string)
+	for _, filePath := range paths {
+		if owner := g.Owner(filePath); owner != "" && representative[owner] == "" {
+			representative[owner] = filePath
+		}
+	}
+
+	var dependencies []types.Dependency
+	for _, project := range g.projects {
+		from := representative[project.Name]
+		if from == "" {
+			continue
+		}
+		for _, required := range project.DependsOn {
+			if to := representative[required]; to != "" {
+				dependencies = append(dependencies, types.Dependency{
+					From:      from,
+					To:        to,
+					Type:      "project",
+					Strength:  types.StrengthStrong,
+					Context:   fmt.Sprintf("project %s depends on %s", project.Name, required),
+					Analyzers: []string{Name},
+				})
+			}
+		}
+	}
+	return dependencies
+}
+
+// Filter drops the dependencies other analyzers report between files of two projects the graph
+// says are unrelated, e.g. a fallback import scan matching a same-named module in another project
+func (g *Graph) Filter(dependencies []types.Dependency) (kept []types.Dependency, dropped int) {
+	for _, dependency := range dependencies {
+		from, to := g.Owner(dependency.From), g.Owner(dependency.To)
+		if from != "" && to != "" && from != to && !g.reaches[from][to] {
+			dropped++
+			continue
+		}
+		kept = append(kept, dependency)
+	}
+	return kept, dropped
+}
+
+// cleanRoot normalizes a project root relative to the repository root
+func cleanRoot(root string) string {
+	root = path.Clean(filepath.ToSlash(root))
+	if root == "" || root == "/" {
+		return "."
+	}
+	return strings.TrimPrefix(root, "./")
+}
+
+// sortProjects orders projects by name with sorted dependencies, so output is stable
+func sortProjects(projects []Project) []Project {
+	for i := range projects {
+		sort.Strings(projects[i].DependsOn)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects
+}
+
+// contains reports whether names holds name
+func contains(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package proto
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies the built-in protobuf analyzer
+const Name = "proto-analyzer"
+
+// Version of the built-in protobuf analyzer
+const Version = "1.0.0"
+
+// importPattern matches import "x.proto"; including the public and weak forms
+var importPattern = regexp.MustCompile(`^\s*import\s+(?:(public|weak)\s+)?"([^"]+)"\s*;`)
+
+// defaultImportRoots are tried after configured include paths, mirroring common protoc -I layouts
+var defaultImportRoots = []string{"proto", "protos", "api"}
+
+// Analyzer resolves .proto imports against the repository tree
+type Analyzer struct {
+	files       map[string]bool
+	importRoots []string
+}
+
+// New creates a new protobuf analyzer
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+// Extensions returns the file extensions handled by the analyzer
+func (a *Analyzer) Extensions() []string {
+	return []string{".proto"}
+}
+
+// Analyze finds import dependencies between .proto files
+func (a *Analyzer) Analyze(input types.PluginInput) (*types.PluginOutput, error) {
+	startTime := time.Now()
+
+	a.files = make(map[string]bool)
+	for _, file := range append(input.ChangedFiles, input.ProjectFiles...) {
+		a.files[file.Path] = true
+	}
+
+	a.importRoots = nil
+	for _, dir := range append(append([]string{}, input.IncludePaths...), defaultImportRoots...) {
+		a.importRoots = append(a.importRoots, path.Clean(strings.TrimPrefix(dir, "./")))
+	}
+
+	var dependencies []types.Dependency
+	analyzed := 0
+
+	for _, file := range input.ChangedFiles {
+		if file.ChangeType == types.ChangeTypeDelete {
+			continue
+		}
+		analyzed++
+
+		for lineNum, line := range strings.Split(file.Content, "\n") {
+			match := importPattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			target := a.resolve(match[2])
+			if target == "" || target == file.Path {
+				continue
+			}
+
+			// Weak imports may be absent at compile time, so they do not force ordering
+			strength := types.StrengthCritical
+			if match[1] == "weak" {
+				strength = types.StrengthWeak
+			}
+
+			dependencies = append(dependencies, types.Dependency{
+				From:     file.Path,
+				To:       target,
+				Type:     "import",
+				Strength: strength,
+				Line:     lineNum + 1,
+				Context:  strings.TrimSpace(line),
+			})
+		}
+	}
+
+	return &types.PluginOutput{
+		Dependencies: dependencies,
+		Errors:       []string{},
+		Metadata: types.PluginMetadata{
+			FilesAnalyzed: analyzed,
+			AnalysisTime:  time.Since(startTime).String(),
+			PluginName:    Name,
+			PluginVersion: Version,
+		},
+	}, nil
+}
+
+// resolve finds the file an import refers to. protoc resolves imports against its -I roots,
+// so those are tried first, then the repository root, then any single file with a matching suffix.
+func (a *Analyzer) resolve(importPath string) string {
+	for _, root := range append(append([]string{}, a.importRoots...), ".") {
+		candidate := path.Clean(path.Join(root, importPath))
+		if a.files[candidate] {
+			return candidate
+		}
+	}
+
+	match := ""
+	for file := range a.files {
+		if strings.HasSuffix(file, "/"+importPath) {
+			if match != "" {
+				return "" // Ambiguous without knowing the protoc invocation
+			}
+			match = file
+		}
+	}
+	return match
+}
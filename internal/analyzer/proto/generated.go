@@ -0,0 +1,57 @@
+package proto
+
+import (
+	"path"
+	"strings"
+
+	"pr-splitter-cli/internal/pathglob"
+	"pr-splitter-cli/internal/types"
+)
+
+// PairGenerated links changed schema files with their changed generated outputs so both land in
+// the same partition. Each rule's source glob may use * and **; the generated globs may reference
+// {dir} (the part matched by **) and {name} (the source file name without extension).
+func PairGenerated(files []types.FileChange, rules []types.GeneratedCodeRule) []types.Dependency {
+	var changed []string
+	for _, file := range files {
+		if file.IsChanged {
+			changed = append(changed, file.Path)
+		}
+	}
+
+	var dependencies []types.Dependency
+	seen := make(map[[2]string]bool)
+
+	for _, rule := range rules {
+		source := pathglob.Compile(rule.Source)
+		for _, schema := range changed {
+			match := source.FindStringSubmatch(schema)
+			if match == nil {
+				continue
+			}
+
+			replacer := strings.NewReplacer(
+				"{dir}", strings.TrimSuffix(match[1], "/"),
+				"{name}", strings.TrimSuffix(path.Base(schema), path.Ext(schema)),
+			)
+
+			for _, pattern := range rule.Generated {
+				generated := pathglob.Compile(path.Clean(replacer.Replace(pattern)))
+				for _, output := range changed {
+					if output == schema || !generated.MatchString(output) || seen[[2]string{schema, output}] {
+						continue
+					}
+					seen[[2]string{schema, output}] = true
+
+					// Edges in both directions make the pair a cycle, which the partitioner keeps together
+					dependencies = append(dependencies,
+						types.Dependency{From: output, To: schema, Type: "generated", Strength: types.StrengthCritical, Context: rule.Source},
+						types.Dependency{From: schema, To: output, Type: "generated", Strength: types.StrengthCritical, Context: pattern},
+					)
+				}
+			}
+		}
+	}
+
+	return dependencies
+}
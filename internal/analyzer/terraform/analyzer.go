@@ -0,0 +1,313 @@
+package terraform
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies the built-in Terraform analyzer
+const Name = "terraform-analyzer"
+
+// Version of the built-in Terraform analyzer
+const Version = "1.0.0"
+
+var (
+	// blockPattern matches the opening line of a top-level block and its labels
+	blockPattern = regexp.MustCompile(`^\s*(module|variable|output|resource|data|locals)\b\s*(?:"([^"]+)")?\s*(?:"([^"]+)")?\s*\{`)
+
+	// sourcePattern matches a module source argument
+	sourcePattern = regexp.MustCompile(`^\s*source\s*=\s*"([^"]+)"`)
+
+	// attributePattern matches an argument assignment, used for locals and tfvars entries
+	attributePattern = regexp.MustCompile(`^\s*([A-Za-z_][\w-]*)\s*=`)
+
+	// referencePattern matches var.x, local.x, module.x, data.t.n and t.n traversals
+	referencePattern = regexp.MustCompile(`\b(var|local|module|data|[a-z][a-z0-9]*_[a-z0-9_]+)\.([A-Za-z_][\w-]*)(?:\.([A-Za-z_][\w-]*))?`)
+
+	// commentPattern strips # and // line comments
+	commentPattern = regexp.MustCompile(`(^|\s)(#|//).*$`)
+)
+
+// Analyzer links Terraform module callers to their local modules and references to their declarations
+type Analyzer struct {
+	files map[string]types.FileChange
+}
+
+// New creates a new Terraform analyzer
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+// Extensions returns the file extensions handled by the analyzer
+func (a *Analyzer) Extensions() []string {
+	return []string{".tf", ".tfvars"}
+}
+
+// moduleDecls indexes the declarations of one Terraform module (a directory) by reference key
+type moduleDecls map[string]string
+
+// Analyze finds module and reference dependencies between Terraform files
+func (a *Analyzer) Analyze(input types.PluginInput) (*types.PluginOutput, error) {
+	startTime := time.Now()
+
+	a.files = make(map[string]types.FileChange)
+	for _, file := range append(input.ProjectFiles, input.ChangedFiles...) {
+		a.files[file.Path] = file
+	}
+
+	// Terraform evaluates every .tf file of a directory as one module
+	decls := make(map[string]moduleDecls)
+	for _, filePath := range a.sortedPaths() {
+		if path.Ext(filePath) != ".tf" {
+			continue
+		}
+		dir := path.Dir(filePath)
+		if decls[dir] == nil {
+			decls[dir] = make(moduleDecls)
+		}
+		for key := range declarations(a.files[filePath].Content) {
+			if _, exists := decls[dir][key]; !exists {
+				decls[dir][key] = filePath
+			}
+		}
+	}
+
+	var dependencies []types.Dependency
+	analyzed := 0
+
+	for _, file := range input.ChangedFiles {
+		if file.ChangeType == types.ChangeTypeDelete {
+			continue
+		}
+		analyzed++
+
+		seen := make(map[string]bool)
+		add := func(dep types.Dependency) {
+			key := dep.From + "\x00" + dep.To
+			if dep.From == dep.To || seen[key] {
+				return
+			}
+			seen[key] = true
+			dependencies = append(dependencies, dep)
+		}
+
+		dir := path.Dir(file.Path)
+		if path.Ext(file.Path) == ".tfvars" {
+			for _, dep := range a.tfvarsDependencies(file, decls[dir]) {
+				add(dep)
+			}
+			continue
+		}
+
+		for _, dep := range a.moduleDependencies(file) {
+			add(dep)
+		}
+		for _, dep := range referenceDependencies(file, decls[dir]) {
+			add(dep)
+		}
+	}
+
+	return &types.PluginOutput{
+		Dependencies: dependencies,
+		Errors:       []string{},
+		Metadata: types.PluginMetadata{
+			FilesAnalyzed: analyzed,
+			AnalysisTime:  time.Since(startTime).String(),
+			PluginName:    Name,
+			PluginVersion: Version,
+		},
+	}, nil
+}
+
+// moduleDependencies links a caller to every file of a local module it sources. The edges go both
+// ways: a module's new required variable and the caller passing it must ship together.
+func (a *Analyzer) moduleDependencies(file types.FileChange) []types.Dependency {
+	var dependencies []types.Dependency
+
+	inModule := false
+	depth := 0
+	for lineNum, line := range strings.Split(file.Content, "\n") {
+		line = commentPattern.ReplaceAllString(line, "")
+
+		if depth == 0 {
+			if match := blockPattern.FindStringSubmatch(line); match != nil && match[1] == "module" {
+				inModule = true
+			}
+		}
+
+		if inModule && depth == 1 {
+			if match := sourcePattern.FindStringSubmatch(line); match != nil && isLocalSource(match[1]) {
+				moduleDir := path.Clean(path.Join(path.Dir(file.Path), match[1]))
+				for _, target := range a.moduleFiles(moduleDir) {
+					dependencies = append(dependencies,
+						types.Dependency{From: file.Path, To: target, Type: "module-source", Strength: types.StrengthCritical, Line: lineNum + 1, Context: strings.TrimSpace(line)},
+						types.Dependency{From: target, To: file.Path, Type: "module-caller", Strength: types.StrengthCritical, Line: lineNum + 1, Context: strings.TrimSpace(line)},
+					)
+				}
+			}
+		}
+
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth <= 0 {
+			depth = 0
+			inModule = false
+		}
+	}
+
+	return dependencies
+}
+
+// referenceDependencies links var., local., module., data. and resource references to the file declaring them
+func referenceDependencies(file types.FileChange, decls moduleDecls) []types.Dependency {
+	var dependencies []types.Dependency
+
+	for lineNum, line := range strings.Split(file.Content, "\n") {
+		line = commentPattern.ReplaceAllString(line, "")
+		for _, match := range referencePattern.FindAllStringSubmatch(line, -1) {
+			key := match[1] + "." + match[2]
+			if match[1] == "data" {
+				key += "." + match[3]
+			}
+
+			target, ok := decls[key]
+			if !ok {
+				continue
+			}
+
+			dependencies = append(dependencies, types.Dependency{
+				From:     file.Path,
+				To:       target,
+				Type:     "reference",
+				Strength: types.StrengthCritical,
+				Line:     lineNum + 1,
+				Context:  strings.TrimSpace(line),
+			})
+		}
+	}
+
+	return dependencies
+}
+
+// tfvarsDependencies links each assignment in a .tfvars file to the variable declaration it sets
+func (a *Analyzer) tfvarsDependencies(file types.FileChange, decls moduleDecls) []types.Dependency {
+	var dependencies []types.Dependency
+
+	depth := 0
+	for lineNum, line := range strings.Split(file.Content, "\n") {
+		line = commentPattern.ReplaceAllString(line, "")
+
+		if depth == 0 {
+			if match := attributePattern.FindStringSubmatch(line); match != nil {
+				if target := a.findVariable(match[1], decls); target != "" {
+					dependencies = append(dependencies, types.Dependency{
+						From:     file.Path,
+						To:       target,
+						Type:     "tfvars",
+						Strength: types.StrengthStrong,
+						Line:     lineNum + 1,
+						Context:  strings.TrimSpace(line),
+					})
+				}
+			}
+		}
+
+		depth += strings.Count(line, "{") + strings.Count(line, "[") - strings.Count(line, "}") - strings.Count(line, "]")
+		if depth < 0 {
+			depth = 0
+		}
+	}
+
+	return dependencies
+}
+
+// findVariable looks up a variable in the tfvars directory, then anywhere it is declared exactly once
+func (a *Analyzer) findVariable(name string, decls moduleDecls) string {
+	key := "var." + name
+	if target, ok := decls[key]; ok {
+		return target
+	}
+
+	match := ""
+	for _, filePath := range a.sortedPaths() {
+		if path.Ext(filePath) != ".tf" {
+			continue
+		}
+		if _, ok := declarations(a.files[filePath].Content)[key]; ok {
+			if match != "" {
+				return "" // Ambiguous: the -var-file target is not known
+			}
+			match = filePath
+		}
+	}
+	return match
+}
+
+// declarations lists the reference keys a file declares, e.g. "var.region" or "aws_s3_bucket.logs"
+func declarations(content string) map[string]bool {
+	keys := make(map[string]bool)
+
+	inLocals := false
+	depth := 0
+	for _, line := range strings.Split(content, "\n") {
+		line = commentPattern.ReplaceAllString(line, "")
+
+		if depth == 0 {
+			if match := blockPattern.FindStringSubmatch(line); match != nil {
+				switch match[1] {
+				case "variable":
+					keys["var."+match[2]] = true
+				case "module":
+					keys["module."+match[2]] = true
+				case "data":
+					keys["data."+match[2]+"."+match[3]] = true
+				case "resource":
+					keys[match[2]+"."+match[3]] = true
+				case "locals":
+					inLocals = true
+				}
+			}
+		} else if inLocals && depth == 1 {
+			if match := attributePattern.FindStringSubmatch(line); match != nil {
+				keys["local."+match[1]] = true
+			}
+		}
+
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth <= 0 {
+			depth = 0
+			inLocals = false
+		}
+	}
+
+	return keys
+}
+
+// moduleFiles lists the known .tf files directly inside a module directory
+func (a *Analyzer) moduleFiles(dir string) []string {
+	var files []string
+	for _, filePath := range a.sortedPaths() {
+		if path.Dir(filePath) == dir && path.Ext(filePath) == ".tf" {
+			files = append(files, filePath)
+		}
+	}
+	return files
+}
+
+func (a *Analyzer) sortedPaths() []string {
+	paths := make([]string, 0, len(a.files))
+	for filePath := range a.files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// isLocalSource reports whether a module source is a path inside the repository
+func isLocalSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
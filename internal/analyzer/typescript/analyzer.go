@@ -0,0 +1,358 @@
+package typescript
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Name identifies the built-in TypeScript/JavaScript analyzer
+const Name = "ts-analyzer"
+
+// Version of the built-in TypeScript/JavaScript analyzer
+const Version = "1.0.0"
+
+// sourceExtensions are tried in order when an import omits the file extension
+var sourceExtensions = []string{".ts", ".tsx", ".d.ts", ".js", ".jsx", ".mts", ".cts", ".mjs", ".cjs"}
+
+// emittedExtensions maps the extension written in an import to the source extensions it compiles from
+var emittedExtensions = map[string][]string{
+	".js":  {".ts", ".tsx"},
+	".jsx": {".tsx"},
+	".mjs": {".mts"},
+	".cjs": {".cts"},
+}
+
+// Analyzer resolves TS/JS module dependencies without requiring Node.js
+type Analyzer struct {
+	files map[string]bool // every known project path, for resolution
+	paths *PathResolver   // tsconfig baseUrl/paths aliases
+}
+
+// importRef is a module specifier found in a source file
+type importRef struct {
+	specifier string
+	kind      string
+	strength  types.DependencyStrength
+	line      int
+}
+
+// New creates a new TypeScript/JavaScript analyzer
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+// Extensions returns the file extensions handled by the analyzer
+func (a *Analyzer) Extensions() []string {
+	return []string{".ts", ".tsx", ".js", ".jsx", ".mts", ".cts", ".mjs", ".cjs"}
+}
+
+// Analyze finds import, re-export, require and dynamic import dependencies in the input
+func (a *Analyzer) Analyze(input types.PluginInput) (*types.PluginOutput, error) {
+	startTime := time.Now()
+
+	a.paths = NewPathResolver(input.ProjectRoot)
+	a.files = make(map[string]bool)
+	for _, file := range append(input.ChangedFiles, input.ProjectFiles...) {
+		a.files[file.Path] = true
+	}
+
+	var dependencies []types.Dependency
+	analyzed := 0
+
+	for _, file := range input.ChangedFiles {
+		if file.ChangeType == types.ChangeTypeDelete {
+			continue
+		}
+		analyzed++
+
+		seen := make(map[string]bool)
+		for _, ref := range extractImports(scan(file.Content)) {
+			target := a.resolve(ref.specifier, file.Path)
+			if target == "" || target == file.Path {
+				continue
+			}
+
+			key := target + "|" + ref.kind
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			dependencies = append(dependencies, types.Dependency{
+				From:     file.Path,
+				To:       target,
+				Type:     ref.kind,
+				Strength: ref.strength,
+				Line:     ref.line,
+				Context:  ref.specifier,
+			})
+		}
+	}
+
+	return &types.PluginOutput{
+		Dependencies: dependencies,
+		Errors:       []string{},
+		Metadata: types.PluginMetadata{
+			FilesAnalyzed: analyzed,
+			AnalysisTime:  time.Since(startTime).String(),
+			PluginName:    Name,
+			PluginVersion: Version,
+		},
+	}, nil
+}
+
+// extractImports walks the token stream and collects module specifiers
+func extractImports(tokens []token) []importRef {
+	var refs []importRef
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.kind != tokenIdent || isMemberAccess(tokens, i) {
+			continue
+		}
+
+		switch t.value {
+		case "import":
+			if ref, ok := parseImport(tokens, i); ok {
+				refs = append(refs, ref)
+			}
+		case "export":
+			if ref, ok := parseReExport(tokens, i); ok {
+				refs = append(refs, ref)
+			}
+		case "require":
+			if ref, ok := parseRequire(tokens, i); ok {
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	return refs
+}
+
+// parseImport handles static imports, type-only imports, import-equals and dynamic import()
+func parseImport(tokens []token, i int) (importRef, bool) {
+	next := at(tokens, i+1)
+
+	// import("./module")
+	if isPunct(next, "(") {
+		if spec := at(tokens, i+2); spec.kind == tokenString {
+			return importRef{spec.value, "dynamic-import", types.StrengthModerate, spec.line}, true
+		}
+		return importRef{}, false
+	}
+
+	// import "./side-effect"
+	if next.kind == tokenString {
+		return importRef{next.value, "import", types.StrengthCritical, next.line}, true
+	}
+
+	typeOnly := isIdent(next, "type") && !isIdent(at(tokens, i+2), "from") && !isPunct(at(tokens, i+2), ",")
+	hasValueBinding := false
+	namedCount, typeNamedCount := 0, 0
+	depth := 0
+
+	for j := i + 1; j < len(tokens); j++ {
+		t := tokens[j]
+		switch {
+		case isPunct(t, "{"):
+			depth++
+		case isPunct(t, "}"):
+			depth--
+		case isPunct(t, ";"):
+			return importRef{}, false
+		case t.kind == tokenString:
+			return importRef{}, false
+		case isPunct(t, "=") && depth == 0:
+			// import x = require("./module")
+			if isIdent(at(tokens, j+1), "require") && isPunct(at(tokens, j+2), "(") {
+				if spec := at(tokens, j+3); spec.kind == tokenString {
+					return importRef{spec.value, "import", types.StrengthCritical, spec.line}, true
+				}
+			}
+			return importRef{}, false
+		case isIdent(t, "from") && depth == 0 && at(tokens, j+1).kind == tokenString:
+			spec := tokens[j+1]
+			allNamedTypes := !hasValueBinding && namedCount > 0 && namedCount == typeNamedCount
+			if typeOnly || allNamedTypes {
+				return importRef{spec.value, "type-import", types.StrengthModerate, spec.line}, true
+			}
+			return importRef{spec.value, "import", types.StrengthCritical, spec.line}, true
+		case (isIdent(t, "import") || isIdent(t, "export")) && j > i+1:
+			return importRef{}, false
+		case t.kind == tokenIdent && depth > 0:
+			// Count specifiers inside braces: "{ type A, B as C }"
+			prev := tokens[j-1]
+			if isPunct(prev, "{") || isPunct(prev, ",") {
+				namedCount++
+				if t.value == "type" && at(tokens, j+1).kind == tokenIdent && !isIdent(at(tokens, j+1), "as") {
+					typeNamedCount++
+				}
+			}
+		case t.kind == tokenIdent && depth == 0 && t.value != "type" && t.value != "as":
+			hasValueBinding = true // default or namespace binding
+		}
+	}
+
+	return importRef{}, false
+}
+
+// parseReExport handles "export * from", "export * as ns from" and "export { a } from"
+func parseReExport(tokens []token, i int) (importRef, bool) {
+	j := i + 1
+	typeOnly := false
+	if isIdent(at(tokens, j), "type") {
+		typeOnly = true
+		j++
+	}
+
+	switch {
+	case isPunct(at(tokens, j), "*"):
+		j++
+		if isIdent(at(tokens, j), "as") {
+			j += 2
+		}
+	case isPunct(at(tokens, j), "{"):
+		for depth := 0; j < len(tokens); j++ {
+			if isPunct(tokens[j], "{") {
+				depth++
+			} else if isPunct(tokens[j], "}") {
+				depth--
+				if depth == 0 {
+					j++
+					break
+				}
+			} else if isPunct(tokens[j], ";") {
+				return importRef{}, false
+			}
+		}
+	default:
+		return importRef{}, false
+	}
+
+	if !isIdent(at(tokens, j), "from") || at(tokens, j+1).kind != tokenString {
+		return importRef{}, false
+	}
+
+	spec := tokens[j+1]
+	if typeOnly {
+		return importRef{spec.value, "type-export", types.StrengthModerate, spec.line}, true
+	}
+	return importRef{spec.value, "export", types.StrengthCritical, spec.line}, true
+}
+
+// parseRequire handles require("x") and require.resolve("x")
+func parseRequire(tokens []token, i int) (importRef, bool) {
+	// Already reported as part of "import x = require(...)"
+	if isPunct(at(tokens, i-1), "=") && isIdent(at(tokens, i-3), "import") {
+		return importRef{}, false
+	}
+
+	if isPunct(at(tokens, i+1), "(") {
+		if spec := at(tokens, i+2); spec.kind == tokenString && isPunct(at(tokens, i+3), ")") {
+			return importRef{spec.value, "require", types.StrengthStrong, spec.line}, true
+		}
+	}
+
+	if isPunct(at(tokens, i+1), ".") && isIdent(at(tokens, i+2), "resolve") && isPunct(at(tokens, i+3), "(") {
+		if spec := at(tokens, i+4); spec.kind == tokenString {
+			return importRef{spec.value, "require-resolve", types.StrengthWeak, spec.line}, true
+		}
+	}
+
+	return importRef{}, false
+}
+
+// resolve maps a module specifier to a known project file, or "" if it is external
+func (a *Analyzer) resolve(specifier, fromFile string) string {
+	return Resolve(specifier, fromFile, a.files, a.paths)
+}
+
+// Imports returns the module specifiers a source file imports, re-exports or requires
+func Imports(content string) []string {
+	var specifiers []string
+	for _, ref := range extractImports(scan(content)) {
+		specifiers = append(specifiers, ref.specifier)
+	}
+	return specifiers
+}
+
+// Resolve maps a module specifier imported by fromFile to one of files, following tsconfig
+// path aliases when paths is set; it returns "" for external modules
+func Resolve(specifier, fromFile string, files map[string]bool, paths *PathResolver) string {
+	if isRelative(specifier) {
+		return ResolveFile(path.Join(path.Dir(fromFile), specifier), files)
+	}
+
+	for _, candidate := range paths.Candidates(specifier, fromFile) {
+		if resolved := ResolveFile(candidate, files); resolved != "" {
+			return resolved
+		}
+	}
+	return ""
+}
+
+// ResolveFile maps a module base path to a known file by trying the exact path,
+// emitted-extension mappings, added extensions and index files
+func ResolveFile(base string, files map[string]bool) string {
+	base = path.Clean(base)
+	if strings.HasPrefix(base, "../") {
+		return ""
+	}
+
+	if files[base] {
+		return base
+	}
+
+	// "./util.js" in TypeScript source refers to util.ts
+	ext := path.Ext(base)
+	for _, sourceExt := range emittedExtensions[ext] {
+		if candidate := strings.TrimSuffix(base, ext) + sourceExt; files[candidate] {
+			return candidate
+		}
+	}
+
+	for _, sourceExt := range sourceExtensions {
+		if files[base+sourceExt] {
+			return base + sourceExt
+		}
+	}
+
+	for _, sourceExt := range sourceExtensions {
+		if candidate := path.Join(base, "index"+sourceExt); files[candidate] {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// isRelative checks if a specifier is a relative path
+func isRelative(specifier string) bool {
+	return specifier == "." || specifier == ".." ||
+		strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../")
+}
+
+// isMemberAccess reports whether the token at i follows a '.', e.g. "foo.import"
+func isMemberAccess(tokens []token, i int) bool {
+	return i > 0 && isPunct(tokens[i-1], ".")
+}
+
+// at returns the token at i, or an empty punctuation token past the end
+func at(tokens []token, i int) token {
+	if i < 0 || i >= len(tokens) {
+		return token{kind: tokenPunct}
+	}
+	return tokens[i]
+}
+
+func isPunct(t token, value string) bool {
+	return t.kind == tokenPunct && t.value == value
+}
+
+func isIdent(t token, value string) bool {
+	return t.kind == tokenIdent && t.value == value
+}
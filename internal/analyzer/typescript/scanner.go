@@ -0,0 +1,201 @@
+package typescript
+
+// tokenKind classifies scanner tokens
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenPunct
+)
+
+// token is a lexical token relevant to import detection
+type token struct {
+	kind  tokenKind
+	value string // identifier text, unquoted string contents, or punctuation character
+	line  int
+}
+
+// scan tokenizes TS/JS source, skipping comments, template literals and regex literals.
+// It is not a full lexer: it only needs to be precise enough that import-like tokens
+// inside comments, strings and regexes are never reported.
+func scan(src string) []token {
+	var tokens []token
+	line := 1
+	i := 0
+
+	for i < len(src) {
+		c := src[i]
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			i += 2
+			for i < len(src) && !(src[i] == '*' && i+1 < len(src) && src[i+1] == '/') {
+				if src[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			i += 2
+
+		case c == '"' || c == '\'':
+			start, startLine := i+1, line
+			i++
+			for i < len(src) && src[i] != c && src[i] != '\n' {
+				if src[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			end := i
+			if end > len(src) {
+				end = len(src)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: src[start:end], line: startLine})
+			i++
+
+		case c == '`':
+			i = skipTemplate(src, i+1, &line)
+
+		case c == '/' && regexAllowed(tokens):
+			i = skipRegex(src, i+1)
+
+		case isIdentStart(c):
+			start := i
+			for i < len(src) && isIdentPart(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: src[start:i], line: line})
+
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(src) && (isIdentPart(src[i]) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: src[start:i], line: line})
+
+		default:
+			tokens = append(tokens, token{kind: tokenPunct, value: string(c), line: line})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// skipTemplate skips a template literal body, including nested ${} expressions
+func skipTemplate(src string, i int, line *int) int {
+	for i < len(src) {
+		switch src[i] {
+		case '\\':
+			i += 2
+			continue
+		case '\n':
+			*line++
+		case '`':
+			return i + 1
+		case '$':
+			if i+1 < len(src) && src[i+1] == '{' {
+				i = skipTemplateExpression(src, i+2, line)
+				continue
+			}
+		}
+		i++
+	}
+	return i
+}
+
+// skipTemplateExpression skips to the brace closing a ${ expression
+func skipTemplateExpression(src string, i int, line *int) int {
+	depth := 1
+	for i < len(src) && depth > 0 {
+		switch src[i] {
+		case '\n':
+			*line++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '`':
+			i = skipTemplate(src, i+1, line)
+			continue
+		case '"', '\'':
+			quote := src[i]
+			i++
+			for i < len(src) && src[i] != quote && src[i] != '\n' {
+				if src[i] == '\\' {
+					i++
+				}
+				i++
+			}
+		}
+		i++
+	}
+	return i
+}
+
+// skipRegex skips a regular expression literal body and its flags
+func skipRegex(src string, i int) int {
+	inClass := false
+	for i < len(src) && src[i] != '\n' {
+		switch src[i] {
+		case '\\':
+			i++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '/':
+			if !inClass {
+				i++
+				for i < len(src) && isIdentPart(src[i]) {
+					i++
+				}
+				return i
+			}
+		}
+		i++
+	}
+	return i
+}
+
+// regexAllowed reports whether a '/' at this point starts a regex rather than a division
+func regexAllowed(tokens []token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+
+	prev := tokens[len(tokens)-1]
+	switch prev.kind {
+	case tokenString:
+		return false
+	case tokenIdent:
+		switch prev.value {
+		case "return", "typeof", "instanceof", "in", "of", "new", "delete", "void", "throw", "case", "do", "else", "yield", "await":
+			return true
+		}
+		return false
+	default:
+		return prev.value != ")" && prev.value != "]" && prev.value != "}"
+	}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
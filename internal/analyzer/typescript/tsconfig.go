@@ -0,0 +1,274 @@
+package typescript
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// configNames are the project config files that may declare baseUrl/paths, in lookup order
+var configNames = []string{"tsconfig.json", "jsconfig.json"}
+
+// PathResolver maps non-relative specifiers to project paths using tsconfig/jsconfig baseUrl and paths.
+// The nearest config file above the importing file applies, so nested packages in monorepos work.
+type PathResolver struct {
+	projectRoot string
+	read        func(relPath string) ([]byte, error) // Reads a config by project path, from projectRoot when nil
+	dirConfigs  map[string]*pathConfig               // directory -> nearest config, nil if none
+}
+
+// pathConfig is the effective module resolution settings of one config file
+type pathConfig struct {
+	baseURL   string // project-relative directory, "" when baseUrl is unset
+	pathsBase string // project-relative directory that paths targets are relative to
+	mappings  []pathMapping
+}
+
+// pathMapping is one "paths" entry such as "@app/*": ["src/app/*"]
+type pathMapping struct {
+	prefix  string // text before the wildcard
+	suffix  string // text after the wildcard
+	exact   bool   // pattern has no wildcard
+	targets []string
+}
+
+// rawConfig is the subset of tsconfig.json that affects module resolution
+type rawConfig struct {
+	Extends         interface{} `json:"extends"`
+	CompilerOptions struct {
+		BaseURL *string             `json:"baseUrl"`
+		Paths   map[string][]string `json:"paths"`
+	} `json:"compilerOptions"`
+}
+
+// NewPathResolver creates a resolver for configs under projectRoot
+func NewPathResolver(projectRoot string) *PathResolver {
+	return &PathResolver{
+		projectRoot: projectRoot,
+		dirConfigs:  make(map[string]*pathConfig),
+	}
+}
+
+// NewPathResolverFrom creates a resolver that reads configs with read, e.g. from a commit
+// rather than the working tree
+func NewPathResolverFrom(read func(relPath string) ([]byte, error)) *PathResolver {
+	return &PathResolver{
+		projectRoot: ".",
+		read:        read,
+		dirConfigs:  make(map[string]*pathConfig),
+	}
+}
+
+// Candidates returns project-relative base paths (without extension resolution) a specifier may refer to
+func (r *PathResolver) Candidates(specifier, fromFile string) []string {
+	if r == nil || r.projectRoot == "" || isRelative(specifier) {
+		return nil
+	}
+
+	cfg := r.configFor(path.Dir(fromFile))
+	if cfg == nil {
+		return nil
+	}
+
+	var candidates []string
+	if mapping, wildcard, ok := cfg.match(specifier); ok {
+		for _, target := range mapping.targets {
+			substituted := strings.Replace(target, "*", wildcard, 1)
+			candidates = append(candidates, path.Join(cfg.pathsBase, substituted))
+		}
+	}
+
+	if cfg.baseURL != "" {
+		candidates = append(candidates, path.Join(cfg.baseURL, specifier))
+	}
+
+	return candidates
+}
+
+// match finds the paths entry with the longest matching prefix, as TypeScript does
+func (c *pathConfig) match(specifier string) (pathMapping, string, bool) {
+	for _, mapping := range c.mappings {
+		if mapping.exact {
+			if specifier == mapping.prefix {
+				return mapping, "", true
+			}
+			continue
+		}
+		if strings.HasPrefix(specifier, mapping.prefix) && strings.HasSuffix(specifier, mapping.suffix) &&
+			len(specifier) >= len(mapping.prefix)+len(mapping.suffix) {
+			return mapping, specifier[len(mapping.prefix) : len(specifier)-len(mapping.suffix)], true
+		}
+	}
+	return pathMapping{}, "", false
+}
+
+// configFor finds the nearest config file at or above a project-relative directory
+func (r *PathResolver) configFor(dir string) *pathConfig {
+	if cfg, cached := r.dirConfigs[dir]; cached {
+		return cfg
+	}
+
+	var cfg *pathConfig
+	for _, name := range configNames {
+		configPath := path.Join(dir, name)
+		if r.exists(configPath) {
+			cfg = r.load(configPath, 0)
+			break
+		}
+	}
+
+	if cfg == nil && dir != "." && dir != "/" && dir != "" {
+		cfg = r.configFor(path.Dir(dir))
+	}
+
+	r.dirConfigs[dir] = cfg
+	return cfg
+}
+
+// load reads a config file and the relative configs it extends
+func (r *PathResolver) load(configPath string, depth int) *pathConfig {
+	if depth > 5 {
+		return nil
+	}
+
+	data, err := r.readConfig(configPath)
+	if err != nil {
+		return nil
+	}
+
+	var raw rawConfig
+	if err := json.Unmarshal(stripJSONComments(data), &raw); err != nil {
+		return nil
+	}
+
+	configDir := path.Dir(configPath)
+	cfg := &pathConfig{}
+
+	// Only relative extends are followed; package extends (e.g. "@tsconfig/node18") rarely set paths
+	if parent, ok := raw.Extends.(string); ok && isRelative(parent) {
+		parentPath := path.Join(configDir, parent)
+		if !strings.HasSuffix(parentPath, ".json") {
+			parentPath += ".json"
+		}
+		if inherited := r.load(parentPath, depth+1); inherited != nil {
+			*cfg = *inherited
+		}
+	}
+
+	if raw.CompilerOptions.BaseURL != nil {
+		cfg.baseURL = path.Join(configDir, *raw.CompilerOptions.BaseURL)
+		cfg.pathsBase = cfg.baseURL
+	}
+
+	if raw.CompilerOptions.Paths != nil {
+		if raw.CompilerOptions.BaseURL == nil && cfg.baseURL == "" {
+			cfg.pathsBase = configDir
+		}
+		cfg.mappings = parseMappings(raw.CompilerOptions.Paths)
+	}
+
+	return cfg
+}
+
+// exists reports whether there is a config file at a project path
+func (r *PathResolver) exists(configPath string) bool {
+	if r.read != nil {
+		_, err := r.read(configPath)
+		return err == nil
+	}
+	_, err := os.Stat(r.abs(configPath))
+	return err == nil
+}
+
+// readConfig reads a config file by its project path
+func (r *PathResolver) readConfig(configPath string) ([]byte, error) {
+	if r.read != nil {
+		return r.read(configPath)
+	}
+	return os.ReadFile(r.abs(configPath))
+}
+
+// abs converts a project-relative path to an absolute filesystem path
+func (r *PathResolver) abs(relPath string) string {
+	return filepath.Join(r.projectRoot, filepath.FromSlash(relPath))
+}
+
+// parseMappings converts a paths object into mappings ordered by prefix length
+func parseMappings(paths map[string][]string) []pathMapping {
+	var mappings []pathMapping
+	for pattern, targets := range paths {
+		mapping := pathMapping{targets: targets}
+		if star := strings.Index(pattern, "*"); star >= 0 {
+			mapping.prefix = pattern[:star]
+			mapping.suffix = pattern[star+1:]
+		} else {
+			mapping.prefix = pattern
+			mapping.exact = true
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	sort.SliceStable(mappings, func(i, j int) bool {
+		if mappings[i].exact != mappings[j].exact {
+			return mappings[i].exact
+		}
+		if len(mappings[i].prefix) != len(mappings[j].prefix) {
+			return len(mappings[i].prefix) > len(mappings[j].prefix)
+		}
+		return mappings[i].prefix < mappings[j].prefix
+	})
+
+	return mappings
+}
+
+// stripJSONComments removes comments and trailing commas, which tsconfig files allow
+func stripJSONComments(data []byte) []byte {
+	var out []byte
+	inString := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			i--
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == ']' || c == '}':
+			// Drop a trailing comma before the closing bracket
+			trimmed := strings.TrimRight(string(out), " \t\r\n")
+			if strings.HasSuffix(trimmed, ",") {
+				out = append([]byte(trimmed[:len(trimmed)-1]), out[len(trimmed):]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
@@ -1,9 +1,16 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/partition"
 	"pr-splitter-cli/internal/splitter"
 	"pr-splitter-cli/internal/types"
 
@@ -12,12 +19,58 @@ import (
 
 // Command flags
 var (
-	targetBranch   string
-	branchPrefix   string
-	maxSize        int
-	maxDepth       int
-	configFile     string
-	nonInteractive bool
+	targetBranch       string
+	branchPrefix       string
+	maxSize            int
+	maxDepth           int
+	maxLines           int
+	configFile         string
+	profile            string
+	nonInteractive     bool
+	applyMode          string
+	rebasePlan         bool
+	since              string
+	commitPattern      string
+	updateExisting     bool
+	keepProgress       bool
+	autostash          bool
+	namespace          string
+	postSummary        bool
+	createPRs          bool
+	prDraft            bool
+	prLabels           []string
+	prMilestone        string
+	requestReviews     bool
+	includePaths       []string
+	planOutput         string
+	mermaidOutput      string
+	reportOutput       string
+	separateMechanical bool
+	groupByDirectory   bool
+	minStrength        string
+	coChange           bool
+	strategy           string
+	topology           string
+	splitHunks         []string
+	parallel           int
+	pushRemote         string
+	upstreamRemote     string
+	pushOptions        []string
+	noPush             bool
+	noVerify           bool
+	signCommits        bool
+	signOff            bool
+	coAuthors          bool
+	operatorAuthor     bool
+	partitionChecks    []string
+	checkPolicy        string
+	binaryFiles        string
+	includeTests       bool
+	projectGraph       bool
+	contextDepth       int
+	contextCacheTTL    time.Duration
+	strictValidation   bool
+	severities         map[string]string
 )
 
 // breakCmd represents the break command
@@ -48,46 +101,112 @@ func runBreakCommand(cmd *cobra.Command, args []string) error {
 	fmt.Printf("🚀 Breaking PR from branch: %s\n", sourceBranch)
 	fmt.Println()
 
+	unlock, err := lockRepository(git.NewClient().WithContext(cmd.Context()))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Create configuration from flags or interactive prompts
-	cfg, err := createConfiguration(sourceBranch)
+	cfg, err := createConfiguration(cmd.Context(), sourceBranch)
 	if err != nil {
 		return fmt.Errorf("failed to create configuration: %w", err)
 	}
+	resolveBranchNamespace(cfg)
+	if cfg.KeepProgress && cfg.UpdateExisting {
+		return fmt.Errorf("--keep-progress cannot be combined with --update, which restores previous branches on failure")
+	}
+	if err := config.ValidateSeverities(cfg.ValidationSeverity); err != nil {
+		return err
+	}
+
+	// Updating a published split force-pushes its branches
+	var opts splitter.Options
+	if cfg.UpdateExisting && !cfg.NoPush {
+		gitClient := git.NewClient().WithContext(cmd.Context())
+		opts.ForcePushCheck = forcePushCheck(gitClient, cfg, !nonInteractive)
+	}
 
 	// Create splitter and run the process with configuration
-	s := splitter.New()
-	result, err := s.SplitWithConfig(sourceBranch, cfg)
+	result, err := splitter.NewWithOptions(opts).SplitWithConfig(cmd.Context(), sourceBranch, cfg)
+	if errors.Is(err, partition.ErrUserAborted) {
+		fmt.Printf("👋 Split cancelled (%v); no branches were kept\n", err)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to split PR: %w", err)
 	}
 
-	// Display final results
+	publishSplit(cfg, result)
+	return nil
+}
+
+// publishSplit shows the result of a split and opens PRs, writes descriptions and posts the
+// summary as configured
+func publishSplit(cfg *types.Config, result *types.SplitResult) {
 	displayBreakResults(result)
 
-	return nil
+	// Nothing is on the remote to open PRs for or summarize yet
+	if cfg.NoPush {
+		if cfg.CreatePRs || cfg.PostSummary {
+			fmt.Println("⚠️  Skipping partition PRs and the split summary: branches were not pushed")
+		}
+		return
+	}
+
+	gitClient := git.NewClient()
+	prs := make(map[int]int)
+	if cfg.CreatePRs {
+		var err error
+		prs, err = createPartitionPRs(gitClient, cfg, result)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: Could not open all partition PRs: %v\n", err)
+		}
+	}
+	if err := writePartitionDescriptions(gitClient, result, prs); err != nil {
+		fmt.Printf("⚠️  Warning: Could not write PR descriptions: %v\n", err)
+	}
+
+	if cfg.PostSummary {
+		if err := postSplitSummary(gitClient, cfg, result.RunID, result.SourceBranch, result.CreatedBranches); err != nil {
+			fmt.Printf("⚠️  Warning: Could not post split summary: %v\n", err)
+		}
+	}
 }
 
 // createConfiguration creates config from flags or interactive prompts
-func createConfiguration(sourceBranch string) (*types.Config, error) {
+func createConfiguration(ctx context.Context, sourceBranch string) (*types.Config, error) {
 	// If config file is specified, try to load it first
-	if configFile != "" {
-		cfg, err := config.LoadFromFile(configFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load config file: %w", err)
+	// Flags override the environment, the repository config file, the user config file and the
+	// defaults, in that order
+	cfg, sources, err := config.Load(repoConfigPath(git.NewClient().WithContext(ctx), configFile), profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+	if len(sources) > 0 {
+		fmt.Printf("⚙️  Configuration from %s\n", strings.Join(sources, ", "))
+		if selected := profileName(); selected != "" {
+			fmt.Printf("🎛️  Using config profile: %s\n", selected)
 		}
-		// Override with any explicit flags
 		overrideConfigFromFlags(cfg)
 		return cfg, nil
 	}
 
 	// Check if multiple flags were provided (non-interactive mode)
 	if hasMultipleFlags() {
-		return createConfigFromFlags(), nil
+		overrideConfigFromFlags(cfg)
+		return cfg, nil
 	}
 
 	// Interactive mode, but use smart analysis with preferred target if specified
 	s := splitter.New()
-	return s.GetSmartConfiguration(sourceBranch, targetBranch)
+	cfg, err = s.GetSmartConfiguration(ctx, sourceBranch, targetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	applyBehaviorFlags(cfg)
+	return cfg, nil
 }
 
 // hasMultipleFlags checks if enough flags were set to warrant non-interactive mode
@@ -110,20 +229,12 @@ func hasMultipleFlags() bool {
 	return nonInteractive || flagCount >= 2
 }
 
-// createConfigFromFlags creates configuration from command-line flags
-func createConfigFromFlags() *types.Config {
-	cfg := &types.Config{
-		MaxFilesPerPartition: config.ConfigDefaults.MaxFilesPerPartition,
-		MaxPartitions:        config.ConfigDefaults.MaxPartitions,
-		BranchPrefix:         config.ConfigDefaults.BranchPrefix,
-		Strategy:             config.ConfigDefaults.Strategy,
-		TargetBranch:         config.ConfigDefaults.TargetBranch,
+// profileName returns the config profile selected with --profile or PR_SPLIT_PROFILE
+func profileName() string {
+	if profile != "" {
+		return profile
 	}
-
-	// Override with provided flags
-	overrideConfigFromFlags(cfg)
-
-	return cfg
+	return os.Getenv(config.ProfileEnvVar)
 }
 
 // overrideConfigFromFlags applies command-line flags to configuration
@@ -141,6 +252,156 @@ func overrideConfigFromFlags(cfg *types.Config) {
 	if maxDepth > 0 {
 		cfg.MaxPartitions = maxDepth * 2 // Simple heuristic
 	}
+	if maxLines > 0 {
+		cfg.MaxLinesPerPartition = maxLines
+	}
+
+	applyBehaviorFlags(cfg)
+}
+
+// applyBehaviorFlags applies flags that are never prompted for interactively
+func applyBehaviorFlags(cfg *types.Config) {
+	if applyMode != "" {
+		cfg.ApplyMode = applyMode
+	}
+	if rebasePlan {
+		cfg.RebasePlan = true
+	}
+	if since != "" {
+		cfg.Since = since
+	}
+	if commitPattern != "" {
+		cfg.CommitPattern = commitPattern
+	}
+	if updateExisting {
+		cfg.UpdateExisting = true
+	}
+	if keepProgress {
+		cfg.KeepProgress = true
+	}
+	if namespace != "" {
+		cfg.BranchNamespace = namespace
+	}
+	if postSummary {
+		cfg.PostSummary = true
+	}
+	if createPRs {
+		cfg.CreatePRs = true
+	}
+	if prDraft {
+		cfg.PRDraft = true
+	}
+	if len(prLabels) > 0 {
+		cfg.PRLabels = append(cfg.PRLabels, prLabels...)
+	}
+	if prMilestone != "" {
+		cfg.PRMilestone = prMilestone
+	}
+	if requestReviews {
+		cfg.RequestReviews = true
+	}
+	if len(includePaths) > 0 {
+		cfg.IncludePaths = append(cfg.IncludePaths, includePaths...)
+	}
+	if planOutput != "" {
+		cfg.PlanOutput = planOutput
+	}
+	if mermaidOutput != "" {
+		cfg.MermaidOutput = mermaidOutput
+	}
+	if reportOutput != "" {
+		cfg.ReportOutput = reportOutput
+	}
+	if separateMechanical {
+		cfg.SeparateMechanical = true
+	}
+	if groupByDirectory {
+		cfg.GroupByDirectory = true
+	}
+	if coChange {
+		cfg.CoChange = true
+	}
+	if strategy != "" {
+		cfg.Strategy = strategy
+	}
+	if topology != "" {
+		cfg.Topology = topology
+	}
+	if len(splitHunks) > 0 {
+		cfg.SplitHunks = append(cfg.SplitHunks, splitHunks...)
+	}
+	if minStrength != "" {
+		cfg.MinDependencyStrength = types.DependencyStrength(strings.ToUpper(minStrength))
+	}
+	if parallel > 0 {
+		cfg.Parallelism = parallel
+	}
+	if pushRemote != "" {
+		cfg.PushRemote = pushRemote
+	}
+	if upstreamRemote != "" {
+		cfg.UpstreamRemote = upstreamRemote
+	}
+	if len(pushOptions) > 0 {
+		cfg.PushOptions = append(cfg.PushOptions, pushOptions...)
+	}
+	if noPush {
+		cfg.NoPush = true
+	}
+	if noVerify {
+		cfg.NoVerify = true
+	}
+	if signCommits {
+		cfg.SignCommits = true
+	}
+	if signOff {
+		cfg.SignOff = true
+	}
+	if coAuthors {
+		cfg.CoAuthorTrailers = true
+	}
+	if operatorAuthor {
+		cfg.OperatorAuthor = true
+	}
+	if len(partitionChecks) > 0 {
+		cfg.PartitionChecks = append(cfg.PartitionChecks, partitionChecks...)
+	}
+	if checkPolicy != "" {
+		cfg.CheckPolicy = checkPolicy
+	}
+	if binaryFiles != "" {
+		cfg.BinaryFiles = binaryFiles
+	}
+	if !includeTests {
+		cfg.ExcludeTests = true
+	}
+	if !projectGraph {
+		cfg.SkipProjectGraph = true
+	}
+	if contextDepth != 0 {
+		cfg.ContextDepth = contextDepth
+	}
+	if contextCacheTTL != 0 {
+		cfg.ContextCacheTTL = contextCacheTTL
+	}
+	if strictValidation {
+		cfg.StrictValidation = true
+	}
+	if len(severities) > 0 {
+		if cfg.ValidationSeverity == nil {
+			cfg.ValidationSeverity = make(types.Severities)
+		}
+		for validationType, status := range config.ParseSeverities(severities) {
+			cfg.ValidationSeverity[validationType] = status
+		}
+	}
+}
+
+// resolveBranchNamespace fills in the {user} placeholder from the git identity
+func resolveBranchNamespace(cfg *types.Config) {
+	if strings.Contains(cfg.BranchNamespace, "{user}") {
+		cfg.BranchNamespace = config.ResolveNamespace(cfg.BranchNamespace, git.NewClient().GetUserSlug())
+	}
 }
 
 // displayBreakResults shows the final results to the user
@@ -157,13 +418,34 @@ func displayBreakResults(result *types.SplitResult) {
 
 	fmt.Println()
 	fmt.Println("📝 Next Steps:")
+	if result.Config.NoPush && len(result.CreatedBranches) > 0 {
+		fmt.Println("1. Inspect the local branches, e.g. 'git log --stat " + result.CreatedBranches[0] + "'")
+		fmt.Println("2. Push them with 'git push --set-upstream " + pushRemoteName(result.Config) + " <branch>' when ready,")
+		fmt.Println("   or delete them with 'pr-split rollback " + result.Config.BranchPrefix + "'")
+		return
+	}
 	if len(result.CreatedBranches) > 0 {
 		fmt.Printf("1. Create GitHub PR: %s → %s\n", result.CreatedBranches[0], result.TargetBranch)
 		if len(result.CreatedBranches) > 1 {
 			fmt.Println("2. After merge, create subsequent PRs in dependency order")
 		}
-		fmt.Printf("3. Use 'pr-split rollback %s' to cleanup when done\n", result.Config.BranchPrefix)
+		rollback := "pr-split rollback " + result.Config.BranchPrefix
+		if result.Config.BranchNamespace != "" {
+			rollback += " --namespace " + result.Config.BranchNamespace
+		}
+		if remote := pushRemoteName(result.Config); remote != git.DefaultRemote {
+			rollback += " --remote " + remote
+		}
+		fmt.Printf("3. Use '%s' to cleanup when done\n", rollback)
+	}
+}
+
+// pushRemoteName returns the remote a split pushes its branches to
+func pushRemoteName(cfg types.Config) string {
+	if cfg.PushRemote != "" {
+		return cfg.PushRemote
 	}
+	return git.DefaultRemote
 }
 
 func init() {
@@ -172,6 +454,53 @@ func init() {
 	breakCmd.Flags().StringVarP(&branchPrefix, "prefix", "p", "", "Branch prefix (default \"pr-split\")")
 	breakCmd.Flags().IntVarP(&maxSize, "max-size", "s", 0, "Maximum files per partition (default 15)")
 	breakCmd.Flags().IntVarP(&maxDepth, "max-depth", "d", 0, "Maximum dependency depth (default 10)")
+	breakCmd.Flags().IntVar(&maxLines, "max-lines", 0, "Split partitions with more changed lines than this (default no limit)")
 	breakCmd.Flags().StringVarP(&configFile, "config", "c", "", "Config file path")
+	breakCmd.Flags().StringVar(&profile, "profile", "", "Named profile of the config file to use, e.g. frontend or hotfix")
 	breakCmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "Run without prompts using defaults")
+	breakCmd.Flags().BoolVar(&autostash, "autostash", false, "No longer needed: branches are built without touching the checkout")
+	breakCmd.Flags().MarkDeprecated("autostash", "local changes no longer get in the way; branches are built without touching the checkout")
+	breakCmd.Flags().BoolVar(&updateExisting, "update", false, "Reset branches from a previous split of yours to the new plan (force-with-lease) instead of failing")
+	breakCmd.Flags().BoolVar(&keepProgress, "keep-progress", false, "On failure keep the branches already pushed so 'pr-split resume' can continue")
+	breakCmd.Flags().StringVar(&pushRemote, "push-remote", "", "Remote to push partition branches to, e.g. your fork (default \"origin\")")
+	breakCmd.Flags().StringVar(&upstreamRemote, "upstream", "", "Remote whose target branch partitions are based on and PRs are opened against, e.g. \"upstream\"")
+	breakCmd.Flags().BoolVar(&noPush, "no-push", false, "Create and validate branches locally without pushing them")
+	breakCmd.Flags().BoolVar(&noVerify, "no-verify", false, "Skip git hooks (pre-push) on the branches the split pushes")
+	breakCmd.Flags().BoolVar(&signCommits, "sign", false, "Sign partition commits with your GPG or SSH key (on by default with commit.gpgSign)")
+	breakCmd.Flags().BoolVar(&signOff, "signoff", false, "Add a Signed-off-by trailer for you to every partition commit (DCO)")
+	breakCmd.Flags().BoolVar(&coAuthors, "co-authors", false, "Add Co-authored-by trailers for the authors of each partition's source commits")
+	breakCmd.Flags().BoolVar(&operatorAuthor, "operator-author", false, "Author partition commits as yourself instead of the source commits' main author")
+	breakCmd.Flags().BoolVar(&strictValidation, "strict", false, "Treat validation warnings (unpushed branches, oversized partitions) as failures")
+	breakCmd.Flags().StringToStringVar(&severities, "severity", nil, "Override the status of failing or warning checks of a validation type, e.g. TYPE_CHECK=FAIL or CONFLICT_PREDICTION=PASS")
+	breakCmd.Flags().StringArrayVar(&partitionChecks, "check", nil, "Command run in a worktree of each partition branch before it is pushed, e.g. \"go build ./...\" (repeatable)")
+	breakCmd.Flags().BoolVar(&includeTests, "include-tests", true, "Split changed test files along with the source files they test (--include-tests=false leaves them out)")
+	breakCmd.Flags().BoolVar(&projectGraph, "project-graph", true, "Read dependencies between projects from the Nx or Turborepo project graph when nx.json or turbo.json exists")
+	breakCmd.Flags().IntVar(&contextDepth, "context-depth", 0, "Levels of imports from the changed files whose files are sent to plugins that use project context, -1 for every project file (default 3)")
+	breakCmd.Flags().DurationVar(&contextCacheTTL, "context-cache-ttl", 0, "Reuse the project context collected for the same source commit for this long, e.g. 1h, negative to always collect it again (default 15m)")
+	breakCmd.Flags().StringVar(&binaryFiles, "binary-files", "", "Where changed binary files go: directory (with the partition holding their directory) or separate (their own partition) (default \"directory\")")
+	breakCmd.Flags().StringVar(&checkPolicy, "check-policy", "", "When a partition check fails: abort (roll back) or continue (mark the partition and push it) (default \"abort\")")
+	breakCmd.Flags().StringArrayVar(&pushOptions, "push-option", nil, "Push option sent with every partition push, e.g. \"ci.skip\" (repeatable)")
+	breakCmd.Flags().IntVar(&parallel, "parallel", 0, "Build and push up to this many independent partition branches at once (default 4)")
+	breakCmd.Flags().BoolVar(&rebasePlan, "rebase-plan", false, "Base partitions on the current target tip instead of the pinned merge-base")
+	breakCmd.Flags().StringVar(&since, "since", "", "Only split the files changed by the source branch's commits after this commit")
+	breakCmd.Flags().StringVar(&commitPattern, "grep", "", "Only split the files changed by commits whose message matches this regular expression, e.g. a ticket key like \"PROJ-123\"")
+	breakCmd.Flags().StringSliceVar(&includePaths, "include-path", nil, "Header search directory for C/C++ analysis (repeatable)")
+	breakCmd.Flags().StringSliceVar(&splitHunks, "split-hunks", nil, "Glob of shared files (e.g. \"**/index.ts\") to split across partitions by hunk (repeatable)")
+	breakCmd.Flags().StringVar(&topology, "topology", "", "Branch topology: linear, independent or dag (default \"linear\")")
+	breakCmd.Flags().StringVar(&strategy, "strategy", "", "Grouping strategy: dependency-first, directory, ownership, semantic, min-cut or workspace (default \"dependency-first\")")
+	breakCmd.Flags().BoolVar(&coChange, "co-change", false, "Group files that historically change together (mines git log)")
+	breakCmd.Flags().StringVar(&minStrength, "min-strength", "", "Ignore dependencies weaker than WEAK, MODERATE, STRONG or CRITICAL")
+	breakCmd.Flags().BoolVar(&groupByDirectory, "group-by-directory", false, "Split large dependency levels by directory instead of truncating them")
+	breakCmd.Flags().BoolVar(&separateMechanical, "separate-mechanical", false, "Put renames, moves and formatting-only changes in their own partitions")
+	breakCmd.Flags().StringVar(&mermaidOutput, "mermaid-out", "", "Write the partition dependency stack as a Mermaid diagram (markdown)")
+	breakCmd.Flags().StringVar(&planOutput, "plan-out", "", "Write the partition plan as JSON before approval (see 'pr-split plan diff')")
+	breakCmd.Flags().StringVar(&reportOutput, "report-out", "", "Write every validation result with its details as JSON, or Markdown for a .md file")
+	breakCmd.Flags().BoolVar(&createPRs, "create-prs", false, "Open a PR per partition with a generated description (needs GITHUB_TOKEN)")
+	breakCmd.Flags().BoolVar(&prDraft, "draft", false, "Open partition PRs as drafts (with --create-prs)")
+	breakCmd.Flags().StringSliceVar(&prLabels, "label", nil, "Label for partition PRs, e.g. \"stack:{index}/{total}\" (repeatable)")
+	breakCmd.Flags().StringVar(&prMilestone, "milestone", "", "Add partition PRs to the open milestone with this title")
+	breakCmd.Flags().BoolVar(&requestReviews, "request-reviews", false, "Request reviews on partition PRs from CODEOWNERS and the reviewers config")
+	breakCmd.Flags().BoolVar(&postSummary, "post-summary", false, "Post a split summary comment on the source branch's PR")
+	breakCmd.Flags().StringVar(&namespace, "namespace", "", "Create branches under a namespace, e.g. \"split/{user}\"")
+	breakCmd.Flags().StringVar(&applyMode, "apply-mode", "", "How changes are applied: checkout or patch (default \"checkout\")")
 }
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+	"pr-splitter-cli/internal/types"
+
+	"github.com/spf13/cobra"
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup [branch-prefix]",
+	Short: "Delete stale split branches whose PRs are merged or closed",
+	Long: `Find split branches that nobody is working on any more and offer to delete them.
+
+Candidates are the branches matching the prefix (default "pr-split") plus every
+branch recorded in the run state. A branch is stale when its last commit is
+older than --older-than and its latest PR is merged or closed. Branches without
+a PR, or when GitHub cannot be reached, are stale only when their changes are
+already in the target branch. Protected branches are never deleted.
+
+Examples:
+  pr-split cleanup                          Stale pr-split branches older than 30 days
+  pr-split cleanup --older-than 2w          Anything untouched for two weeks
+  pr-split cleanup auth-split --dry-run     Preview for a custom prefix
+  pr-split cleanup --namespace split/{user}
+                                            Only branches in your namespace`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCleanup,
+}
+
+var (
+	cleanupOlderThan string
+	cleanupNamespace string
+	cleanupTarget    string
+	cleanupRemote    string
+	cleanupProtect   []string
+	cleanupDryRun    bool
+)
+
+// staleBranch is a split branch considered for cleanup
+type staleBranch struct {
+	name   string
+	local  bool
+	remote bool
+	age    time.Duration
+	reason string // Why the branch counts as done, e.g. "#42 merged"
+}
+
+func runCleanup(cmd *cobra.Command, args []string) error {
+	prefix := config.ConfigDefaults.BranchPrefix
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+
+	maxAge, err := parseAge(cleanupOlderThan)
+	if err != nil {
+		return err
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	gitClient.SetRemote(cleanupRemote)
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+	if cleanupNamespace != "" {
+		prefix = namespacedPrefix(gitClient, cleanupNamespace, prefix)
+	}
+
+	originalBranch, err := gitClient.GetCurrentBranch()
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	if err := gitClient.FetchRemote(); err != nil {
+		fmt.Printf("⚠️  Warning: %v; using the last fetched remote branches\n", err)
+	}
+
+	fmt.Printf("🔍 Searching for split branches older than %s (prefix %s)\n\n", cleanupOlderThan, prefix)
+	candidates, err := collectCleanupCandidates(gitClient, prefix)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	candidates = keepOldBranches(gitClient, candidates, cutoff)
+	if len(candidates) == 0 {
+		fmt.Printf("✅ No split branches older than %s\n", cleanupOlderThan)
+		return nil
+	}
+
+	stale := keepFinishedBranches(gitClient, candidates)
+	if len(stale) == 0 {
+		fmt.Println("✅ No stale split branches: every old branch still has an open PR or unmerged changes")
+		return nil
+	}
+
+	var localBranches, remoteBranches []string
+	fmt.Printf("📋 Found %d stale branches:\n", len(stale))
+	for _, branch := range stale {
+		var where []string
+		if branch.local {
+			where = append(where, "local")
+			localBranches = append(localBranches, branch.name)
+		}
+		if branch.remote {
+			where = append(where, gitClient.Remote())
+			remoteBranches = append(remoteBranches, branch.name)
+		}
+		fmt.Printf("  🔸 %-40s %s, last commit %d days ago (%s)\n",
+			branch.name, branch.reason, int(branch.age.Hours()/24), strings.Join(where, ", "))
+	}
+	fmt.Println()
+
+	if cleanupDryRun {
+		fmt.Printf("🔍 DRY RUN: Would delete %d local and %d remote branches\n", len(localBranches), len(remoteBranches))
+		fmt.Println("Run without --dry-run to actually delete these branches")
+		return nil
+	}
+
+	if !promptForConfirmation(fmt.Sprintf("Delete %d local and %d remote branches?", len(localBranches), len(remoteBranches))) {
+		fmt.Println("❌ Cleanup cancelled by user")
+		return nil
+	}
+
+	unlock, err := lockRepository(gitClient)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return performRollback(gitClient, localBranches, remoteBranches, originalBranch)
+}
+
+// collectCleanupCandidates lists the local and remote branches matching the prefix or recorded
+// by a run, without protected branches
+func collectCleanupCandidates(gitClient *git.Client, prefix string) ([]*staleBranch, error) {
+	local, err := gitClient.GetLocalBranches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local branches: %w", err)
+	}
+	remoteRefs, err := gitClient.GetRemoteBranches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote branches: %w", err)
+	}
+	var remote []string
+	for _, ref := range remoteRefs {
+		if branch, ok := strings.CutPrefix(ref, gitClient.Remote()+"/"); ok {
+			remote = append(remote, branch)
+		}
+	}
+
+	recorded := make(map[string]bool)
+	if _, st, err := loadState(gitClient); err != nil {
+		fmt.Printf("⚠️  Warning: Could not read run state: %v\n", err)
+	} else {
+		for _, run := range st.Runs {
+			for _, branch := range run.Branches {
+				recorded[branch.Name] = true
+			}
+		}
+	}
+
+	protected, err := protectedBranches(gitClient, cleanupTarget, cleanupProtect)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*staleBranch)
+	add := func(name string, isRemote bool) {
+		// A namespace limits cleanup to your own branches, even ones recorded by a run
+		if !strings.HasPrefix(name, prefix) && (!recorded[name] || cleanupNamespace != "") {
+			return
+		}
+		if protected.Protects(name) {
+			return
+		}
+		branch, ok := byName[name]
+		if !ok {
+			branch = &staleBranch{name: name}
+			byName[name] = branch
+		}
+		if isRemote {
+			branch.remote = true
+		} else {
+			branch.local = true
+		}
+	}
+	for _, name := range local {
+		add(name, false)
+	}
+	for _, name := range remote {
+		add(name, true)
+	}
+
+	candidates := make([]*staleBranch, 0, len(byName))
+	for _, branch := range byName {
+		candidates = append(candidates, branch)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].name < candidates[j].name })
+	return candidates, nil
+}
+
+// keepOldBranches drops branches with a commit after the cutoff, locally or on the remote
+func keepOldBranches(gitClient *git.Client, candidates []*staleBranch, cutoff time.Time) []*staleBranch {
+	var old []*staleBranch
+	for _, branch := range candidates {
+		var refs []string
+		if branch.local {
+			refs = append(refs, branch.name)
+		}
+		if branch.remote {
+			refs = append(refs, gitClient.Remote()+"/"+branch.name)
+		}
+
+		var latest time.Time
+		for _, ref := range refs {
+			owner, err := gitClient.GetBranchOwner(ref)
+			if err != nil {
+				fmt.Printf("⚠️  Warning: Skipping %s: %v\n", ref, err)
+				latest = time.Now()
+				break
+			}
+			if owner.CommittedAt.After(latest) {
+				latest = owner.CommittedAt
+			}
+		}
+		if latest.After(cutoff) {
+			continue
+		}
+		branch.age = time.Since(latest)
+		old = append(old, branch)
+	}
+	return old
+}
+
+// keepFinishedBranches keeps branches whose latest PR is merged or closed, or that have no
+// PR and are already merged into the target
+func keepFinishedBranches(gitClient *git.Client, candidates []*staleBranch) []*staleBranch {
+	names := make([]string, len(candidates))
+	for i, branch := range candidates {
+		names[i] = branch.name
+	}
+
+	pulls := make([]*provider.PullRequest, len(candidates))
+	errs := make([]error, len(candidates))
+	github, err := newGitHubClient(gitClient, &types.Config{PushRemote: cleanupRemote})
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not connect to GitHub: %v; only branches merged into %s count as stale\n", err, cleanupTarget)
+	} else {
+		pulls, errs = github.FindLatestPullRequests(names)
+	}
+
+	targets := []string{cleanupTarget, gitClient.RemoteRef(cleanupTarget)}
+	var finished []*staleBranch
+	for i, branch := range candidates {
+		pull := pulls[i]
+		switch {
+		case errs[i] != nil:
+			fmt.Printf("⚠️  Keeping %s: PR lookup failed: %v\n", branch.name, errs[i])
+			continue
+		case pull != nil && pull.MergedAt != nil:
+			branch.reason = fmt.Sprintf("#%d merged", pull.Number)
+		case pull != nil && pull.State == "closed":
+			branch.reason = fmt.Sprintf("#%d closed", pull.Number)
+		case pull != nil:
+			fmt.Printf("⏭️  Keeping %s: #%d is still open\n", branch.name, pull.Number)
+			continue
+		case isMergedIntoAny(gitClient, cleanupBranchRef(gitClient, branch), targets):
+			branch.reason = "merged into " + cleanupTarget
+		default:
+			fmt.Printf("⏭️  Keeping %s: no PR and not merged into %s\n", branch.name, cleanupTarget)
+			continue
+		}
+		finished = append(finished, branch)
+	}
+	return finished
+}
+
+// cleanupBranchRef returns the ref to inspect for a branch, preferring the local branch
+func cleanupBranchRef(gitClient *git.Client, branch *staleBranch) string {
+	if branch.local {
+		return branch.name
+	}
+	return gitClient.Remote() + "/" + branch.name
+}
+
+// parseAge parses an age such as "30d", "2w" or "36h"
+func parseAge(value string) (time.Duration, error) {
+	days := map[string]int{"d": 1, "w": 7}
+	for unit, multiplier := range days {
+		if number, ok := strings.CutSuffix(value, unit); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid age %q: use e.g. 30d, 2w or 36h", value)
+			}
+			return time.Duration(n*multiplier) * 24 * time.Hour, nil
+		}
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age %q: use e.g. 30d, 2w or 36h", value)
+	}
+	return age, nil
+}
+
+func init() {
+	cleanupCmd.Flags().StringVar(&cleanupOlderThan, "older-than", "30d", "Only branches whose last commit is older than this, e.g. 30d, 2w or 36h")
+	cleanupCmd.Flags().StringVar(&cleanupNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
+	cleanupCmd.Flags().StringVarP(&cleanupTarget, "target", "t", config.ConfigDefaults.TargetBranch, "Target branch the splits were merged into, never deleted")
+	cleanupCmd.Flags().StringVar(&cleanupRemote, "remote", git.DefaultRemote, "Remote to delete branches from, e.g. your fork")
+	cleanupCmd.Flags().StringSliceVar(&cleanupProtect, "protect", nil, "Branch name or glob to never delete, on top of main, master, develop, release/* and protected_branches (repeatable)")
+	cleanupCmd.Flags().BoolVar(&cleanupDryRun, "dry-run", false, "Preview what would be deleted without actually deleting")
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+
+	"github.com/spf13/cobra"
+)
+
+var commentsCmd = &cobra.Command{
+	Use:   "comments [branch-prefix]",
+	Short: "Show unresolved review comments on published partition PRs",
+	Long: `Fetch unresolved review threads for every partition branch that has an open PR.
+
+Use this before updating a published split: rewriting a partition branch
+orphans the anchors of review comments on the files it touches.
+
+Requires a GitHub origin remote and a token in GITHUB_TOKEN or GH_TOKEN.
+Set GITHUB_API_URL for GitHub Enterprise.
+
+Examples:
+  pr-split comments pr-split                      Review threads for all pr-split-* PRs
+  pr-split comments --namespace "split/{user}"    Review threads for your namespaced split`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: runComments,
+}
+
+var commentsNamespace string
+
+func runComments(cmd *cobra.Command, args []string) error {
+	prefix := ""
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+
+	if commentsNamespace != "" {
+		prefix = namespacedPrefix(gitClient, commentsNamespace, prefix)
+	} else if prefix == "" {
+		return fmt.Errorf("a branch prefix is required unless --namespace is set")
+	}
+
+	branches, err := findLocalBranchesWithPrefix(gitClient, prefix)
+	if err != nil {
+		return fmt.Errorf("failed to find local branches: %w", err)
+	}
+	if len(branches) == 0 {
+		fmt.Printf("✅ No branches found with prefix '%s'\n", prefix)
+		return nil
+	}
+	branches = sortPartitionBranches(branches)
+
+	github, err := newGitHubClient(gitClient, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to GitHub: %w", err)
+	}
+
+	fmt.Printf("💬 Checking review comments on %d partition branches...\n", len(branches))
+	fmt.Println()
+
+	totalThreads := 0
+	for _, branch := range branches {
+		pr, err := github.FindPullRequest(branch)
+		if err != nil {
+			fmt.Printf("⚠️  %s: could not look up PR: %v\n", branch, err)
+			continue
+		}
+		if pr == nil {
+			fmt.Printf("📭 %s: no open PR\n", branch)
+			continue
+		}
+
+		threads, err := github.UnresolvedReviewThreads(pr.Number)
+		if err != nil {
+			fmt.Printf("⚠️  %s: could not fetch review threads for #%d: %v\n", branch, pr.Number, err)
+			continue
+		}
+
+		totalThreads += len(threads)
+		displayPRThreads(branch, pr, threads)
+	}
+
+	fmt.Println()
+	if totalThreads > 0 {
+		fmt.Printf("⚠️  %d unresolved threads. Force-pushing partition branches will orphan their anchors;\n", totalThreads)
+		fmt.Println("   resolve or reply to them before re-splitting the affected partitions.")
+	} else {
+		fmt.Println("✅ No unresolved review threads")
+	}
+
+	return nil
+}
+
+// displayPRThreads prints the unresolved threads of one partition PR grouped by file
+func displayPRThreads(branch string, pr *provider.PullRequest, threads []provider.ReviewThread) {
+	fmt.Printf("🔸 %s → #%d %s\n", branch, pr.Number, pr.Title)
+	if len(threads) == 0 {
+		fmt.Println("   ✅ No unresolved threads")
+		return
+	}
+
+	byPath := make(map[string][]provider.ReviewThread)
+	var paths []string
+	for _, thread := range threads {
+		if _, seen := byPath[thread.Path]; !seen {
+			paths = append(paths, thread.Path)
+		}
+		byPath[thread.Path] = append(byPath[thread.Path], thread)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		fmt.Printf("   📄 %s (%d threads)\n", path, len(byPath[path]))
+		for _, thread := range byPath[path] {
+			marker := ""
+			if thread.Outdated {
+				marker = " [outdated anchor]"
+			}
+			fmt.Printf("      L%d @%s%s: %s\n", thread.Line, thread.Author, marker, firstLine(thread.Body))
+		}
+	}
+
+	// Files with several open threads are usually the ones to amend or move
+	for _, path := range paths {
+		if len(byPath[path]) >= 3 {
+			fmt.Printf("   💡 %s has %d open threads; consider amending it in this partition before dependents build on it\n",
+				path, len(byPath[path]))
+		}
+	}
+}
+
+// namespacedPrefix combines a namespace (with {user} resolved) and a branch prefix
+func namespacedPrefix(gitClient *git.Client, namespace, prefix string) string {
+	ns := strings.Trim(config.ResolveNamespace(namespace, gitClient.GetUserSlug()), "/")
+	return ns + "/" + prefix
+}
+
+// firstLine truncates a comment body to its first line
+func firstLine(body string) string {
+	line := strings.SplitN(strings.TrimSpace(body), "\n", 2)[0]
+	if len(line) > 100 {
+		line = line[:97] + "..."
+	}
+	return line
+}
+
+func init() {
+	commentsCmd.Flags().StringVar(&commentsNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/types"
+
+	"github.com/spf13/cobra"
+)
+
+// completionFunc completes the positional arguments or a flag value of a command
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completionClient returns a git client for the checkout completions are for. Completion runs
+// without the root command's pre-run, so --repo is applied here.
+func completionClient() *git.Client {
+	if repoPath == "" {
+		return git.NewClient()
+	}
+	dir, err := filepath.Abs(repoPath)
+	if err != nil {
+		return git.NewClient()
+	}
+	return git.NewClientInDir(dir)
+}
+
+// completeBranches completes local branch names
+func completeBranches(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	branches, err := completionClient().GetLocalBranches()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return matching(branches, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePrefixes completes the branch prefixes of the splits recorded in state, newest first
+func completePrefixes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	_, st, err := loadState(completionClient())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	seen := make(map[string]bool)
+	var prefixes []string
+	for i := len(st.Runs) - 1; i >= 0; i-- {
+		prefix := st.Runs[i].Config.BranchPrefix
+		if prefix == "" || seen[prefix] {
+			continue
+		}
+		seen[prefix] = true
+		prefixes = append(prefixes, fmt.Sprintf("%s\tsplit of %s", prefix, st.Runs[i].SourceBranch))
+	}
+	return matching(prefixes, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeRunIDs completes the IDs of the runs recorded in state, newest first
+func completeRunIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	_, st, err := loadState(completionClient())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var ids []string
+	for i := len(st.Runs) - 1; i >= 0; i-- {
+		run := st.Runs[i]
+		ids = append(ids, fmt.Sprintf("%s\t%s, %s → %s", run.ID, run.Status, run.SourceBranch, run.TargetBranch))
+	}
+	return matching(ids, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeBranchThenPrefix completes a source branch, then a branch prefix, for commands like
+// 'sync <source-branch> [branch-prefix]'
+func completeBranchThenPrefix(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeBranches(cmd, args, toComplete)
+	case 1:
+		return completePrefixes(cmd, args, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFirst completes only the first positional argument with complete
+func completeFirst(complete completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// completeValues completes a flag from a fixed list of values
+func completeValues(values ...string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return matching(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// matching keeps the candidates starting with the word being completed; a candidate may carry
+// a tab-separated description
+func matching(candidates []string, toComplete string) []string {
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// registerCompletions adds dynamic completion of branch names, split prefixes, run IDs and
+// flag values to the commands
+func registerCompletions() {
+	breakCmd.ValidArgsFunction = completeFirst(completeBranches)
+	syncCmd.ValidArgsFunction = completeBranchThenPrefix
+	summaryCmd.ValidArgsFunction = completeBranchThenPrefix
+	for _, cmd := range []*cobra.Command{rollbackCmd, cleanupCmd, commentsCmd, mergeCmd, retargetCmd, statusCmd, watchCmd} {
+		cmd.ValidArgsFunction = completeFirst(completePrefixes)
+	}
+	resumeCmd.ValidArgsFunction = completeRunIDs
+	undoCmd.ValidArgsFunction = completeRunIDs
+
+	for _, cmd := range []*cobra.Command{breakCmd, syncCmd, rollbackCmd} {
+		if cmd.Flags().Lookup("target") != nil {
+			cobra.CheckErr(cmd.RegisterFlagCompletionFunc("target", completeBranches))
+		}
+	}
+	flagValues := map[string][]string{
+		"strategy":     {types.StrategyDependencyFirst, types.StrategyDirectory, types.StrategyOwnership, types.StrategySemantic, types.StrategyMinCut, types.StrategyWorkspace},
+		"topology":     {types.TopologyLinear, types.TopologyIndependent, types.TopologyDAG},
+		"apply-mode":   {types.ApplyModeCheckout, types.ApplyModePatch},
+		"binary-files": {types.BinaryFilesDirectory, types.BinaryFilesSeparate},
+		"check-policy": {types.CheckPolicyAbort, types.CheckPolicyContinue},
+	}
+	for flag, values := range flagValues {
+		cobra.CheckErr(breakCmd.RegisterFlagCompletionFunc(flag, completeValues(values...)))
+	}
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/demo"
+	"pr-splitter-cli/internal/splitter"
+	"pr-splitter-cli/internal/types"
+
+	"github.com/spf13/cobra"
+)
+
+var demoCmd = &cobra.Command{
+	Use:   "demo",
+	Short: "Generate a sample repository and split it",
+	Long: `Create a throwaway repository with an oversized feature branch (including a
+circular import and a renamed file), run a split against it, and leave the
+result for exploration.
+
+The repository gets a local bare "origin" next to it, so nothing is pushed
+anywhere else. Delete the directory when you are done.
+
+Examples:
+  pr-split demo                          Generate in a new temporary directory
+  pr-split demo --dir /tmp/try-pr-split  Generate in a specific directory
+  pr-split demo --generate-only          Create the repository without splitting`,
+	Args: cobra.NoArgs,
+	RunE: runDemo,
+}
+
+var (
+	demoDir          string
+	demoMaxSize      int
+	demoGenerateOnly bool
+)
+
+func runDemo(cmd *cobra.Command, args []string) error {
+	dir := demoDir
+	if dir == "" {
+		tempDir, err := os.MkdirTemp("", "pr-split-demo-")
+		if err != nil {
+			return fmt.Errorf("failed to create demo directory: %w", err)
+		}
+		dir = tempDir
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create demo directory: %w", err)
+	}
+
+	fmt.Printf("🧪 Generating demo repository in %s\n", dir)
+	repo, err := demo.Generate(dir)
+	if err != nil {
+		return fmt.Errorf("failed to generate demo repository: %w", err)
+	}
+	fmt.Printf("✅ Created branch %s with %d changed files\n", repo.Branch, repo.FilesAdded)
+	fmt.Println()
+
+	if !demoGenerateOnly {
+		// Every git and plugin operation resolves paths from the working directory
+		if err := os.Chdir(repo.Dir); err != nil {
+			return fmt.Errorf("failed to enter demo repository: %w", err)
+		}
+
+		cfg := &types.Config{
+			MaxFilesPerPartition: demoMaxSize,
+			MaxPartitions:        config.ConfigDefaults.MaxPartitions,
+			BranchPrefix:         config.ConfigDefaults.BranchPrefix,
+			Strategy:             config.ConfigDefaults.Strategy,
+			TargetBranch:         "main",
+			BranchTemplate:       config.ConfigDefaults.BranchTemplate,
+			ApplyMode:            config.ConfigDefaults.ApplyMode,
+		}
+
+		result, err := splitter.New().SplitWithConfig(cmd.Context(), repo.Branch, cfg)
+		if err != nil {
+			return fmt.Errorf("demo split failed: %w", err)
+		}
+		displayBreakResults(result)
+	}
+
+	fmt.Println()
+	fmt.Println("🔭 Explore the result:")
+	fmt.Printf("   cd %s\n", repo.Dir)
+	if demoGenerateOnly {
+		fmt.Printf("   pr-split break %s --max-size %d\n", repo.Branch, demoMaxSize)
+	} else {
+		fmt.Println("   git branch --list 'pr-split-*'")
+		fmt.Println("   git log --oneline --graph --all")
+		fmt.Println("   pr-split rollback pr-split")
+	}
+	fmt.Printf("   rm -rf %s   # when you are done\n", dir)
+
+	return nil
+}
+
+func init() {
+	demoCmd.Flags().StringVar(&demoDir, "dir", "", "Directory to create the demo repository in (default: a new temporary directory)")
+	demoCmd.Flags().IntVarP(&demoMaxSize, "max-size", "s", 5, "Maximum files per partition for the demo split")
+	demoCmd.Flags().BoolVar(&demoGenerateOnly, "generate-only", false, "Create the repository without running a split")
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge [branch-prefix]",
+	Short: "Merge partition branches into the target locally, in dependency order",
+	Long: `Merge every partition branch into the target branch one at a time, in partition
+(dependency) order, checking each step.
+
+By default the merges are simulated in a temporary worktree, so you can see whether
+the whole chain lands cleanly without touching your checkout. With --apply the
+target branch itself is updated; if any step fails it is reset to where it started.
+
+Each step must merge without conflicts and, with --check, the command must succeed
+in the merged tree (e.g. --check "go build ./..." or --check "npm test").
+
+Examples:
+  pr-split merge pr-split --check "go build ./..."
+  pr-split merge pr-split --apply --squash --push`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMerge,
+}
+
+var (
+	mergeNamespace string
+	mergeTarget    string
+	mergeCheck     string
+	mergeApply     bool
+	mergeSquash    bool
+	mergePush      bool
+	mergeNoVerify  bool
+)
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	prefix := ""
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+	if mergePush && !mergeApply {
+		return fmt.Errorf("--push requires --apply")
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	gitClient.SetNoVerify(mergeNoVerify)
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+
+	branches, err := partitionBranchesFor(gitClient, prefix, mergeNamespace)
+	if err != nil {
+		return err
+	}
+
+	target := mergeTarget
+	if target == "" {
+		target = config.ConfigDefaults.TargetBranch
+	}
+
+	if mergeApply {
+		unlock, err := lockRepository(gitClient)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		return applyMerges(gitClient, target, branches)
+	}
+	return simulateMerges(gitClient, target, branches)
+}
+
+// simulateMerges merges the chain into a throwaway worktree of the target
+func simulateMerges(gitClient *git.Client, target string, branches []string) error {
+	root, err := gitClient.RepoRoot()
+	if err != nil {
+		return err
+	}
+
+	worktree, err := git.AddTemporaryWorktreeAt(gitClient.Context(), root, target)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := worktree.Remove(); err != nil {
+			fmt.Printf("⚠️  Warning: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("🧪 Simulating %d merges into %s in %s\n", len(branches), target, worktree.Path)
+	worktreeClient := git.NewClientInDir(worktree.Path).WithContext(gitClient.Context())
+	worktreeClient.SetNoVerify(mergeNoVerify)
+	if err := mergeChain(worktreeClient, worktree.Path, branches); err != nil {
+		return err
+	}
+	fmt.Printf("🎉 All %d partitions merge cleanly into %s (simulation, nothing was changed)\n", len(branches), target)
+	return nil
+}
+
+// applyMerges merges the chain into the target branch itself, resetting it if any step fails
+func applyMerges(gitClient *git.Client, target string, branches []string) error {
+	dirty, err := gitClient.HasLocalChanges()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w; commit or stash them before merging", git.ErrDirtyWorktree)
+	}
+
+	root, err := gitClient.RepoRoot()
+	if err != nil {
+		return err
+	}
+	originalBranch, _ := gitClient.GetCurrentBranch()
+	startTip, err := gitClient.ResolveCommit(target)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", target, err)
+	}
+
+	if err := gitClient.CheckoutBranch(target); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", target, err)
+	}
+
+	fmt.Printf("🔀 Merging %d partitions into %s\n", len(branches), target)
+	if err := mergeChain(gitClient, root, branches); err != nil {
+		fmt.Printf("↩️  Resetting %s to %s\n", target, shortCommit(startTip))
+		if resetErr := gitClient.ResetHard(startTip); resetErr != nil {
+			fmt.Printf("⚠️  Warning: %v\n", resetErr)
+		}
+		restoreBranch(gitClient, originalBranch)
+		return err
+	}
+	fmt.Printf("🎉 Merged all %d partitions into %s\n", len(branches), target)
+
+	if mergePush {
+		if err := gitClient.PushBranch(target); err != nil {
+			restoreBranch(gitClient, originalBranch)
+			return err
+		}
+		fmt.Printf("⬆️  Pushed %s\n", target)
+	}
+
+	restoreBranch(gitClient, originalBranch)
+	return nil
+}
+
+// mergeChain merges each branch in turn into the checkout at dir, running the check after each step
+func mergeChain(client *git.Client, dir string, branches []string) error {
+	for i, branch := range branches {
+		fmt.Printf("   [%d/%d] %s\n", i+1, len(branches), branch)
+		if err := client.MergeBranch(branch, mergeSquash); err != nil {
+			return fmt.Errorf("step %d failed: %w", i+1, err)
+		}
+
+		if mergeCheck == "" {
+			continue
+		}
+		check := exec.Command("sh", "-c", mergeCheck)
+		check.Dir = dir
+		if output, err := check.CombinedOutput(); err != nil {
+			fmt.Println(lastLines(string(output), 20))
+			return fmt.Errorf("step %d failed: %q fails after merging %s: %w", i+1, mergeCheck, branch, err)
+		}
+	}
+	return nil
+}
+
+// lastLines keeps the tail of command output
+func lastLines(output string, n int) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shortCommit abbreviates a commit SHA for display
+func shortCommit(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+func init() {
+	mergeCmd.Flags().StringVar(&mergeNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
+	mergeCmd.Flags().StringVarP(&mergeTarget, "target", "t", "", "Target branch (default \"main\")")
+	mergeCmd.Flags().StringVar(&mergeCheck, "check", "", "Command that must succeed after each merge, e.g. \"go build ./...\"")
+	mergeCmd.Flags().BoolVar(&mergeApply, "apply", false, "Merge into the target branch instead of simulating in a temporary worktree")
+	mergeCmd.Flags().BoolVar(&mergeSquash, "squash", false, "Squash each partition into a single commit")
+	mergeCmd.Flags().BoolVar(&mergeNoVerify, "no-verify", false, "Skip git hooks on the merge commits and the push")
+	mergeCmd.Flags().BoolVar(&mergePush, "push", false, "Push the target branch after merging (with --apply)")
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"fmt"
+
+	"pr-splitter-cli/internal/partition"
+
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Inspect saved partition plans",
+	Long: `Work with partition plans saved by 'pr-split break --plan-out <file>'.
+
+Available Commands:
+  show     List the partitions of a plan, or the files of one partition
+  diff     Show how a regenerated plan differs from a previous one
+  mermaid  Draw the partition dependency stack as a Mermaid flowchart`,
+}
+
+var planShowCmd = &cobra.Command{
+	Use:   "show <plan.json> [partition]",
+	Short: "Show a saved partition plan",
+	Long: `List the partitions of a plan saved with --plan-out, or every file of one
+partition. Partitions are referenced by slug (e.g. "auth", "api-2") or number.
+
+Examples:
+  pr-split plan show plan.json
+  pr-split plan show plan.json auth`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPlanShow,
+}
+
+var planDiffCmd = &cobra.Command{
+	Use:   "diff <old.json> <new.json>",
+	Short: "Compare two saved partition plans",
+	Long: `Show how a regenerated partition plan differs from a previous one: files moved
+between partitions, partitions added or removed, and dependency changes.
+
+Partitions are matched by slug first, then by the files they share, since
+numeric IDs shift when partitions are inserted or removed. Use it to check the impact of changed settings or new commits
+before applying a plan.
+
+Examples:
+  pr-split break feature/x --plan-out old.json
+  pr-split break feature/x --max-size 8 --plan-out new.json
+  pr-split plan diff old.json new.json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPlanDiff,
+}
+
+var planMermaidCmd = &cobra.Command{
+	Use:   "mermaid <plan.json>",
+	Short: "Draw a saved plan as a Mermaid flowchart",
+	Long: `Print a Mermaid flowchart of partition dependencies, fenced so it can be pasted
+into a PR description or markdown file where GitHub renders it.
+
+Examples:
+  pr-split plan mermaid plan.json
+  pr-split plan mermaid plan.json --files > stack.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanMermaid,
+}
+
+var mermaidFiles bool
+
+func runPlanMermaid(cmd *cobra.Command, args []string) error {
+	plan, err := partition.LoadPlan(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(partition.MermaidBlock(partition.RenderMermaid(plan, mermaidFiles)))
+	return nil
+}
+
+func runPlanShow(cmd *cobra.Command, args []string) error {
+	plan, err := partition.LoadPlan(args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		fmt.Printf("📋 %d partitions covering %d files\n", len(plan.Partitions), plan.Metadata.TotalFiles)
+		for _, p := range plan.Partitions {
+			fmt.Printf("   %-3d %-24s %-40s %d files\n", p.ID, p.Slug, p.BranchName, len(p.Files))
+		}
+		return nil
+	}
+
+	p, err := partition.FindPartition(plan.Partitions, args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📦 Partition %d [%s]: %s\n", p.ID, p.Slug, p.Description)
+	fmt.Printf("   Branch: %s\n", p.BranchName)
+	for _, file := range p.Files {
+		fmt.Printf("   - %s (%s)\n", file.Path, file.DescribeChange())
+	}
+	for _, item := range p.Checklist {
+		fmt.Printf("   ☐ %s\n", item)
+	}
+	return nil
+}
+
+func runPlanDiff(cmd *cobra.Command, args []string) error {
+	oldPlan, err := partition.LoadPlan(args[0])
+	if err != nil {
+		return err
+	}
+	newPlan, err := partition.LoadPlan(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff := partition.DiffPlans(oldPlan, newPlan)
+
+	fmt.Printf("📋 Comparing %s (%d partitions) → %s (%d partitions)\n",
+		args[0], len(oldPlan.Partitions), args[1], len(newPlan.Partitions))
+	fmt.Println()
+
+	if diff.IsEmpty() {
+		fmt.Println("✅ Plans are equivalent")
+		return nil
+	}
+
+	for _, match := range diff.Matched {
+		if partition.PartitionLabel(match.Old) != partition.PartitionLabel(match.New) {
+			fmt.Printf("🔀 %s is now %s\n", partition.PartitionLabel(match.Old), partition.PartitionLabel(match.New))
+		}
+	}
+	for _, removed := range diff.Removed {
+		fmt.Printf("➖ Removed partition %s (%d files)\n", partition.PartitionLabel(removed), len(removed.Files))
+	}
+	for _, added := range diff.Added {
+		fmt.Printf("➕ Added partition %s (%d files)\n", partition.PartitionLabel(added), len(added.Files))
+	}
+
+	if len(diff.MovedFiles) > 0 {
+		fmt.Printf("\n📦 Moved files (%d):\n", len(diff.MovedFiles))
+		for _, move := range diff.MovedFiles {
+			fmt.Printf("   %s: %s → %s\n", move.Path, move.From, move.To)
+		}
+	}
+
+	if len(diff.AddedFiles) > 0 {
+		fmt.Printf("\n🆕 Files only in new plan (%d):\n", len(diff.AddedFiles))
+		for _, path := range diff.AddedFiles {
+			fmt.Printf("   + %s\n", path)
+		}
+	}
+	if len(diff.RemovedFiles) > 0 {
+		fmt.Printf("\n🗑️  Files only in old plan (%d):\n", len(diff.RemovedFiles))
+		for _, path := range diff.RemovedFiles {
+			fmt.Printf("   - %s\n", path)
+		}
+	}
+
+	if len(diff.DependencyChanges) > 0 {
+		fmt.Println("\n🔗 Dependency changes:")
+		for _, change := range diff.DependencyChanges {
+			fmt.Printf("   %s\n", change.Partition)
+			for _, dep := range change.Added {
+				fmt.Printf("      + depends on %s\n", dep)
+			}
+			for _, dep := range change.Removed {
+				fmt.Printf("      - no longer depends on %s\n", dep)
+			}
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	planCmd.AddCommand(planShowCmd)
+	planCmd.AddCommand(planDiffCmd)
+	planCmd.AddCommand(planMermaidCmd)
+
+	planMermaidCmd.Flags().BoolVar(&mermaidFiles, "files", false, "List each partition's files inside its node")
+}
@@ -0,0 +1,358 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/plugin"
+	"pr-splitter-cli/internal/projectcontext"
+	"pr-splitter-cli/internal/types"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginCmd groups plugin management subcommands
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Inspect and manage language analysis plugins",
+	Long: `Inspect and manage the language plugins used for dependency analysis.
+
+Examples:
+  pr-split plugin list                  Show all discovered plugins
+  pr-split plugin info typescript       Show details for a single plugin
+  pr-split plugin test python           Run a plugin against files in this repo
+  pr-split plugin install go-analyzer   Install a plugin from the registry`,
+}
+
+// Command flags for plugin test
+var (
+	pluginTestInput  string
+	pluginTestSource string
+	pluginTestTarget string
+	pluginTestLimit  int
+	pluginTestJSON   bool
+
+	pluginInstallRegistry string
+	pluginInstallSHA256   string
+	pluginInstallForce    bool
+)
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List discovered plugins and whether they are usable",
+	Args:  cobra.NoArgs,
+	RunE:  runPluginList,
+}
+
+var pluginInfoCmd = &cobra.Command{
+	Use:   "info [plugin-name]",
+	Short: "Show details for a single plugin",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPluginInfo,
+}
+
+var pluginTestCmd = &cobra.Command{
+	Use:   "test [plugin-name]",
+	Short: "Run a plugin against sample input and validate its output",
+	Long: `Feed a sample PluginInput to a single plugin and validate the dependencies it returns.
+
+Input is taken from a fixture file (--input), from the diff between two branches
+(--source/--target), or from the files in the current repository.
+
+Examples:
+  pr-split plugin test typescript                          Use repository files as input
+  pr-split plugin test python --input fixture.json         Use a PluginInput fixture
+  pr-split plugin test typescript --source feature/x       Use the diff against main`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginTest,
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install [url|path|name]",
+	Short: "Install a plugin from an archive URL, local archive, or registry",
+	Long: `Download a plugin archive (.tar.gz, .tgz or .zip) into the plugins directory.
+
+The archive must contain a plugin.json manifest at its root or in a single
+top-level directory. If the manifest has a "checksum" field, the executable
+is compared with it. A source that is not a URL, does not end in an archive
+extension and does not start with ./, ../ or / is a name, resolved through a
+registry index JSON given with --registry or the ` + plugin.RegistryEnvVar + `
+environment variable. A replaced plugin is kept until the new one installs.
+
+Examples:
+  pr-split plugin install https://example.com/go-analyzer.tar.gz --sha256 <hex>
+  pr-split plugin install ./rust-analyzer.zip
+  pr-split plugin install go-analyzer --registry https://example.com/plugins.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginInstall,
+}
+
+func runPluginList(cmd *cobra.Command, args []string) error {
+	inspector := plugin.NewInspector()
+
+	statuses, err := inspector.InspectAll()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🧩 Built-in analyzers:")
+	for _, builtin := range inspector.Builtins() {
+		fmt.Printf("✅ %s (built-in) [%s]\n", builtin.Name, strings.Join(builtin.Extensions, ", "))
+	}
+	fmt.Println()
+
+	fmt.Printf("🔌 Plugins in %s:\n", inspector.PluginDir())
+	fmt.Println()
+
+	if len(statuses) == 0 {
+		fmt.Println("⚠️  No plugins found")
+		fmt.Println("💡 Create plugins with a plugin.json manifest file")
+		return nil
+	}
+
+	validCount := 0
+	for _, status := range statuses {
+		if status.Valid() {
+			validCount++
+			fmt.Printf("✅ %s v%s (%s) [%s]\n", status.Plugin.Name, status.Plugin.Version,
+				runtimeLabel(status.Plugin), strings.Join(status.Plugin.Extensions, ", "))
+			continue
+		}
+
+		name := status.DirName
+		if status.Manifest != nil {
+			name = status.Manifest.Name
+		}
+		fmt.Printf("❌ %s: %s\n", name, strings.Join(status.Problems, "; "))
+	}
+
+	fmt.Println()
+	fmt.Printf("%d of %d plugin(s) usable\n", validCount, len(statuses))
+	return nil
+}
+
+func runPluginInfo(cmd *cobra.Command, args []string) error {
+	status, err := plugin.NewInspector().Inspect(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔌 Plugin: %s\n", status.DirName)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Directory:   %s\n", status.Path)
+
+	if manifest := status.Manifest; manifest != nil {
+		fmt.Printf("Name:        %s\n", manifest.Name)
+		fmt.Printf("Version:     %s\n", manifest.Version)
+		fmt.Printf("Description: %s\n", manifest.Description)
+		fmt.Printf("Extensions:  %s\n", strings.Join(manifest.Extensions, ", "))
+		if manifest.Author != "" {
+			fmt.Printf("Author:      %s\n", manifest.Author)
+		}
+		if manifest.Homepage != "" {
+			fmt.Printf("Homepage:    %s\n", manifest.Homepage)
+		}
+	}
+
+	if status.Plugin != nil {
+		fmt.Printf("Executable:  %s\n", status.Plugin.Executable)
+		fmt.Printf("Runtime:     %s\n", runtimeLabel(status.Plugin))
+		if status.Plugin.ProjectContext {
+			fmt.Println("Context:     changed files and the unchanged project files")
+		} else {
+			fmt.Println("Context:     changed files only")
+		}
+	}
+
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if status.Valid() {
+		fmt.Println("✅ Plugin is usable")
+	} else {
+		fmt.Println("❌ Plugin is not usable:")
+		for _, problem := range status.Problems {
+			fmt.Printf("   - %s\n", problem)
+		}
+	}
+
+	return nil
+}
+
+func runPluginTest(cmd *cobra.Command, args []string) error {
+	pluginName := args[0]
+	inspector := plugin.NewInspector()
+
+	input, err := buildPluginTestInput(cmd.Context(), inspector, pluginName)
+	if err != nil {
+		return fmt.Errorf("failed to build plugin input: %w", err)
+	}
+
+	fmt.Printf("🧪 Testing plugin '%s' with %d changed and %d context files...\n",
+		pluginName, len(input.ChangedFiles), len(input.ProjectFiles))
+
+	report, err := inspector.TestPlugin(cmd.Context(), pluginName, input)
+	if err != nil {
+		return err
+	}
+
+	if pluginTestJSON && report.Output != nil {
+		data, err := json.MarshalIndent(report.Output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal plugin output: %w", err)
+		}
+		fmt.Println(string(data))
+	}
+
+	displayPluginTestReport(report)
+
+	if !report.Passed() {
+		return fmt.Errorf("plugin test failed: %w", report.Err)
+	}
+	return nil
+}
+
+func runPluginInstall(cmd *cobra.Command, args []string) error {
+	installer := plugin.NewInstaller()
+
+	status, err := installer.Install(args[0], plugin.InstallOptions{
+		RegistryURL: pluginInstallRegistry,
+		SHA256:      pluginInstallSHA256,
+		Force:       pluginInstallForce,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install plugin: %w", err)
+	}
+
+	if !status.Valid() {
+		fmt.Printf("⚠️  Installed %s but it is not usable: %s\n", status.Path, strings.Join(status.Problems, "; "))
+		return nil
+	}
+
+	fmt.Printf("✅ Installed %s v%s to %s\n", status.Plugin.Name, status.Plugin.Version, status.Path)
+	return nil
+}
+
+// buildPluginTestInput creates plugin input from a fixture, a branch diff, or the working tree
+func buildPluginTestInput(ctx context.Context, inspector *plugin.Inspector, pluginName string) (types.PluginInput, error) {
+	var input types.PluginInput
+
+	if pluginTestInput != "" {
+		data, err := os.ReadFile(pluginTestInput)
+		if err != nil {
+			return input, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, &input); err != nil {
+			return input, fmt.Errorf("failed to parse fixture: %w", err)
+		}
+		return input, nil
+	}
+
+	status, err := inspector.Inspect(pluginName)
+	if err != nil {
+		return input, err
+	}
+	wantsContext := status.Plugin != nil && status.Plugin.ProjectContext
+
+	gitClient := git.NewClient().WithContext(ctx)
+
+	var files []types.FileChange
+	if pluginTestSource != "" {
+		files, err = gitClient.GetChanges(pluginTestSource, pluginTestTarget)
+		if err == nil && wantsContext {
+			files, err = gitClient.WithProjectContext(files, pluginTestSource, projectcontext.DefaultDepth)
+		}
+	} else {
+		files, err = gitClient.GetProjectFiles()
+	}
+	if err != nil {
+		return input, err
+	}
+
+	files, err = inspector.FilterForPlugin(pluginName, files)
+	if err != nil {
+		return input, err
+	}
+
+	for _, file := range files {
+		// Without a branch diff, treat repository files as changed up to the limit
+		if pluginTestSource == "" && len(input.ChangedFiles) < pluginTestLimit {
+			file.IsChanged = true
+		}
+
+		if file.IsChanged {
+			input.ChangedFiles = append(input.ChangedFiles, file)
+		} else if wantsContext {
+			input.ProjectFiles = append(input.ProjectFiles, file)
+		}
+	}
+
+	return input, nil
+}
+
+// displayPluginTestReport prints dependencies, warnings, and timing for a plugin run
+func displayPluginTestReport(report *plugin.TestReport) {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if report.Output != nil && !pluginTestJSON {
+		fmt.Printf("🔗 Dependencies (%d):\n", len(report.Output.Dependencies))
+		for _, dep := range report.Output.Dependencies {
+			location := ""
+			if dep.Line > 0 {
+				location = fmt.Sprintf(" (line %d)", dep.Line)
+			}
+			fmt.Printf("  %s → %s [%s, %s]%s\n", dep.From, dep.To, dep.Type, dep.Strength, location)
+		}
+		fmt.Println()
+	}
+
+	if report.Output != nil {
+		meta := report.Output.Metadata
+		fmt.Printf("Plugin:         %s v%s\n", meta.PluginName, meta.PluginVersion)
+		fmt.Printf("Files analyzed: %d\n", meta.FilesAnalyzed)
+		for _, errMsg := range report.Output.Errors {
+			fmt.Printf("⚠️  Plugin error: %s\n", errMsg)
+		}
+	}
+
+	for _, warning := range report.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	fmt.Printf("Duration:       %s\n", report.Duration)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if report.Passed() {
+		fmt.Println("✅ Output passed schema validation")
+	} else {
+		fmt.Printf("❌ %v\n", report.Err)
+	}
+}
+
+// runtimeLabel returns a display name for a plugin's runtime
+func runtimeLabel(p *plugin.Plugin) string {
+	if p.Runtime == "" {
+		return fmt.Sprintf("auto (%s)", p.ResolvedRuntime())
+	}
+	return p.Runtime
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInfoCmd)
+	pluginCmd.AddCommand(pluginTestCmd)
+	pluginCmd.AddCommand(pluginInstallCmd)
+
+	pluginTestCmd.Flags().StringVarP(&pluginTestInput, "input", "i", "", "PluginInput JSON fixture file")
+	pluginTestCmd.Flags().StringVar(&pluginTestSource, "source", "", "Source branch to diff for input")
+	pluginTestCmd.Flags().StringVarP(&pluginTestTarget, "target", "t", "main", "Target branch to diff against")
+	pluginTestCmd.Flags().IntVar(&pluginTestLimit, "limit", 50, "Maximum repository files to treat as changed")
+	pluginTestCmd.Flags().BoolVar(&pluginTestJSON, "json", false, "Print raw plugin output as JSON")
+
+	pluginInstallCmd.Flags().StringVar(&pluginInstallRegistry, "registry", "", "Registry index URL used to resolve plugin names")
+	pluginInstallCmd.Flags().StringVar(&pluginInstallSHA256, "sha256", "", "Expected SHA-256 of the archive")
+	pluginInstallCmd.Flags().BoolVar(&pluginInstallForce, "force", false, "Replace an existing plugin with the same name")
+}
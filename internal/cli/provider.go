@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+	"pr-splitter-cli/internal/types"
+)
+
+// Global provider API flags
+var (
+	apiConcurrency int
+	apiRateLimit   float64
+	apiMaxRetries  int
+)
+
+// newGitHubClient connects to GitHub using API limits from flags, then config, then defaults.
+// In a fork workflow PRs are opened against the upstream remote from the push remote.
+func newGitHubClient(gitClient *git.Client, cfg *types.Config) (*provider.GitHub, error) {
+	baseRemote, headRemote := git.DefaultRemote, git.DefaultRemote
+	tokenEnv := ""
+	if cfg != nil {
+		if cfg.UpstreamRemote != "" {
+			baseRemote = cfg.UpstreamRemote
+		}
+		if cfg.PushRemote != "" {
+			headRemote = cfg.PushRemote
+		}
+		tokenEnv = cfg.GitHubTokenEnv
+	}
+	if tokenEnv == "" {
+		// Commands without a split configuration still honor the user and repository config files
+		if loaded, _, err := config.Load(repoConfigPath(gitClient, ""), ""); err == nil {
+			tokenEnv = loaded.GitHubTokenEnv
+		}
+	}
+
+	github, err := provider.NewGitHubForRemotes(gitClient.WorkingDir(), baseRemote, headRemote, tokenEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := provider.DefaultLimits
+	if cfg != nil {
+		if cfg.APIConcurrency > 0 {
+			limits.Concurrency = cfg.APIConcurrency
+		}
+		if cfg.APIRateLimit > 0 {
+			limits.RequestsPerSecond = cfg.APIRateLimit
+		}
+	}
+	// The flag defaults are the default limits, so only flags given override the config
+	flags := rootCmd.PersistentFlags()
+	if flags.Changed("api-concurrency") {
+		limits.Concurrency = apiConcurrency
+	}
+	if flags.Changed("api-rate-limit") {
+		limits.RequestsPerSecond = apiRateLimit
+	}
+	if flags.Changed("api-retries") {
+		limits.MaxRetries = apiMaxRetries
+	}
+
+	github.SetLimits(limits)
+	return github, nil
+}
+
+// repoConfigPath returns the config file given with --config, else the repository's
+// .pr-splitter.yaml when there is one
+func repoConfigPath(gitClient *git.Client, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	root, err := gitClient.RepoRoot()
+	if err != nil {
+		return ""
+	}
+	path := filepath.Join(root, config.RepoConfigName)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
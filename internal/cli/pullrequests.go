@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+	"pr-splitter-cli/internal/types"
+)
+
+// writePartitionDescriptions writes a PR description per partition under .pr-split/descriptions
+func writePartitionDescriptions(gitClient *git.Client, result *types.SplitResult, prs map[int]int) error {
+	root, err := gitClient.RepoRoot()
+	if err != nil {
+		return err
+	}
+	if err := provider.WriteDescriptions(root, result, prs); err != nil {
+		return err
+	}
+	fmt.Printf("📝 Wrote PR descriptions to %s/\n", provider.DescriptionsDir)
+	return nil
+}
+
+// createPartitionPRs opens a PR per partition in merge order, using the generated descriptions
+// as bodies, then cross-links them. It returns the PR number of every partition that was opened.
+func createPartitionPRs(gitClient *git.Client, cfg *types.Config, result *types.SplitResult) (map[int]int, error) {
+	github, err := newGitHubClient(gitClient, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to GitHub: %w", err)
+	}
+
+	milestone := 0
+	if cfg.PRMilestone != "" {
+		if milestone, err = github.FindMilestone(cfg.PRMilestone); err != nil {
+			return nil, err
+		}
+	}
+
+	var reviewers *partitionReviewers
+	if cfg.RequestReviews {
+		reviewers = newPartitionReviewers(gitClient, github, cfg, result.TargetBranch)
+	}
+
+	prs := make(map[int]int)
+	for i, partition := range result.Partitions {
+		// PRs from a fork can only target upstream branches, so they all target the target
+		// branch and rely on the cross-links for their order
+		base := pullRequestBase(result, i)
+		if github.CrossRepository() {
+			base = result.TargetBranch
+		}
+
+		// Re-runs with --update keep the PRs that are already open
+		existing, err := github.FindPullRequest(partition.BranchName)
+		if err != nil {
+			linkPartitionPRs(github, result, prs)
+			return prs, err
+		}
+		if existing != nil {
+			if existing.Base.Ref != base {
+				if err := github.UpdatePullRequestBase(existing.Number, base); err != nil {
+					fmt.Printf("⚠️  Warning: %v\n", err)
+				}
+			}
+			prs[partition.ID] = existing.Number
+			fmt.Printf("🔗 Reusing #%d for %s: %s\n", existing.Number, partition.BranchName, existing.HTMLURL)
+			continue
+		}
+
+		pr, err := github.CreatePullRequest(provider.NewPullRequest{
+			Title: provider.PartitionTitle(result, i),
+			Head:  github.HeadRef(partition.BranchName),
+			Base:  base,
+			Body:  provider.RenderDescription(result, i, prs),
+			Draft: cfg.PRDraft,
+		})
+		if err != nil {
+			linkPartitionPRs(github, result, prs)
+			return prs, err
+		}
+		prs[partition.ID] = pr.Number
+		fmt.Printf("🔗 Opened #%d for %s: %s\n", pr.Number, partition.BranchName, pr.HTMLURL)
+
+		if len(cfg.PRLabels) > 0 {
+			if err := github.AddLabels(pr.Number, provider.PartitionLabels(cfg.PRLabels, i, len(result.Partitions))); err != nil {
+				fmt.Printf("⚠️  Warning: %v\n", err)
+			}
+		}
+		if milestone > 0 {
+			if err := github.SetMilestone(pr.Number, milestone); err != nil {
+				fmt.Printf("⚠️  Warning: %v\n", err)
+			}
+		}
+		if reviewers != nil {
+			reviewers.request(pr.Number, partition)
+		}
+	}
+
+	linkPartitionPRs(github, result, prs)
+	return prs, nil
+}
+
+// linkPartitionPRs rewrites each opened PR's description now that every PR number is known, so
+// dependency and dependent placeholders become links
+func linkPartitionPRs(github *provider.GitHub, result *types.SplitResult, prs map[int]int) {
+	if len(prs) == 0 {
+		return
+	}
+
+	linked := 0
+	for i, partition := range result.Partitions {
+		number, ok := prs[partition.ID]
+		if !ok {
+			continue
+		}
+		if err := github.UpdatePullRequestBody(number, provider.RenderDescription(result, i, prs)); err != nil {
+			fmt.Printf("⚠️  Warning: Could not cross-link #%d: %v\n", number, err)
+			continue
+		}
+		linked++
+	}
+	fmt.Printf("🔗 Cross-linked %d PRs with their dependencies\n", linked)
+}
+
+// pullRequestBase is the branch the PR of the partition at index merges into, following the
+// topology its branch was created with
+func pullRequestBase(result *types.SplitResult, index int) string {
+	partition := result.Partitions[index]
+	branchOf := func(id int) string {
+		for _, p := range result.Partitions {
+			if p.ID == id {
+				return p.BranchName
+			}
+		}
+		return result.TargetBranch
+	}
+
+	switch result.Config.Topology {
+	case types.TopologyIndependent:
+		return result.TargetBranch
+	case types.TopologyDAG:
+		if len(partition.Dependencies) == 0 {
+			return result.TargetBranch
+		}
+		return branchOf(partition.Dependencies[0])
+	default:
+		if index == 0 {
+			return result.TargetBranch
+		}
+		return result.Partitions[index-1].BranchName
+	}
+}
+
+// partitionReviewers requests reviews on partition PRs from the owners of their files
+type partitionReviewers struct {
+	github    *provider.GitHub
+	rules     []provider.CodeOwnersRule
+	overrides map[string][]string
+	author    string
+}
+
+// newPartitionReviewers reads CODEOWNERS as of the target branch, where GitHub reads it from
+func newPartitionReviewers(gitClient *git.Client, github *provider.GitHub, cfg *types.Config, targetBranch string) *partitionReviewers {
+	r := &partitionReviewers{github: github, overrides: cfg.Reviewers}
+
+	for _, location := range provider.CodeOwnersLocations {
+		if content, err := gitClient.ReadFileAt(targetBranch, location); err == nil {
+			r.rules = provider.ParseCodeOwners(content)
+			fmt.Printf("👥 Using %s from %s for reviewers\n", location, targetBranch)
+			break
+		}
+	}
+	if r.rules == nil && len(r.overrides) == 0 {
+		fmt.Printf("⚠️  Warning: No CODEOWNERS on %s and no reviewers configured; not requesting reviews\n", targetBranch)
+	}
+
+	// GitHub rejects review requests from the PR author
+	author, err := github.CurrentUser()
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+	}
+	r.author = author
+	return r
+}
+
+// request asks the owners of a partition's files to review its PR
+func (r *partitionReviewers) request(number int, partition types.Partition) {
+	owners := provider.PartitionReviewers(r.rules, r.overrides, partition.Files)
+	users, teams, skipped := provider.SplitReviewers(owners)
+	if len(skipped) > 0 {
+		fmt.Printf("⚠️  Warning: Cannot request reviews by email on #%d: %s\n", number, strings.Join(skipped, ", "))
+	}
+
+	var requested []string
+	for _, user := range users {
+		if !strings.EqualFold(user, r.author) {
+			requested = append(requested, user)
+		}
+	}
+	if len(requested) == 0 && len(teams) == 0 {
+		return
+	}
+
+	if err := r.github.RequestReviewers(number, requested, teams); err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+		return
+	}
+	fmt.Printf("👀 Requested reviews on #%d from %d users and %d teams\n", number, len(requested), len(teams))
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/partition"
+	"pr-splitter-cli/internal/splitter"
+	"pr-splitter-cli/internal/state"
+
+	"github.com/spf13/cobra"
+)
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume [run-id]",
+	Short: "Continue a split that failed or was interrupted",
+	Long: `Continue the most recent split run (or the given run) from its recorded state
+in .git/pr-split/state.json.
+
+Branches the run already pushed are verified first: each must still be the recorded
+commit locally and on origin, and the source branch must not have moved. The
+remaining partitions are then created, pushed and validated as originally planned.
+Leftovers of the partition that was being created when the run stopped are removed.
+
+A failed split keeps its pushed branches only with 'pr-split break --keep-progress';
+otherwise it rolls them back and there is nothing to resume. Interrupted runs
+(killed, lost connection) can always be resumed.
+
+Examples:
+  pr-split resume
+  pr-split resume 3f9c0a1b2c4d`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runResume,
+}
+
+func runResume(cmd *cobra.Command, args []string) error {
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+	unlock, err := lockRepository(gitClient)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	store, st, err := loadState(gitClient)
+	if err != nil {
+		return err
+	}
+
+	run := st.Latest()
+	if len(args) > 0 {
+		run = st.Find(args[0])
+		if run == nil {
+			return fmt.Errorf("no run %s in %s", args[0], store.Path())
+		}
+	}
+	if run == nil {
+		return fmt.Errorf("no split runs recorded in %s", store.Path())
+	}
+	switch run.Status {
+	case state.RunStatusCompleted:
+		return fmt.Errorf("run %s already completed; use 'pr-split break --update' to split again", run.ID)
+	case state.RunStatusUndone:
+		return fmt.Errorf("run %s was undone; split again with 'pr-split break'", run.ID)
+	}
+	if run.Plan == nil {
+		return fmt.Errorf("run %s has no recorded plan to resume", run.ID)
+	}
+
+	displayRunRecord(run)
+	fmt.Println()
+
+	result, err := splitter.New().Resume(cmd.Context(), store, run)
+	if errors.Is(err, partition.ErrUserAborted) {
+		fmt.Printf("👋 Resume cancelled (%v)\n", err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resume split: %w", err)
+	}
+
+	publishSplit(&run.Config, result)
+	return nil
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"fmt"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+
+	"github.com/spf13/cobra"
+)
+
+var retargetCmd = &cobra.Command{
+	Use:   "retarget [branch-prefix]",
+	Short: "Retarget and rebase partition PRs after their dependencies merge",
+	Long: `Update a split after some of its partition PRs have merged.
+
+PRs whose base is a merged partition branch are retargeted to the nearest unmerged
+ancestor (usually the target branch), and their branches are rebased onto it so the
+PR only shows its own changes. Branches stacked on a rebased branch are rebased too.
+Rewritten branches are pushed with --force-with-lease.
+
+Requires a GitHub origin remote and a token in GITHUB_TOKEN or GH_TOKEN.
+
+Examples:
+  pr-split retarget pr-split
+  pr-split retarget pr-split --dry-run
+  pr-split retarget --namespace "split/{user}"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRetarget,
+}
+
+var (
+	retargetNamespace string
+	retargetDryRun    bool
+)
+
+func runRetarget(cmd *cobra.Command, args []string) error {
+	prefix := ""
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+	if !retargetDryRun {
+		unlock, err := lockRepository(gitClient)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	branches, err := partitionBranchesFor(gitClient, prefix, retargetNamespace)
+	if err != nil {
+		return err
+	}
+
+	github, err := newGitHubClient(gitClient, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to GitHub: %w", err)
+	}
+
+	gitClient.SetForcePushCheck(reviewThreadCheck(gitClient, github, true))
+	restacked, err := restackPartitions(gitClient, github, branches, retargetDryRun)
+	if err != nil {
+		return err
+	}
+	if restacked == 0 {
+		fmt.Println("✅ Nothing to retarget: no open partition PR is based on a merged branch")
+	}
+	return nil
+}
+
+// partitionBranchesFor finds the local partition branches of a prefix, in partition order
+func partitionBranchesFor(gitClient *git.Client, prefix, namespace string) ([]string, error) {
+	if namespace != "" {
+		prefix = namespacedPrefix(gitClient, namespace, prefix)
+	} else if prefix == "" {
+		return nil, fmt.Errorf("a branch prefix is required unless --namespace is set")
+	}
+
+	branches, err := findLocalBranchesWithPrefix(gitClient, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find local branches: %w", err)
+	}
+	if len(branches) == 0 {
+		return nil, fmt.Errorf("no branches found with prefix '%s'", prefix)
+	}
+	return sortPartitionBranches(branches), nil
+}
+
+// restackPartitions retargets open partition PRs whose base merged, rebasing them and every
+// branch stacked on them. It returns how many branches were (or, on a dry run, would be) rebased.
+func restackPartitions(gitClient *git.Client, github *provider.GitHub, branches []string, dryRun bool) (int, error) {
+	if !dryRun {
+		dirty, err := gitClient.HasLocalChanges()
+		if err != nil {
+			return 0, err
+		}
+		if dirty {
+			return 0, fmt.Errorf("%w; commit or stash them before rebasing", git.ErrDirtyWorktree)
+		}
+	}
+
+	if err := gitClient.FetchRemote(); err != nil {
+		return 0, err
+	}
+
+	pulls, errs := github.FindLatestPullRequests(branches)
+	prs := make(map[string]*provider.PullRequest)
+	oldTips := make(map[string]string)
+	for i, branch := range branches {
+		if errs[i] != nil {
+			return 0, fmt.Errorf("failed to look up PR for %s: %w", branch, errs[i])
+		}
+		prs[branch] = pulls[i]
+
+		tip, err := gitClient.ResolveCommit(gitClient.RemoteRef(branch))
+		if err != nil {
+			return 0, fmt.Errorf("failed to resolve %s: %w", branch, err)
+		}
+		oldTips[branch] = tip
+	}
+
+	merged := func(branch string) bool {
+		pr := prs[branch]
+		return pr != nil && pr.MergedAt != nil
+	}
+	// Follow merged bases down to the first branch that is still open (or not a partition)
+	resolveBase := func(base string) string {
+		for merged(base) {
+			base = prs[base].Base.Ref
+		}
+		return base
+	}
+
+	originalBranch, _ := gitClient.GetCurrentBranch()
+	rebased := make(map[string]bool)
+	for _, branch := range branches {
+		pr := prs[branch]
+		if pr == nil {
+			fmt.Printf("⚠️  Warning: %s has no PR; branches stacked on it are not restacked\n", branch)
+			continue
+		}
+		if pr.State != "open" {
+			continue
+		}
+
+		oldBase := pr.Base.Ref
+		newBase := resolveBase(oldBase)
+		if newBase == oldBase && !rebased[oldBase] {
+			continue
+		}
+
+		onto := newBase
+		if _, isPartition := prs[newBase]; !isPartition {
+			onto = gitClient.RemoteRef(newBase)
+		}
+
+		if newBase != oldBase {
+			fmt.Printf("🎯 #%d %s: %s merged, retargeting to %s\n", pr.Number, branch, oldBase, newBase)
+		} else {
+			fmt.Printf("🔁 #%d %s: %s was rebased, restacking\n", pr.Number, branch, oldBase)
+		}
+		rebased[branch] = true
+		if dryRun {
+			continue
+		}
+
+		if err := gitClient.SyncWithRemote(branch); err != nil {
+			return 0, err
+		}
+		if err := gitClient.RebaseOnto(branch, oldTips[oldBase], onto); err != nil {
+			restoreBranch(gitClient, originalBranch)
+			return 0, fmt.Errorf("%w\n   Resolve it manually with: git rebase --onto %s %s %s", err, onto, oldTips[oldBase], branch)
+		}
+		if err := gitClient.ForcePushWithLease(branch, oldTips[branch]); err != nil {
+			restoreBranch(gitClient, originalBranch)
+			return 0, err
+		}
+		if newBase != oldBase {
+			if err := github.UpdatePullRequestBase(pr.Number, newBase); err != nil {
+				restoreBranch(gitClient, originalBranch)
+				return 0, err
+			}
+		}
+		fmt.Printf("✅ Rebased and pushed %s\n", branch)
+	}
+
+	restoreBranch(gitClient, originalBranch)
+	if dryRun && len(rebased) > 0 {
+		fmt.Println("🔍 Dry run: no branches were rebased, pushed or retargeted")
+	}
+	return len(rebased), nil
+}
+
+// restoreBranch checks out the branch the user started on
+func restoreBranch(gitClient *git.Client, branch string) {
+	if branch == "" {
+		return
+	}
+	if err := gitClient.CheckoutBranch(branch); err != nil {
+		fmt.Printf("⚠️  Warning: Could not return to %s: %v\n", branch, err)
+	}
+}
+
+func init() {
+	retargetCmd.Flags().StringVar(&retargetNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
+	retargetCmd.Flags().BoolVar(&retargetDryRun, "dry-run", false, "Show which PRs would be retargeted and rebased without changing anything")
+}
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
+	"pr-splitter-cli/internal/config"
 	"pr-splitter-cli/internal/git"
 
 	"github.com/spf13/cobra"
@@ -13,9 +15,20 @@ import (
 
 // Command flags for rollback
 var (
-	dryRun bool
+	dryRun            bool
+	rollbackNamespace string
+	rollbackRemote    string
+	allUsers          bool
+	rollbackRunID     string
+	rollbackTarget    string
+	rollbackProtect   []string
+	rollbackSelect    bool
+	mergedOnly        bool
 )
 
+// stdin is shared by every prompt so buffered input is never lost between them
+var stdin = bufio.NewReader(os.Stdin)
+
 var rollbackCmd = &cobra.Command{
 	Use:   "rollback [branch-prefix]",
 	Short: "Rollback and cleanup branches created by pr-splitter",
@@ -23,20 +36,46 @@ var rollbackCmd = &cobra.Command{
 
 This command will:
 1. List all branches matching the prefix pattern
-2. Ask for confirmation (unless --dry-run)
+2. Let you pick branches to keep (with --interactive), then ask for confirmation
+   (unless --dry-run)
 3. Delete both local and remote branches
 4. Return to the original branch
 
+Protected branches are never deleted, even when the prefix matches them: main,
+master, develop, release/*, the target branch (--target) and any --protect glob.
+
+With --namespace, only branches directly under the namespace are matched
+(e.g. split/alice/pr-split-1-core), so teammates' branches are never touched.
+The prefix may then be omitted to cleanup the whole namespace.
+
 Examples:
   pr-split rollback pr-split            Cleanup all branches starting with 'pr-split'
   pr-split rollback feature-split-      Cleanup branches with custom prefix
-  pr-split rollback pr-split --dry-run  Preview what would be deleted`,
-	Args: cobra.ExactArgs(1),
+  pr-split rollback pr-split --dry-run  Preview what would be deleted
+  pr-split rollback pr-split -i         Choose which branches to delete
+  pr-split rollback pr-split --merged-only
+                                        Cleanup after the split has landed, keeping unmerged work
+  pr-split rollback --namespace split/{user}
+                                        Cleanup every branch in your namespace`,
+	Args: cobra.RangeArgs(0, 1),
 	RunE: runRollback,
 }
 
 func runRollback(cmd *cobra.Command, args []string) error {
-	branchPrefix := args[0]
+	branchPrefix := ""
+	if len(args) > 0 {
+		branchPrefix = args[0]
+	}
+
+	// Initialize git client
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	gitClient.SetRemote(rollbackRemote)
+
+	if rollbackNamespace != "" {
+		branchPrefix = namespacedPrefix(gitClient, rollbackNamespace, branchPrefix)
+	} else if branchPrefix == "" {
+		return fmt.Errorf("a branch prefix is required unless --namespace is set")
+	}
 
 	if dryRun {
 		fmt.Printf("🔍 DRY RUN: Searching for branches with prefix: %s\n", branchPrefix)
@@ -45,9 +84,6 @@ func runRollback(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
-	// Initialize git client
-	gitClient := git.NewClient()
-
 	// Validate git repository
 	if err := gitClient.ValidateGitRepository(); err != nil {
 		return fmt.Errorf("git repository validation failed: %w", err)
@@ -70,12 +106,46 @@ func runRollback(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to find remote branches: %w", err)
 	}
 
+	if !allUsers {
+		remoteBranches = excludeForeignBranches(gitClient, remoteBranches)
+	}
+
+	if rollbackRunID != "" {
+		localBranches = filterBranchesByRun(gitClient, localBranches, "", rollbackRunID)
+		remoteBranches = filterBranchesByRun(gitClient, remoteBranches, gitClient.Remote()+"/", rollbackRunID)
+	}
+
+	protected, err := protectedBranches(gitClient, rollbackTarget, rollbackProtect)
+	if err != nil {
+		return err
+	}
+	localBranches = excludeProtectedBranches(localBranches, protected, "")
+	remoteBranches = excludeProtectedBranches(remoteBranches, protected, gitClient.Remote()+"/")
+
+	if mergedOnly {
+		if err := gitClient.FetchRemote(); err != nil {
+			fmt.Printf("⚠️  Warning: %v; checking against local branches\n", err)
+		}
+		// Merged on the remote or in a local merge that is not pushed yet
+		targets := []string{rollbackTarget, gitClient.RemoteRef(rollbackTarget)}
+		localBranches = keepMergedBranches(gitClient, localBranches, "", targets)
+		remoteBranches = keepMergedBranches(gitClient, remoteBranches, gitClient.Remote()+"/", targets)
+	}
+
 	// Display what would be deleted
 	if len(localBranches) == 0 && len(remoteBranches) == 0 {
 		fmt.Printf("✅ No branches found with prefix '%s'\n", branchPrefix)
 		return nil
 	}
 
+	if rollbackSelect {
+		localBranches, remoteBranches = selectBranches(localBranches, remoteBranches, gitClient.Remote())
+		if len(localBranches) == 0 && len(remoteBranches) == 0 {
+			fmt.Println("✅ No branches selected; nothing to delete")
+			return nil
+		}
+	}
+
 	fmt.Printf("📋 Found branches to delete:\n")
 	fmt.Println()
 
@@ -108,6 +178,12 @@ func runRollback(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	unlock, err := lockRepository(gitClient)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Perform rollback
 	return performRollback(gitClient, localBranches, remoteBranches, originalBranch)
 }
@@ -140,6 +216,18 @@ func performRollback(gitClient *git.Client, localBranches, remoteBranches []stri
 		}
 	}
 
+	// Tracking refs of branches that were already gone on the remote are left behind by the
+	// deletion and would be matched again by the next rollback
+	if len(remoteBranches) > 0 {
+		pruned, err := gitClient.PruneTrackingRefs(remoteBranches)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: Could not prune remote-tracking refs: %v\n", err)
+		}
+		for _, ref := range pruned {
+			fmt.Printf("🧹 Pruned stale remote-tracking ref: %s\n", ref)
+		}
+	}
+
 	// Delete local branches
 	for _, branch := range localBranches {
 		if branch == safetyBranch {
@@ -161,6 +249,101 @@ func performRollback(gitClient *git.Client, localBranches, remoteBranches []stri
 	return nil
 }
 
+// rollbackChoice is a branch offered for deletion in interactive selection
+type rollbackChoice struct {
+	branch   string
+	remote   bool
+	selected bool
+}
+
+// selectBranches lets the user toggle which of the matching branches to delete, all selected
+// to start with, and returns the selected local and remote branches
+func selectBranches(localBranches, remoteBranches []string, remote string) ([]string, []string) {
+	var choices []rollbackChoice
+	for _, branch := range localBranches {
+		choices = append(choices, rollbackChoice{branch: branch, selected: true})
+	}
+	for _, branch := range remoteBranches {
+		choices = append(choices, rollbackChoice{branch: branch, remote: true, selected: true})
+	}
+
+	for {
+		fmt.Println("Select branches to delete:")
+		for i, choice := range choices {
+			mark, name := " ", choice.branch
+			if choice.selected {
+				mark = "x"
+			}
+			if choice.remote {
+				name = remote + "/" + name
+			}
+			fmt.Printf("  [%s] %2d. %s\n", mark, i+1, name)
+		}
+		fmt.Print("Toggle numbers or ranges (e.g. \"2 4-6\"), 'all', 'none', or Enter to continue: ")
+
+		input, err := stdin.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input == "" || err != nil {
+			break
+		}
+		switch input {
+		case "all", "none":
+			for i := range choices {
+				choices[i].selected = input == "all"
+			}
+		default:
+			indexes, err := parseSelection(input, len(choices))
+			if err != nil {
+				fmt.Printf("⚠️  %v\n", err)
+				continue
+			}
+			for _, i := range indexes {
+				choices[i].selected = !choices[i].selected
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+
+	var local, remoteSelected []string
+	for _, choice := range choices {
+		switch {
+		case !choice.selected:
+		case choice.remote:
+			remoteSelected = append(remoteSelected, choice.branch)
+		default:
+			local = append(local, choice.branch)
+		}
+	}
+	return local, remoteSelected
+}
+
+// parseSelection turns "2 4-6" or "2,4-6" into zero-based indexes below count
+func parseSelection(input string, count int) ([]int, error) {
+	var indexes []int
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' }) {
+		from, to, isRange := strings.Cut(field, "-")
+		if !isRange {
+			to = from
+		}
+		start, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, fmt.Errorf("not a branch number: %s", field)
+		}
+		end, err := strconv.Atoi(to)
+		if err != nil {
+			return nil, fmt.Errorf("not a branch number: %s", field)
+		}
+		if start < 1 || end > count || start > end {
+			return nil, fmt.Errorf("no branches %s (choose from 1-%d)", field, count)
+		}
+		for i := start; i <= end; i++ {
+			indexes = append(indexes, i-1)
+		}
+	}
+	return indexes, nil
+}
+
 // findLocalBranchesWithPrefix finds local branches matching the prefix
 func findLocalBranchesWithPrefix(gitClient *git.Client, prefix string) ([]string, error) {
 	branches, err := gitClient.GetLocalBranches()
@@ -187,9 +370,9 @@ func findRemoteBranchesWithPrefix(gitClient *git.Client, prefix string) ([]strin
 
 	var matching []string
 	for _, branch := range branches {
-		// Remove origin/ prefix for consistency (assumes origin remote)
-		cleanBranch := strings.TrimPrefix(branch, "origin/")
-		if strings.HasPrefix(cleanBranch, prefix) {
+		// Only branches of the remote being cleaned, without its name for consistency
+		cleanBranch, ok := strings.CutPrefix(branch, gitClient.Remote()+"/")
+		if ok && strings.HasPrefix(cleanBranch, prefix) {
 			matching = append(matching, cleanBranch)
 		}
 	}
@@ -197,13 +380,102 @@ func findRemoteBranchesWithPrefix(gitClient *git.Client, prefix string) ([]strin
 	return matching, nil
 }
 
+// excludeForeignBranches drops remote branches whose tip commit has no Pr-Split-Run trailer
+// of a run recorded in this repository. Partition commits keep the source commits' authors,
+// so the author does not tell whose split a branch belongs to.
+func excludeForeignBranches(gitClient *git.Client, branches []string) []string {
+	ownRuns := make(map[string]bool)
+	if _, st, err := loadState(gitClient); err != nil {
+		fmt.Printf("⚠️  Warning: Could not read run state: %v\n", err)
+	} else {
+		for _, run := range st.Runs {
+			ownRuns[run.ID] = true
+		}
+	}
+
+	var own []string
+	for _, branch := range branches {
+		owner, err := gitClient.GetBranchOwner(gitClient.Remote() + "/" + branch)
+		switch {
+		case err != nil || owner.RunID == "":
+			fmt.Printf("⚠️  Skipping %s: not created by a split run (use --all-users to include)\n", branch)
+		case !ownRuns[owner.RunID]:
+			fmt.Printf("⚠️  Skipping %s: created by run %s, which is not recorded here (use --all-users to include)\n",
+				branch, owner.RunID)
+		default:
+			own = append(own, branch)
+		}
+	}
+	return own
+}
+
+// excludeProtectedBranches drops protected branches such as main or the target, which a
+// prefix like "ma" would otherwise match
+func excludeProtectedBranches(branches []string, protected git.ProtectedBranches, refPrefix string) []string {
+	var deletable []string
+	for _, branch := range branches {
+		if protected.Protects(branch) {
+			fmt.Printf("🛡️  Skipping protected branch: %s%s\n", refPrefix, branch)
+			continue
+		}
+		deletable = append(deletable, branch)
+	}
+	return deletable
+}
+
+// keepMergedBranches drops branches with changes that are in none of the targets yet
+func keepMergedBranches(gitClient *git.Client, branches []string, refPrefix string, targets []string) []string {
+	var merged []string
+	for _, branch := range branches {
+		if !isMergedIntoAny(gitClient, refPrefix+branch, targets) {
+			fmt.Printf("⏭️  Keeping unmerged branch: %s%s\n", refPrefix, branch)
+			continue
+		}
+		merged = append(merged, branch)
+	}
+	return merged
+}
+
+// isMergedIntoAny reports whether every change of branch is in at least one of the targets
+func isMergedIntoAny(gitClient *git.Client, branch string, targets []string) bool {
+	for _, target := range targets {
+		if gitClient.IsMergedInto(branch, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterBranchesByRun keeps branches whose tip commit carries the given run id trailer
+func filterBranchesByRun(gitClient *git.Client, branches []string, refPrefix, runID string) []string {
+	var matching []string
+	for _, branch := range branches {
+		if owner, err := gitClient.GetBranchOwner(refPrefix + branch); err == nil && owner.RunID == runID {
+			matching = append(matching, branch)
+		}
+	}
+	return matching
+}
+
+// protectedBranches combines the built-in protected branches with the target and --protect
+// flags of a command and the protected_branches and target_branch of the config files
+func protectedBranches(gitClient *git.Client, target string, extra []string) (git.ProtectedBranches, error) {
+	cfg, _, err := config.Load(repoConfigPath(gitClient, ""), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+	protect := append(append([]string(nil), extra...), cfg.ProtectedBranches...)
+	if cfg.TargetBranch != "" {
+		protect = append(protect, cfg.TargetBranch)
+	}
+	return git.NewProtectedBranches(target, protect), nil
+}
+
 // promptForConfirmation asks user for yes/no confirmation
 func promptForConfirmation(message string) bool {
-	reader := bufio.NewReader(os.Stdin)
-
 	for {
 		fmt.Printf("%s [y/N]: ", message)
-		input, err := reader.ReadString('\n')
+		input, err := stdin.ReadString('\n')
 		if err != nil {
 			fmt.Printf("Error reading input: %v\n", err)
 			continue
@@ -235,4 +507,12 @@ func containsString(slice []string, str string) bool {
 func init() {
 	// Add dry-run flag to rollback command
 	rollbackCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview what would be deleted without actually deleting")
+	rollbackCmd.Flags().BoolVarP(&rollbackSelect, "interactive", "i", false, "Choose which of the matching branches to delete")
+	rollbackCmd.Flags().BoolVar(&mergedOnly, "merged-only", false, "Only delete branches whose changes are already in the target branch")
+	rollbackCmd.Flags().StringVar(&rollbackRunID, "run", "", "Only delete branches created by this run id (Pr-Split-Run trailer)")
+	rollbackCmd.Flags().BoolVar(&allUsers, "all-users", false, "Also delete remote branches not created by a split run recorded in this repository")
+	rollbackCmd.Flags().StringVar(&rollbackRemote, "remote", git.DefaultRemote, "Remote to delete branches from, e.g. your fork")
+	rollbackCmd.Flags().StringVarP(&rollbackTarget, "target", "t", config.ConfigDefaults.TargetBranch, "Target branch of the split, never deleted")
+	rollbackCmd.Flags().StringSliceVar(&rollbackProtect, "protect", nil, "Branch name or glob to never delete, on top of main, master, develop, release/* and protected_branches (repeatable)")
+	rollbackCmd.Flags().StringVar(&rollbackNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
 }
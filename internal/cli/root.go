@@ -1,9 +1,32 @@
 package cli
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/output"
+	"pr-splitter-cli/internal/provider"
+
 	"github.com/spf13/cobra"
 )
 
+var (
+	commandTimeout time.Duration
+	cancelTimeout  context.CancelFunc = func() {}
+	repoPath       string
+	noColor        bool
+	forceColor     bool
+	traceGit       bool
+	restoreOutput  = func() {}
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "pr-split",
 	Short: "Intelligently break large PRs into smaller, reviewable partitions",
@@ -14,18 +37,103 @@ Examples:
   pr-split break feature/large-branch    Break a branch into partitions
   pr-split --help                        Show help information`,
 	Version: "1.0.0",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		style := output.StyleAuto
+		if noColor {
+			style = output.StylePlain
+		} else if forceColor {
+			style = output.StyleEmoji
+		}
+		restore, err := output.Setup(style)
+		if err != nil {
+			return fmt.Errorf("failed to set up plain output: %w", err)
+		}
+		restoreOutput = restore
+
+		// Traces go to stderr so they stay out of output that is piped or parsed
+		if traceGit {
+			git.SetTrace(os.Stderr)
+		}
+		if repoPath != "" {
+			if err := enterRepository(repoPath); err != nil {
+				return err
+			}
+		}
+		if commandTimeout > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), commandTimeout)
+			cmd.SetContext(ctx)
+			cancelTimeout = cancel
+		}
+		return nil
+	},
+}
+
+// enterRepository makes the checkout at path the working directory of the command, like
+// 'git -C', so git, plugins, validation and relative paths all refer to it
+func enterRepository(path string) error {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("invalid --repo path: %w", err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("--repo %s is not a directory", path)
+	}
+	if err := git.Run(exec.Command("git", "-C", dir, "rev-parse", "--git-dir")); err != nil {
+		return fmt.Errorf("--repo %s is not a git checkout", path)
+	}
+	return os.Chdir(dir)
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// The first SIGINT or SIGTERM cancels running git and plugin processes so the command can
+// roll back; a second one terminates immediately.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer func() { cancelTimeout() }()
+	defer func() { restoreOutput() }()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		signal.Stop(signals)
+		fmt.Printf("\n🛑 Received %s: stopping and rolling back (press Ctrl-C again to exit immediately)\n", sig)
+		cancel()
+	}()
+
+	registerCompletions()
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
 	// Add child commands here
 	rootCmd.AddCommand(breakCmd)
 	rootCmd.AddCommand(rollbackCmd)
+	rootCmd.AddCommand(cleanupCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(pluginCmd)
+	rootCmd.AddCommand(commentsCmd)
+	rootCmd.AddCommand(summaryCmd)
+	rootCmd.AddCommand(retargetCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(resumeCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(demoCmd)
+
+	rootCmd.PersistentFlags().StringVarP(&repoPath, "repo", "C", "", "Run as if started in this checkout, like 'git -C' (default the current directory)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Plain ASCII output without emoji or box drawing (default when stdout is not a terminal or NO_COLOR is set)")
+	rootCmd.PersistentFlags().BoolVar(&forceColor, "color", false, "Emoji output even when stdout is not a terminal, e.g. in CI log viewers that render it")
+	rootCmd.PersistentFlags().BoolVar(&traceGit, "trace-git", false, "Log every git command with its working directory, duration and exit code to stderr, with its stderr when it fails")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "Cancel the command and roll back after this long, e.g. 10m (default no limit)")
+	rootCmd.PersistentFlags().IntVar(&apiConcurrency, "api-concurrency", provider.DefaultLimits.Concurrency, "Maximum concurrent provider API requests")
+	rootCmd.PersistentFlags().Float64Var(&apiRateLimit, "api-rate-limit", provider.DefaultLimits.RequestsPerSecond, "Maximum provider API requests per second, 0 for no limit")
+	rootCmd.PersistentFlags().IntVar(&apiMaxRetries, "api-retries", provider.DefaultLimits.MaxRetries, "Retries after provider rate limit responses")
 
 	// Global flags can be added here if needed
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.pr-splitter.yaml)")
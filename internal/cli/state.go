@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/state"
+	"pr-splitter-cli/internal/types"
+)
+
+// loadState reads the run records of the repository
+func loadState(gitClient *git.Client) (*state.Store, *state.State, error) {
+	gitDir, err := gitClient.GitCommonDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	store := state.NewStore(gitDir)
+	st, err := store.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return store, st, nil
+}
+
+// displayRunRecord prints a one-paragraph summary of a recorded run
+func displayRunRecord(run *state.Run) {
+	fmt.Printf("🆔 Run %s: %s, started %s\n", run.ID, run.Status, run.StartedAt.Local().Format("2006-01-02 15:04"))
+	fmt.Printf("   %s (%s) → %s (%s), %d branches pushed\n",
+		run.SourceBranch, shortCommit(run.SourceCommit), run.TargetBranch, shortCommit(run.TargetCommit), len(run.Branches))
+	if run.Error != "" {
+		fmt.Printf("   Error: %s\n", run.Error)
+	}
+
+	if len(run.Validation) == 0 {
+		return
+	}
+	counts := make(map[types.ValidationStatus]int)
+	for _, result := range run.Validation {
+		counts[result.Status]++
+	}
+	fmt.Printf("   Validation: %d passed, %d warnings, %d failed\n",
+		counts[types.ValidationStatusPass], counts[types.ValidationStatusWarn], counts[types.ValidationStatusFail])
+	for _, result := range run.Validation {
+		if result.Status == types.ValidationStatusFail {
+			fmt.Printf("   ❌ %s: %s\n", result.Type, result.Message)
+		}
+	}
+}
+
+// lockRepository takes the repository lock for the running command; call the returned function to release it
+func lockRepository(gitClient *git.Client) (func(), error) {
+	gitDir, err := gitClient.GitCommonDir()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := state.AcquireLock(gitDir, "pr-split "+strings.Join(os.Args[1:], " "))
+	if err != nil {
+		return nil, err
+	}
+	if lock.Stale != nil {
+		fmt.Printf("🔓 Removed stale lock of pid %d (%s)\n", lock.Stale.PID, lock.Stale.Command)
+	}
+	return lock.Release, nil
+}
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+	"pr-splitter-cli/internal/state"
+
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status [branch-prefix]",
+	Short: "Show where a split stands",
+	Long: `Print one row per partition branch: whether it exists locally and on origin,
+how far it is ahead of and behind the target, whether it still builds on the
+partition before it, whether it is still the commit the last recorded run pushed,
+and (with --api) the state of its PR and its number of unresolved review threads. The last run's validation results are
+shown above the table.
+
+Examples:
+  pr-split status pr-split
+  pr-split status pr-split --api
+  pr-split status --namespace "split/{user}"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStatus,
+}
+
+var (
+	statusNamespace string
+	statusTarget    string
+	statusAPI       bool
+)
+
+// partitionStatus is one row of the status table
+type partitionStatus struct {
+	branch string
+	local  bool
+	remote bool
+	ahead  int
+	behind int
+	stack  string
+	run    string // Whether the tip is still the commit the last run pushed
+	pr     string
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	prefix := ""
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.CheckRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+	if statusNamespace != "" {
+		prefix = namespacedPrefix(gitClient, statusNamespace, prefix)
+	} else if prefix == "" {
+		return fmt.Errorf("a branch prefix is required unless --namespace is set")
+	}
+
+	target := statusTarget
+	if target == "" {
+		target = config.ConfigDefaults.TargetBranch
+	}
+
+	rows, err := collectPartitionStatus(gitClient, prefix, target)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no branches found with prefix '%s'", prefix)
+	}
+
+	run := addRunStatus(gitClient, rows)
+	if statusAPI {
+		addPullRequestStatus(gitClient, rows)
+	}
+
+	if run != nil {
+		displayRunRecord(run)
+		fmt.Println()
+	}
+	displayPartitionStatus(rows, target)
+	return nil
+}
+
+// collectPartitionStatus gathers git state for every local or remote branch of the split
+func collectPartitionStatus(gitClient *git.Client, prefix, target string) ([]*partitionStatus, error) {
+	local, err := findLocalBranchesWithPrefix(gitClient, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find local branches: %w", err)
+	}
+	remote, err := findRemoteBranchesWithPrefix(gitClient, prefix)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not list remote branches: %v\n", err)
+	}
+
+	byBranch := make(map[string]*partitionStatus)
+	var names []string
+	for _, branch := range local {
+		byBranch[branch] = &partitionStatus{branch: branch, local: true}
+		names = append(names, branch)
+	}
+	for _, branch := range remote {
+		if row, ok := byBranch[branch]; ok {
+			row.remote = true
+			continue
+		}
+		byBranch[branch] = &partitionStatus{branch: branch, remote: true}
+		names = append(names, branch)
+	}
+
+	var rows []*partitionStatus
+	previous := ""
+	for _, branch := range sortPartitionBranches(names) {
+		row := byBranch[branch]
+		ref := branch
+		if !row.local {
+			ref = gitClient.Remote() + "/" + branch
+		}
+
+		if ahead, behind, err := gitClient.AheadBehind(target, ref); err == nil {
+			row.ahead, row.behind = ahead, behind
+		}
+
+		switch {
+		case previous == "":
+			row.stack = "base"
+		case gitClient.IsAncestor(previous, ref):
+			row.stack = "stacked"
+		case gitClient.IsAncestor(target, ref) || row.behind == 0:
+			row.stack = "on target"
+		default:
+			row.stack = "needs restack"
+		}
+		previous = ref
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// addRunStatus compares branch tips with the most recent recorded run that pushed any of them
+func addRunStatus(gitClient *git.Client, rows []*partitionStatus) *state.Run {
+	_, st, err := loadState(gitClient)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not read run state: %v\n", err)
+		return nil
+	}
+
+	var run *state.Run
+	for _, row := range rows {
+		if candidate := st.LatestForBranch(row.branch); candidate != nil && (run == nil || candidate.StartedAt.After(run.StartedAt)) {
+			run = candidate
+		}
+	}
+	if run == nil {
+		return nil
+	}
+
+	for _, row := range rows {
+		record := run.Branch(row.branch)
+		ref := row.branch
+		if !row.local {
+			ref = gitClient.Remote() + "/" + row.branch
+		}
+		switch tip, _ := gitClient.ResolveCommit(ref); {
+		case record == nil:
+			row.run = "not in run"
+		case tip == record.Commit:
+			row.run = "as pushed"
+		default:
+			row.run = "changed"
+		}
+	}
+	return run
+}
+
+// addPullRequestStatus fills in the PR column from GitHub, with the unresolved review threads
+// of open PRs
+func addPullRequestStatus(gitClient *git.Client, rows []*partitionStatus) {
+	github, err := newGitHubClient(gitClient, nil)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not connect to GitHub: %v\n", err)
+		return
+	}
+
+	branches := make([]string, len(rows))
+	for i, row := range rows {
+		branches[i] = row.branch
+	}
+
+	pulls, errs := github.FindLatestPullRequests(branches)
+	for i, row := range rows {
+		if errs[i] != nil {
+			row.pr = "⚠️  lookup failed"
+			continue
+		}
+		row.pr = provider.PartitionPR{Branch: row.branch, PR: pulls[i]}.Status()
+		if pulls[i] == nil {
+			continue
+		}
+		row.pr = fmt.Sprintf("#%d %s", pulls[i].Number, row.pr)
+
+		// Open threads are what a force-push of the branch would orphan
+		if pulls[i].State == "open" {
+			if threads, err := github.UnresolvedReviewThreads(pulls[i].Number); err != nil {
+				row.pr += ", ⚠️  threads unknown"
+			} else if len(threads) > 0 {
+				row.pr += fmt.Sprintf(", 💬 %d unresolved threads", len(threads))
+			}
+		}
+	}
+}
+
+// displayPartitionStatus prints the status table
+func displayPartitionStatus(rows []*partitionStatus, target string) {
+	yesNo := func(b bool) string {
+		if b {
+			return "✓"
+		}
+		return "✗"
+	}
+
+	fmt.Printf("📊 %d partition branches (ahead/behind %s)\n\n", len(rows), target)
+	header := fmt.Sprintf("   %-3s %-40s %-5s %-6s %-13s %-14s %-11s", "#", "BRANCH", "LOCAL", "REMOTE", "AHEAD/BEHIND", "STACK", "RUN")
+	if rows[0].pr != "" {
+		header += " PR"
+	}
+	fmt.Println(strings.TrimRight(header, " "))
+
+	for i, row := range rows {
+		line := fmt.Sprintf("   %-3d %-40s %-5s %-6s %-13s %-14s %-11s", i+1, row.branch, yesNo(row.local), yesNo(row.remote),
+			fmt.Sprintf("+%d/-%d", row.ahead, row.behind), row.stack, orDash(row.run))
+		if row.pr != "" {
+			line += " " + row.pr
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+func init() {
+	statusCmd.Flags().StringVar(&statusNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
+	statusCmd.Flags().StringVarP(&statusTarget, "target", "t", "", "Target branch (default \"main\")")
+	statusCmd.Flags().BoolVar(&statusAPI, "api", false, "Also show the state of each partition's PR on GitHub")
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+	"pr-splitter-cli/internal/types"
+
+	"github.com/spf13/cobra"
+)
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary <source-branch> [branch-prefix]",
+	Short: "Post or refresh the split summary comment on the original PR",
+	Long: `Post a summary comment on the original (unsplit) PR listing every partition PR
+and its state, or update the existing summary in place.
+
+Run it again as partitions merge to keep the progress current. The summary is
+also posted automatically by 'pr-split break --post-summary'.
+
+Requires a GitHub origin remote and a token in GITHUB_TOKEN or GH_TOKEN.
+
+Examples:
+  pr-split summary feature/large-branch pr-split
+  pr-split summary feature/large-branch --namespace "split/{user}"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSummary,
+}
+
+var summaryNamespace string
+
+func runSummary(cmd *cobra.Command, args []string) error {
+	sourceBranch := args[0]
+	prefix := ""
+	if len(args) > 1 {
+		prefix = args[1]
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+
+	if summaryNamespace != "" {
+		prefix = namespacedPrefix(gitClient, summaryNamespace, prefix)
+	} else if prefix == "" {
+		return fmt.Errorf("a branch prefix is required unless --namespace is set")
+	}
+
+	branches, err := findLocalBranchesWithPrefix(gitClient, prefix)
+	if err != nil {
+		return fmt.Errorf("failed to find local branches: %w", err)
+	}
+	if len(branches) == 0 {
+		return fmt.Errorf("no branches found with prefix '%s'", prefix)
+	}
+
+	branches = sortPartitionBranches(branches)
+
+	// Recover the run id from the partition commit trailer
+	runID := ""
+	if owner, err := gitClient.GetBranchOwner(branches[0]); err == nil {
+		runID = owner.RunID
+	}
+
+	return postSplitSummary(gitClient, nil, runID, sourceBranch, branches)
+}
+
+// postSplitSummary posts or updates the summary comment on the source branch's PR
+func postSplitSummary(gitClient *git.Client, cfg *types.Config, runID, sourceBranch string, branches []string) error {
+	github, err := newGitHubClient(gitClient, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to GitHub: %w", err)
+	}
+
+	original, err := github.FindPullRequest(sourceBranch)
+	if err != nil {
+		return fmt.Errorf("failed to look up PR for %s: %w", sourceBranch, err)
+	}
+	if original == nil {
+		return fmt.Errorf("no open PR found for %s", sourceBranch)
+	}
+
+	pulls, errs := github.FindLatestPullRequests(branches)
+	partitions := make([]provider.PartitionPR, len(branches))
+	for i, branch := range branches {
+		if errs[i] != nil {
+			fmt.Printf("⚠️  Warning: Could not look up PR for %s: %v\n", branch, errs[i])
+		}
+		partitions[i] = provider.PartitionPR{Branch: branch, PR: pulls[i]}
+	}
+
+	created, err := github.UpsertSummaryComment(original.Number, provider.RenderSplitSummary(partitions, runID))
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Printf("📣 Posted split summary on #%d\n", original.Number)
+	} else {
+		fmt.Printf("🔄 Updated split summary on #%d\n", original.Number)
+	}
+	return nil
+}
+
+// partitionNumberPattern finds the partition number in a branch name
+var partitionNumberPattern = regexp.MustCompile(`(?:^|[-/_])(\d+)(?:[-/_]|$)`)
+
+// sortPartitionBranches orders branches by partition number so 10 sorts after 9
+func sortPartitionBranches(branches []string) []string {
+	number := func(branch string) int {
+		if match := partitionNumberPattern.FindStringSubmatch(branch); match != nil {
+			n, _ := strconv.Atoi(match[1])
+			return n
+		}
+		return 0
+	}
+
+	sorted := append([]string(nil), branches...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if number(sorted[i]) != number(sorted[j]) {
+			return number(sorted[i]) < number(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+func init() {
+	summaryCmd.Flags().StringVar(&summaryNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
+}
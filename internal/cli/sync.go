@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/splitter"
+
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync <source-branch> [branch-prefix]",
+	Short: "Carry new source branch commits into an existing split",
+	Long: `Update the partition branches of an existing split after the original branch
+got new commits, instead of rolling back and splitting again.
+
+Modified files are committed to the partition that already contains them. New files
+join the latest partition they depend on (or the earliest one depending on them);
+files unrelated to every partition get a new partition at the end of the stack.
+Branches stacked on an updated branch are rebased, and everything is pushed with
+--force-with-lease.
+
+Examples:
+  pr-split sync feature/large-branch pr-split --dry-run
+  pr-split sync feature/large-branch pr-split
+  pr-split sync feature/large-branch --namespace "split/{user}"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSync,
+}
+
+var (
+	syncNamespace string
+	syncTarget    string
+	syncDryRun    bool
+	syncNoVerify  bool
+	syncConfig    string
+	syncProfile   string
+)
+
+func runSync(cmd *cobra.Command, args []string) error {
+	sourceBranch := args[0]
+	prefix := ""
+	if len(args) > 1 {
+		prefix = args[1]
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+	if !syncDryRun {
+		unlock, err := lockRepository(gitClient)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	branches, err := partitionBranchesFor(gitClient, prefix, syncNamespace)
+	if err != nil {
+		return err
+	}
+
+	cfg, _, err := config.Load(repoConfigPath(gitClient, syncConfig), syncProfile)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+	if syncTarget != "" {
+		cfg.TargetBranch = syncTarget
+	}
+	if syncNoVerify {
+		cfg.NoVerify = true
+	}
+
+	fmt.Printf("🔄 Syncing %d partition branches with %s\n", len(branches), sourceBranch)
+	var opts splitter.Options
+	if !syncDryRun {
+		opts.ForcePushCheck = forcePushCheck(gitClient, cfg, true)
+	}
+	s := splitter.NewWithOptions(opts)
+	plan, err := s.PlanSync(cmd.Context(), sourceBranch, branches, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to plan sync: %w", err)
+	}
+	if len(plan.Updated) == 0 {
+		fmt.Println("✅ The split is already up to date")
+		return nil
+	}
+
+	displaySyncPlan(plan)
+	if syncDryRun {
+		fmt.Println("🔍 Dry run: no branches were changed")
+		return nil
+	}
+
+	if err := s.ApplySync(cmd.Context(), plan); err != nil {
+		return fmt.Errorf("failed to sync split: %w", err)
+	}
+	for _, branch := range plan.Restacked {
+		fmt.Printf("🔁 Rebased %s onto its updated base\n", branch)
+	}
+	fmt.Println("🎉 Split is up to date")
+	return nil
+}
+
+// displaySyncPlan lists the files synced into each branch
+func displaySyncPlan(result *splitter.SyncPlan) {
+	branches := append([]string(nil), result.Branches...)
+	if result.NewBranch != "" {
+		branches = append(branches, result.NewBranch)
+	}
+	for _, branch := range branches {
+		files := result.Updated[branch]
+		if len(files) == 0 {
+			continue
+		}
+		label := ""
+		if branch == result.NewBranch {
+			label = " (new partition)"
+		}
+		fmt.Printf("📦 %s%s: %d files\n", branch, label, len(files))
+		for _, file := range files {
+			fmt.Printf("   - %s\n", file)
+		}
+	}
+}
+
+func init() {
+	syncCmd.Flags().StringVar(&syncNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
+	syncCmd.Flags().StringVarP(&syncTarget, "target", "t", "", "Target branch (default \"main\")")
+	syncCmd.Flags().StringVarP(&syncConfig, "config", "c", "", "Config file with analysis settings (include paths, plugin priority, ...)")
+	syncCmd.Flags().StringVar(&syncProfile, "profile", "", "Named profile of the config file to use")
+	syncCmd.Flags().BoolVar(&syncNoVerify, "no-verify", false, "Skip git hooks (pre-commit, commit-msg, pre-push) on the commits and pushes of the sync")
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show where new changes would go without changing any branch")
+}
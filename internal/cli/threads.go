@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+	"pr-splitter-cli/internal/types"
+)
+
+// reviewThreadCheck warns before a force-push rewrites files of a PR with unresolved review
+// threads, since the push orphans their anchors. On a terminal it asks whether to push anyway.
+func reviewThreadCheck(gitClient *git.Client, github *provider.GitHub, interactive bool) git.ForcePushCheck {
+	interactive = interactive && stdinIsTerminal()
+
+	var mu sync.Mutex // Partition branches are pushed in parallel; keep their warnings apart
+	return func(branch, oldTip, newTip string) error {
+		pr, err := github.FindPullRequest(branch)
+		if err != nil || pr == nil {
+			return nil
+		}
+		threads, err := github.UnresolvedReviewThreads(pr.Number)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: Could not fetch review threads of #%d: %v\n", pr.Number, err)
+			return nil
+		}
+
+		// A deleted branch or an unknown remote tip affects every thread
+		affected := threads
+		if oldTip != "" && newTip != "" {
+			if paths, err := gitClient.ChangedPaths(oldTip, newTip); err == nil {
+				affected = provider.ThreadsOnPaths(threads, paths)
+			}
+		}
+		if len(affected) == 0 {
+			return nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Printf("⚠️  #%d %s has %d unresolved review threads on files this push rewrites; their anchors will be orphaned:\n",
+			pr.Number, branch, len(affected))
+		for _, thread := range affected {
+			fmt.Printf("   📄 %s L%d @%s: %s\n", thread.Path, thread.Line, thread.Author, firstLine(thread.Body))
+		}
+		if interactive && !promptForConfirmation(fmt.Sprintf("Force-push %s anyway?", branch)) {
+			return fmt.Errorf("force-push of %s cancelled: %d unresolved review threads on #%d", branch, len(affected), pr.Number)
+		}
+		return nil
+	}
+}
+
+// stdinIsTerminal reports whether prompts can be answered
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// forcePushCheck returns the review thread check for the split's GitHub repository, or nil
+// when GitHub cannot be reached, e.g. without a token
+func forcePushCheck(gitClient *git.Client, cfg *types.Config, interactive bool) git.ForcePushCheck {
+	github, err := newGitHubClient(gitClient, cfg)
+	if err != nil {
+		return nil
+	}
+	return reviewThreadCheck(gitClient, github, interactive)
+}
@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/state"
+
+	"github.com/spf13/cobra"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [run-id]",
+	Short: "Undo the branches pushed by the most recent split",
+	Long: `Undo exactly what the most recent split run (or the given run) did, using the
+record in .git/pr-split/state.json instead of matching branch names by prefix.
+
+Branches the run created are deleted locally and on the remote they were pushed to. Branches it reset with
+--update are put back where the previous split left them. A branch that has moved
+since the run pushed it (someone pushed a fix, or it was rebased) is left alone
+unless --force is given; remote changes are always made with a lease, so nothing
+pushed concurrently is overwritten.
+
+Examples:
+  pr-split undo
+  pr-split undo --dry-run
+  pr-split undo 3f9c0a1b2c4d`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUndo,
+}
+
+var (
+	undoDryRun bool
+	undoForce  bool
+)
+
+// undoAction is what undo does to one branch of the run
+type undoAction struct {
+	record      state.BranchRecord
+	localTip    string // Current local tip, empty if the branch is gone
+	remoteTip   string // Current tip on the push remote, empty if the branch is gone
+	localMoved  bool
+	remoteMoved bool
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+
+	store, st, err := loadState(gitClient)
+	if err != nil {
+		return err
+	}
+
+	run := st.Latest()
+	if len(args) > 0 {
+		run = st.Find(args[0])
+		if run == nil {
+			return fmt.Errorf("no run %s in %s", args[0], store.Path())
+		}
+	}
+	if run == nil {
+		return fmt.Errorf("no split runs recorded in %s", store.Path())
+	}
+	if run.Status == state.RunStatusUndone {
+		return fmt.Errorf("run %s was already undone", run.ID)
+	}
+	gitClient.SetRemote(run.Config.PushRemote)
+
+	displayRunRecord(run)
+	fmt.Println()
+
+	if len(run.Branches) == 0 {
+		fmt.Println("✅ The run has no pushed branches to undo")
+		return nil
+	}
+
+	actions, err := planUndo(gitClient, run)
+	if err != nil {
+		return err
+	}
+	displayUndoPlan(actions, gitClient.Remote())
+
+	if undoDryRun {
+		fmt.Println("🔍 DRY RUN: No branches were changed")
+		return nil
+	}
+	pending := 0
+	for _, action := range actions {
+		if !action.skipped() {
+			pending++
+		}
+	}
+	if pending == 0 {
+		return fmt.Errorf("every branch of run %s moved since it was pushed; use --force to undo them anyway", run.ID)
+	}
+	if !promptForConfirmation(fmt.Sprintf("Undo %d branches of run %s?", pending, run.ID)) {
+		fmt.Println("❌ Undo cancelled by user")
+		return nil
+	}
+
+	unlock, err := lockRepository(gitClient)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Tag the audit log entries of the undo with the run it reverts
+	gitClient.SetRunID(run.ID)
+	// Pushes skip hooks when the run they undo did
+	gitClient.SetNoVerify(run.Config.NoVerify)
+
+	gitClient.SetForcePushCheck(forcePushCheck(gitClient, nil, true))
+	if err := leaveRunBranches(gitClient, run); err != nil {
+		return err
+	}
+
+	// Branches left alone or that failed stay in the record for a later undo
+	var remaining []state.BranchRecord
+	for _, action := range actions {
+		if action.skipped() || !applyUndo(gitClient, action) {
+			remaining = append([]state.BranchRecord{action.record}, remaining...)
+		}
+	}
+
+	run.Branches = remaining
+	run.UpdatedAt = time.Now().UTC()
+	if len(remaining) == 0 {
+		run.Status = state.RunStatusUndone
+	}
+	if err := store.SaveRun(run); err != nil {
+		fmt.Printf("⚠️  Warning: Could not record undo: %v\n", err)
+	}
+
+	if len(remaining) > 0 {
+		return fmt.Errorf("%d branches of run %s were not undone; they stay recorded so you can retry", len(remaining), run.ID)
+	}
+	fmt.Printf("🎉 Undid run %s\n", run.ID)
+	return nil
+}
+
+// planUndo compares every branch of the run with what the run pushed, last partition first
+func planUndo(gitClient *git.Client, run *state.Run) ([]undoAction, error) {
+	names := make([]string, len(run.Branches))
+	for i, record := range run.Branches {
+		names[i] = record.Name
+	}
+	owners, err := gitClient.FindRemoteOwners(names)
+	if err != nil {
+		return nil, err
+	}
+	remote := make(map[string]string)
+	for _, owner := range owners {
+		remote[owner.Branch] = owner.Commit
+	}
+
+	var actions []undoAction
+	for i := len(run.Branches) - 1; i >= 0; i-- {
+		record := run.Branches[i]
+		local, _ := gitClient.ResolveCommit("refs/heads/" + record.Name)
+		action := undoAction{record: record, localTip: local, remoteTip: remote[record.Name]}
+		action.localMoved = local != "" && local != record.Commit
+		action.remoteMoved = action.remoteTip != "" && action.remoteTip != record.Commit
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// skipped reports whether undo leaves the branch alone because it moved since the run
+func (a undoAction) skipped() bool {
+	return (a.localMoved || a.remoteMoved) && !undoForce
+}
+
+// displayUndoPlan prints what undo will do to each branch
+func displayUndoPlan(actions []undoAction, remote string) {
+	fmt.Println("📋 Undo plan:")
+	for _, action := range actions {
+		record := action.record
+		switch {
+		case action.skipped():
+			fmt.Printf("   ⏭️  %s: moved since the run pushed %s, skipping (use --force)\n", record.Name, shortCommit(record.Commit))
+		case record.Updated:
+			fmt.Printf("   ↩️  %s: restore %s\n", record.Name, describePrevious(record, remote))
+		default:
+			fmt.Printf("   🗑️  %s: delete locally and on %s\n", record.Name, remote)
+		}
+	}
+	fmt.Println()
+}
+
+// describePrevious names the commits an updated branch is restored to
+func describePrevious(record state.BranchRecord, remote string) string {
+	local, onRemote := "delete local branch", "delete on "+remote
+	if record.Previous != "" {
+		local = "local to " + shortCommit(record.Previous)
+	}
+	if record.PreviousRemote != "" {
+		onRemote = remote + " to " + shortCommit(record.PreviousRemote)
+	}
+	return local + ", " + onRemote
+}
+
+// leaveRunBranches checks out the source branch when a branch of the run is checked out
+func leaveRunBranches(gitClient *git.Client, run *state.Run) error {
+	current, err := gitClient.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+	if run.Branch(current) == nil {
+		return nil
+	}
+	fmt.Printf("💼 Checking out %s\n", run.SourceBranch)
+	return gitClient.CheckoutBranch(run.SourceBranch)
+}
+
+// applyUndo deletes or restores one branch, reporting whether it fully succeeded
+func applyUndo(gitClient *git.Client, action undoAction) bool {
+	record := action.record
+	ok := true
+	if action.remoteTip != "" || record.PreviousRemote != "" {
+		if err := gitClient.RestoreRemoteBranch(record.Name, action.remoteTip, record.PreviousRemote); err != nil {
+			fmt.Printf("⚠️  Warning: %v\n", err)
+			ok = false
+		}
+	}
+
+	var err error
+	switch {
+	case record.Updated && record.Previous != "":
+		err = gitClient.SetBranch(record.Name, record.Previous)
+	case action.localTip != "":
+		err = gitClient.DeleteLocalBranch(record.Name)
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not undo local branch %s: %v\n", record.Name, err)
+		ok = false
+	}
+
+	if ok {
+		fmt.Printf("✅ Undid %s\n", record.Name)
+	}
+	return ok
+}
+
+func init() {
+	undoCmd.Flags().BoolVar(&undoDryRun, "dry-run", false, "Show what would be undone without changing anything")
+	undoCmd.Flags().BoolVar(&undoForce, "force", false, "Also undo branches that moved since the run pushed them")
+}
@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/partition"
+	"pr-splitter-cli/internal/state"
+	"pr-splitter-cli/internal/types"
+	"pr-splitter-cli/internal/validation"
+
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [branch-prefix | plan.json | state.json]",
+	Short: "Re-run branch validation on an existing split",
+	Long: `Re-run the git integrity, branch existence and diff comparison checks of
+'pr-split break' on split branches at any later time, e.g. after rebasing them
+by hand.
+
+The branches and the changes they should cover come from, in order:
+  • a plan file saved with --plan-out, or a copy of .git/pr-split/state.json
+  • the most recent recorded run (or --run) that created branches with the prefix
+  • the local and remote branches matching the prefix, without a diff to compare
+
+The strict mode and severity overrides of the recorded run apply. The command
+exits non-zero when a check fails.
+
+Examples:
+  pr-split validate                         Branches of the last pr-split run
+  pr-split validate auth-split --json       Machine-readable results
+  pr-split validate plan.json
+  pr-split validate --run 3f9c0a1b2c4d`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runValidate,
+}
+
+var (
+	validateRunID     string
+	validateNamespace string
+	validateRemote    string
+	validateJSON      bool
+)
+
+// validationTarget is an existing split to validate
+type validationTarget struct {
+	origin       string // Where the branches were found, e.g. "run 3f9c0a1b2c4d"
+	branches     []string
+	changes      []types.FileChange
+	sourceBranch string
+	baseRef      string
+	config       types.Config
+}
+
+// validationReport is the --json output of validate
+type validationReport struct {
+	Origin   string                   `json:"origin"`
+	Branches []string                 `json:"branches"`
+	Passed   bool                     `json:"passed"`
+	Results  []types.ValidationResult `json:"results"`
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	// Keep stdout for the report in JSON mode
+	var out io.Writer = os.Stdout
+	if validateJSON {
+		out = os.Stderr
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.CheckRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+	gitClient.SetRemote(validateRemote)
+
+	target, err := resolveValidationTarget(gitClient, args)
+	if err != nil {
+		return err
+	}
+	if len(target.branches) == 0 {
+		return fmt.Errorf("no split branches found in %s", target.origin)
+	}
+
+	if validateRemote == "" {
+		gitClient.SetRemote(target.config.PushRemote)
+	}
+	if !target.config.NoPush {
+		if err := gitClient.FetchRemote(); err != nil {
+			fmt.Fprintf(out, "⚠️  Warning: %v; using the last fetched remote branches\n", err)
+		}
+	}
+
+	fmt.Fprintf(out, "🔍 Validating %d branches from %s\n\n", len(target.branches), target.origin)
+
+	validator := validation.NewValidator()
+	validator.SetOutput(out)
+	if validateJSON {
+		validator.SetOutput(io.Discard)
+	}
+	validator.SetRemote(gitClient.Remote())
+	validator.SetLocalOnly(target.config.NoPush)
+	validator.SetSeverity(target.config.StrictValidation, target.config.ValidationSeverity)
+
+	results, err := validator.RevalidateBranches(cmd.Context(), target.branches, target.changes, target.sourceBranch, target.baseRef)
+	if err != nil {
+		return err
+	}
+	passed := validator.AllPassed(results)
+
+	if validateJSON {
+		data, err := json.MarshalIndent(validationReport{
+			Origin:   target.origin,
+			Branches: target.branches,
+			Passed:   passed,
+			Results:  results,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal validation results: %w", err)
+		}
+		fmt.Println(string(data))
+	}
+
+	if !passed {
+		return fmt.Errorf("validation of %s failed", target.origin)
+	}
+	return nil
+}
+
+// resolveValidationTarget finds the branches to validate from a plan or state file, a recorded
+// run, or the branch prefix
+func resolveValidationTarget(gitClient *git.Client, args []string) (*validationTarget, error) {
+	if len(args) > 0 {
+		if info, err := os.Stat(args[0]); err == nil && !info.IsDir() {
+			return loadValidationFile(args[0])
+		}
+	}
+
+	_, st, err := loadState(gitClient)
+	if err != nil {
+		return nil, err
+	}
+	if validateRunID != "" {
+		run := st.Find(validateRunID)
+		if run == nil {
+			return nil, fmt.Errorf("no run %s recorded", validateRunID)
+		}
+		return runValidationTarget(run), nil
+	}
+
+	prefix := config.ConfigDefaults.BranchPrefix
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+	if validateNamespace != "" {
+		prefix = namespacedPrefix(gitClient, validateNamespace, prefix)
+	}
+
+	for i := len(st.Runs) - 1; i >= 0; i-- {
+		for _, branch := range st.Runs[i].Branches {
+			if strings.HasPrefix(branch.Name, prefix) {
+				return runValidationTarget(st.Runs[i]), nil
+			}
+		}
+	}
+	return prefixValidationTarget(gitClient, prefix)
+}
+
+// loadValidationFile reads a plan saved with --plan-out, or a state file whose run to validate
+// is --run or the most recent one
+func loadValidationFile(path string) (*validationTarget, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var probe struct {
+		Runs json.RawMessage `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if probe.Runs == nil {
+		plan, err := partition.LoadPlan(path)
+		if err != nil {
+			return nil, err
+		}
+		target := planValidationTarget(plan)
+		target.origin = "plan " + path
+		return target, nil
+	}
+
+	var st state.State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	run := st.Latest()
+	if validateRunID != "" {
+		run = st.Find(validateRunID)
+	}
+	if run == nil {
+		return nil, fmt.Errorf("no matching run recorded in %s", path)
+	}
+	return runValidationTarget(run), nil
+}
+
+// runValidationTarget validates every partition branch planned by a recorded run
+func runValidationTarget(run *state.Run) *validationTarget {
+	target := &validationTarget{}
+	if run.Plan != nil {
+		target = planValidationTarget(run.Plan)
+	} else {
+		for _, branch := range run.Branches {
+			target.branches = append(target.branches, branch.Name)
+		}
+	}
+	target.origin = "run " + run.ID
+	target.sourceBranch = run.SourceBranch
+	target.config = run.Config
+	return target
+}
+
+// planValidationTarget takes the branches and changed files of a partition plan
+func planValidationTarget(plan *types.PartitionPlan) *validationTarget {
+	target := &validationTarget{baseRef: plan.Metadata.BaseCommit}
+	for _, p := range plan.Partitions {
+		target.branches = append(target.branches, p.BranchName)
+		for _, file := range p.Files {
+			if file.IsChanged {
+				target.changes = append(target.changes, file)
+			}
+		}
+	}
+	return target
+}
+
+// prefixValidationTarget takes the local and remote branches matching the prefix when no run
+// recorded them, so there are no original changes to compare with
+func prefixValidationTarget(gitClient *git.Client, prefix string) (*validationTarget, error) {
+	local, err := findLocalBranchesWithPrefix(gitClient, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find local branches: %w", err)
+	}
+	remote, err := findRemoteBranchesWithPrefix(gitClient, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find remote branches: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, branch := range append(local, remote...) {
+		if !seen[branch] {
+			seen[branch] = true
+			names = append(names, branch)
+		}
+	}
+	return &validationTarget{
+		origin:   fmt.Sprintf("branches with prefix '%s'", prefix),
+		branches: sortPartitionBranches(names),
+	}, nil
+}
+
+func init() {
+	validateCmd.Flags().StringVar(&validateRunID, "run", "", "Validate the branches of this recorded run instead of the most recent matching one")
+	validateCmd.Flags().StringVar(&validateNamespace, "namespace", "", "Branch namespace the split used, e.g. \"split/{user}\"")
+	validateCmd.Flags().StringVar(&validateRemote, "remote", "", "Remote the branches are expected on (default the remote the run pushed to, or origin)")
+	validateCmd.Flags().BoolVar(&validateJSON, "json", false, "Print the validation results as JSON")
+}
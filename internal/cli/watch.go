@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/provider"
+	"pr-splitter-cli/internal/state"
+
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [branch-prefix]",
+	Short: "Keep a split moving: retarget and rebase partitions as their PRs merge",
+	Long: `Poll the partition PRs of a split and, whenever one merges, run the same
+retarget and rebase as 'pr-split retarget' on the rest of the stack.
+
+Watching stops once every partition PR is merged or closed, or when a rebase needs
+manual conflict resolution. With --webhook, each merge, restack and failure is
+POSTed as JSON (with a Slack-compatible "text" field).
+
+Requires a GitHub origin remote and a token in GITHUB_TOKEN or GH_TOKEN.
+
+Examples:
+  pr-split watch pr-split
+  pr-split watch pr-split --interval 10m --webhook https://hooks.slack.com/services/...`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWatch,
+}
+
+var (
+	watchNamespace string
+	watchInterval  time.Duration
+	watchWebhook   string
+)
+
+// watchEvent is the JSON payload sent to the webhook
+type watchEvent struct {
+	Text   string `json:"text"`
+	Event  string `json:"event"` // merged, restacked, failed or done
+	Branch string `json:"branch,omitempty"`
+	PR     int    `json:"pr,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	prefix := ""
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+	if watchInterval < 10*time.Second {
+		return fmt.Errorf("--interval must be at least 10s")
+	}
+
+	gitClient := git.NewClient().WithContext(cmd.Context())
+	if err := gitClient.ValidateGitRepository(); err != nil {
+		return fmt.Errorf("git repository validation failed: %w", err)
+	}
+
+	branches, err := partitionBranchesFor(gitClient, prefix, watchNamespace)
+	if err != nil {
+		return err
+	}
+
+	github, err := newGitHubClient(gitClient, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to GitHub: %w", err)
+	}
+
+	fmt.Printf("👀 Watching %d partition PRs every %s (Ctrl+C to stop)\n", len(branches), watchInterval)
+
+	// Merges that happened before watching started are restacked on the first poll
+	seenMerged := make(map[string]bool)
+	first := true
+	needsRestack := false
+	for {
+		pulls, errs := github.FindLatestPullRequests(branches)
+
+		var newlyMerged []*provider.PullRequest
+		pending := 0
+		failed := false
+		for i, branch := range branches {
+			if errs[i] != nil {
+				fmt.Printf("⚠️  Warning: Could not look up PR for %s: %v\n", branch, errs[i])
+				failed = true
+				continue
+			}
+			pr := pulls[i]
+			switch {
+			case pr == nil || pr.State == "open":
+				pending++
+			case pr.MergedAt != nil && !seenMerged[branch]:
+				seenMerged[branch] = true
+				newlyMerged = append(newlyMerged, pr)
+			}
+		}
+
+		for _, pr := range newlyMerged {
+			if first {
+				continue
+			}
+			fmt.Printf("🎉 #%d %s merged\n", pr.Number, pr.Head.Ref)
+			notifyWebhook(watchEvent{
+				Text:   fmt.Sprintf("Partition #%d (%s) merged", pr.Number, pr.Head.Ref),
+				Event:  "merged",
+				Branch: pr.Head.Ref,
+				PR:     pr.Number,
+				URL:    pr.HTMLURL,
+			})
+		}
+
+		if len(newlyMerged) > 0 {
+			needsRestack = true
+		}
+		if needsRestack && !failed {
+			restacked, err := restackLocked(gitClient, github, branches)
+			var locked *state.LockedError
+			switch {
+			case errors.As(err, &locked):
+				fmt.Printf("⏳ %v\n   Restacking at the next poll\n", err)
+			case err != nil:
+				notifyWebhook(watchEvent{Text: fmt.Sprintf("pr-split could not restack the split: %v", err), Event: "failed"})
+				return err
+			default:
+				needsRestack = false
+				if restacked > 0 {
+					notifyWebhook(watchEvent{Text: fmt.Sprintf("Restacked %d partition branches after merges", restacked), Event: "restacked"})
+				}
+			}
+		}
+		first = false
+
+		if pending == 0 && !failed {
+			fmt.Println("🏁 Every partition PR is merged or closed; done watching")
+			notifyWebhook(watchEvent{Text: fmt.Sprintf("All %d partition PRs are merged or closed", len(branches)), Event: "done"})
+			return nil
+		}
+
+		select {
+		case <-cmd.Context().Done():
+			fmt.Println("👋 Stopped watching")
+			return nil
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
+// restackLocked restacks under the repository lock, so a watch never rebases while another command runs
+func restackLocked(gitClient *git.Client, github *provider.GitHub, branches []string) (int, error) {
+	unlock, err := lockRepository(gitClient)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	// Nobody is there to answer, so orphaned review threads are only reported
+	gitClient.SetForcePushCheck(reviewThreadCheck(gitClient, github, false))
+	return restackPartitions(gitClient, github, branches, false)
+}
+
+// notifyWebhook posts an event to the configured webhook, warning on failure
+func notifyWebhook(event watchEvent) {
+	if watchWebhook == "" {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not encode webhook event: %v\n", err)
+		return
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(watchWebhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not notify webhook: %v\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		fmt.Printf("⚠️  Warning: Webhook responded with %s\n", resp.Status)
+	}
+}
+
+func init() {
+	watchCmd.Flags().StringVar(&watchNamespace, "namespace", "", "Only match branches under this namespace, e.g. \"split/{user}\"")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Minute, "How often to poll the partition PRs")
+	watchCmd.Flags().StringVar(&watchWebhook, "webhook", "", "URL to POST merge, restack and failure events to")
+}
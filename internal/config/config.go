@@ -4,8 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"pr-splitter-cli/internal/types"
 
@@ -19,12 +23,16 @@ var ConfigDefaults = struct {
 	BranchPrefix         string
 	Strategy             string
 	TargetBranch         string
+	BranchTemplate       string
+	ApplyMode            string
 }{
 	MaxFilesPerPartition: 15,
 	MaxPartitions:        8,
 	BranchPrefix:         "pr-split",
-	Strategy:             "dependency-first",
+	Strategy:             types.StrategyDependencyFirst,
 	TargetBranch:         "main",
+	BranchTemplate:       "{prefix}-{id}-{name}",
+	ApplyMode:            types.ApplyModeCheckout,
 }
 
 // GetFromUser prompts the user for configuration via CLI
@@ -62,6 +70,8 @@ func GetFromUser() (*types.Config, error) {
 		BranchPrefix:         branchPrefix,
 		Strategy:             ConfigDefaults.Strategy,
 		TargetBranch:         targetBranch,
+		BranchTemplate:       ConfigDefaults.BranchTemplate,
+		ApplyMode:            ConfigDefaults.ApplyMode,
 	}
 
 	if err := ValidateConfig(config); err != nil {
@@ -76,21 +86,149 @@ func GetFromUser() (*types.Config, error) {
 
 // ConfigFile represents the YAML configuration file structure
 type ConfigFile struct {
-	TargetBranch     string   `yaml:"target_branch"`
-	BranchPrefix     string   `yaml:"branch_prefix"`
-	MaxPartitionSize int      `yaml:"max_partition_size"`
-	MaxPartitions    int      `yaml:"max_partitions"`
-	Strategy         string   `yaml:"strategy"`
-	ExcludedPaths    []string `yaml:"excluded_paths"`
+	TargetBranch       string                    `yaml:"target_branch"`
+	BranchPrefix       string                    `yaml:"branch_prefix"`
+	MaxPartitionSize   int                       `yaml:"max_partition_size"`
+	MaxPartitions      int                       `yaml:"max_partitions"`
+	MaxLines           int                       `yaml:"max_lines_per_partition"`
+	Strategy           string                    `yaml:"strategy"`
+	BranchTemplate     string                    `yaml:"branch_template"`
+	BranchNamespace    string                    `yaml:"branch_namespace"`
+	PostSummary        bool                      `yaml:"post_summary"`
+	CreatePRs          bool                      `yaml:"create_prs"`
+	PRDraft            bool                      `yaml:"pr_draft"`
+	PRLabels           []string                  `yaml:"pr_labels"`
+	PRMilestone        string                    `yaml:"pr_milestone"`
+	RequestReviews     bool                      `yaml:"request_reviews"`
+	Reviewers          map[string][]string       `yaml:"reviewers"`
+	IncludePaths       []string                  `yaml:"include_paths"`
+	GeneratedCode      []types.GeneratedCodeRule `yaml:"generated_code"`
+	SeparateMechanical bool                      `yaml:"separate_mechanical"`
+	Checklist          []types.ChecklistRule     `yaml:"checklist"`
+	PluginPriority     map[string]int            `yaml:"plugin_priority"`
+	GroupByDirectory   bool                      `yaml:"group_by_directory"`
+	MinStrength        string                    `yaml:"min_dependency_strength"`
+	CoChange           bool                      `yaml:"co_change"`
+	CoChangeCommits    int                       `yaml:"co_change_commits"`
+	Teams              map[string][]string       `yaml:"teams"`
+	APIConcurrency     int                       `yaml:"api_concurrency"`
+	APIRateLimit       float64                   `yaml:"api_rate_limit"`
+	Topology           string                    `yaml:"topology"`
+	SplitHunks         []string                  `yaml:"split_hunks"`
+	ApplyMode          string                    `yaml:"apply_mode"`
+	Parallelism        int                       `yaml:"parallelism"`
+	PushRemote         string                    `yaml:"push_remote"`
+	UpstreamRemote     string                    `yaml:"upstream_remote"`
+	PushOptions        []string                  `yaml:"push_options"`
+	NoPush             bool                      `yaml:"no_push"`
+	NoVerify           bool                      `yaml:"no_verify"`
+	SignCommits        bool                      `yaml:"sign_commits"`
+	SignOff            bool                      `yaml:"signoff"`
+	CoAuthors          bool                      `yaml:"co_authors"`
+	OperatorAuthor     bool                      `yaml:"operator_author"`
+	ProtectedBranches  []string                  `yaml:"protected_branches"`
+	PartitionChecks    []string                  `yaml:"partition_checks"`
+	CheckPolicy        string                    `yaml:"check_policy"`
+	Strict             bool                      `yaml:"strict"`
+	ValidationSeverity map[string]string         `yaml:"validation_severity"`
+	BinaryFiles        string                    `yaml:"binary_files"`
+	ExcludeTests       bool                      `yaml:"exclude_tests"`
+	PairingRules       []types.PairingRule       `yaml:"pairing_rules"`
+	SkipProjectGraph   bool                      `yaml:"skip_project_graph"`
+	GitHubTokenEnv     string                    `yaml:"github_token_env"`
+	ContextDepth       int                       `yaml:"context_depth"`
+	ContextCacheTTL    string                    `yaml:"context_cache_ttl"`
+	ExcludedPaths      []string                  `yaml:"excluded_paths"`
 }
 
 // LoadFromFile loads configuration from a YAML file
 func LoadFromFile(filePath string) (*types.Config, error) {
+	return LoadProfile(filePath, "")
+}
+
+// LoadProfile loads configuration from a YAML file, with the settings of the named profile under
+// "profiles" replacing top-level settings of the same key; an empty profile loads the top-level
+// settings alone
+func LoadProfile(filePath, profile string) (*types.Config, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	settings, profiles, err := parseSettings(data)
+	if err != nil {
+		return nil, err
+	}
+	if settings, err = applyProfile(settings, profiles, profile); err != nil {
+		return nil, err
+	}
+	return fromSettings(settings)
+}
+
+// Load builds the configuration from, lowest precedence first: the defaults, the user config
+// file, the repository config file at repoPath (optional) and PR_SPLIT_* environment variables.
+// The named profile, or else PR_SPLIT_PROFILE, is looked up among the profiles of both files.
+// Flags are for the caller to apply on top. It also returns the sources that set anything.
+func Load(repoPath, profile string) (*types.Config, []string, error) {
+	var settings yaml.MapSlice
+	profiles := make(map[string]yaml.MapSlice)
+	var sources []string
+
+	userPath := UserConfigPath()
+	for _, filePath := range []string{userPath, repoPath} {
+		if filePath == "" {
+			continue
+		}
+		data, err := os.ReadFile(filePath)
+		if filePath == userPath && os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		fileSettings, fileProfiles, err := parseSettings(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+		settings = overlay(settings, fileSettings)
+		for name, overrides := range fileProfiles {
+			profiles[name] = overlay(profiles[name], overrides)
+		}
+		sources = append(sources, filePath)
+	}
+
+	if profile == "" {
+		profile = os.Getenv(ProfileEnvVar)
+	}
+	settings, err := applyProfile(settings, profiles, profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	environment, err := environmentSettings()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(environment) > 0 {
+		settings = overlay(settings, environment)
+		sources = append(sources, "environment")
+	}
+
+	cfg, err := fromSettings(settings)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, sources, nil
+}
+
+// fromSettings converts merged YAML settings to a validated configuration with defaults
+func fromSettings(settings yaml.MapSlice) (*types.Config, error) {
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge config settings: %w", err)
+	}
+
 	var configFile ConfigFile
 	if err := yaml.Unmarshal(data, &configFile); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML config: %w", err)
@@ -103,6 +241,8 @@ func LoadFromFile(filePath string) (*types.Config, error) {
 		BranchPrefix:         ConfigDefaults.BranchPrefix,
 		Strategy:             ConfigDefaults.Strategy,
 		TargetBranch:         ConfigDefaults.TargetBranch,
+		BranchTemplate:       ConfigDefaults.BranchTemplate,
+		ApplyMode:            ConfigDefaults.ApplyMode,
 	}
 
 	// Apply values from file
@@ -121,6 +261,65 @@ func LoadFromFile(filePath string) (*types.Config, error) {
 	if configFile.Strategy != "" {
 		config.Strategy = configFile.Strategy
 	}
+	if configFile.BranchTemplate != "" {
+		config.BranchTemplate = configFile.BranchTemplate
+	}
+	if configFile.BranchNamespace != "" {
+		config.BranchNamespace = configFile.BranchNamespace
+	}
+	config.MaxLinesPerPartition = configFile.MaxLines
+	config.PostSummary = configFile.PostSummary
+	config.CreatePRs = configFile.CreatePRs
+	config.PRDraft = configFile.PRDraft
+	config.PRLabels = configFile.PRLabels
+	config.PRMilestone = configFile.PRMilestone
+	config.RequestReviews = configFile.RequestReviews
+	config.Reviewers = configFile.Reviewers
+	config.IncludePaths = configFile.IncludePaths
+	config.GeneratedCode = configFile.GeneratedCode
+	config.SeparateMechanical = configFile.SeparateMechanical
+	config.ChecklistRules = configFile.Checklist
+	config.PluginPriority = configFile.PluginPriority
+	config.GroupByDirectory = configFile.GroupByDirectory
+	config.MinDependencyStrength = types.DependencyStrength(strings.ToUpper(configFile.MinStrength))
+	config.CoChange = configFile.CoChange
+	config.CoChangeCommits = configFile.CoChangeCommits
+	config.Teams = configFile.Teams
+	config.Topology = configFile.Topology
+	config.SplitHunks = configFile.SplitHunks
+	config.APIConcurrency = configFile.APIConcurrency
+	config.APIRateLimit = configFile.APIRateLimit
+	config.Parallelism = configFile.Parallelism
+	config.PushRemote = configFile.PushRemote
+	config.UpstreamRemote = configFile.UpstreamRemote
+	config.PushOptions = configFile.PushOptions
+	config.NoPush = configFile.NoPush
+	config.NoVerify = configFile.NoVerify
+	config.SignCommits = configFile.SignCommits
+	config.SignOff = configFile.SignOff
+	config.CoAuthorTrailers = configFile.CoAuthors
+	config.OperatorAuthor = configFile.OperatorAuthor
+	config.ProtectedBranches = configFile.ProtectedBranches
+	config.PartitionChecks = configFile.PartitionChecks
+	config.CheckPolicy = configFile.CheckPolicy
+	config.StrictValidation = configFile.Strict
+	config.ValidationSeverity = ParseSeverities(configFile.ValidationSeverity)
+	config.BinaryFiles = configFile.BinaryFiles
+	config.ExcludeTests = configFile.ExcludeTests
+	config.PairingRules = configFile.PairingRules
+	config.SkipProjectGraph = configFile.SkipProjectGraph
+	config.GitHubTokenEnv = configFile.GitHubTokenEnv
+	config.ContextDepth = configFile.ContextDepth
+	if configFile.ContextCacheTTL != "" {
+		ttl, err := time.ParseDuration(configFile.ContextCacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid context_cache_ttl %q: %w", configFile.ContextCacheTTL, err)
+		}
+		config.ContextCacheTTL = ttl
+	}
+	if configFile.ApplyMode != "" {
+		config.ApplyMode = configFile.ApplyMode
+	}
 
 	if err := ValidateConfig(config); err != nil {
 		return nil, fmt.Errorf("invalid configuration in file: %w", err)
@@ -129,6 +328,135 @@ func LoadFromFile(filePath string) (*types.Config, error) {
 	return config, nil
 }
 
+// RepoConfigName is the config file picked up at the root of the repository
+const RepoConfigName = ".pr-splitter.yaml"
+
+// ProfileEnvVar selects a config profile when --profile is not given
+const ProfileEnvVar = "PR_SPLIT_PROFILE"
+
+// envSettings map environment variables to the config file settings they override
+var envSettings = []struct {
+	name    string
+	key     string
+	integer bool
+}{
+	{name: "PR_SPLIT_TARGET", key: "target_branch"},
+	{name: "PR_SPLIT_PREFIX", key: "branch_prefix"},
+	{name: "PR_SPLIT_MAX_SIZE", key: "max_partition_size", integer: true},
+	{name: "PR_SPLIT_STRATEGY", key: "strategy"},
+	{name: "PR_SPLIT_NAMESPACE", key: "branch_namespace"},
+	{name: "PR_SPLIT_GITHUB_TOKEN_ENV", key: "github_token_env"},
+}
+
+// UserConfigPath returns the personal config file, $XDG_CONFIG_HOME/pr-split/config.yaml or
+// ~/.config/pr-split/config.yaml, or an empty string when there is no home directory
+func UserConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "pr-split", "config.yaml")
+}
+
+// parseSettings splits a YAML config file into its top-level settings and its profiles
+func parseSettings(data []byte) (yaml.MapSlice, map[string]yaml.MapSlice, error) {
+	var document yaml.MapSlice
+	var sections struct {
+		Profiles map[string]yaml.MapSlice `yaml:"profiles"`
+	}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse YAML config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &sections); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config profiles: %w", err)
+	}
+
+	var settings yaml.MapSlice
+	for _, item := range document {
+		if item.Key != "profiles" {
+			settings = append(settings, item)
+		}
+	}
+	for name, overrides := range sections.Profiles {
+		for _, override := range overrides {
+			if override.Key == "profiles" {
+				return nil, nil, fmt.Errorf("config profile '%s' cannot define profiles of its own", name)
+			}
+		}
+	}
+	return settings, sections.Profiles, nil
+}
+
+// applyProfile lays the settings of the named profile over the top-level settings; an empty
+// profile leaves them as they are
+func applyProfile(settings yaml.MapSlice, profiles map[string]yaml.MapSlice, profile string) (yaml.MapSlice, error) {
+	if profile == "" {
+		return settings, nil
+	}
+	overrides, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown config profile '%s' (available: %s)", profile, profileNames(profiles))
+	}
+	return overlay(settings, overrides), nil
+}
+
+// overlay replaces settings with the overrides of the same key, key by key: a "hotfix" profile
+// setting only max_partition_size keeps every other setting
+func overlay(settings, overrides yaml.MapSlice) yaml.MapSlice {
+	merged := append(yaml.MapSlice{}, settings...)
+	for _, override := range overrides {
+		replaced := false
+		for i := range merged {
+			if merged[i].Key == override.Key {
+				merged[i].Value = override.Value
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, override)
+		}
+	}
+	return merged
+}
+
+// environmentSettings returns the settings set by PR_SPLIT_* environment variables
+func environmentSettings() (yaml.MapSlice, error) {
+	var settings yaml.MapSlice
+	for _, setting := range envSettings {
+		value, ok := os.LookupEnv(setting.name)
+		if !ok || value == "" {
+			continue
+		}
+		if !setting.integer {
+			settings = append(settings, yaml.MapItem{Key: setting.key, Value: value})
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': expected a number", setting.name, value)
+		}
+		settings = append(settings, yaml.MapItem{Key: setting.key, Value: number})
+	}
+	return settings, nil
+}
+
+// profileNames lists profile names in order, "none" when there are none
+func profileNames(profiles map[string]yaml.MapSlice) string {
+	if len(profiles) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
 // GetFromUserWithCapacityCheck prompts user with file count awareness
 func GetFromUserWithCapacityCheck(estimatedFileCount int) (*types.Config, error) {
 	fmt.Println("🔧 Configuration Setup:")
@@ -168,6 +496,8 @@ func GetFromUserWithCapacityCheck(estimatedFileCount int) (*types.Config, error)
 		BranchPrefix:         branchPrefix,
 		Strategy:             ConfigDefaults.Strategy,
 		TargetBranch:         targetBranch,
+		BranchTemplate:       ConfigDefaults.BranchTemplate,
+		ApplyMode:            ConfigDefaults.ApplyMode,
 	}
 
 	if err := ValidateConfig(config); err != nil {
@@ -214,6 +544,34 @@ func CalculateRecommendations(estimatedFileCount int) Recommendations {
 	}
 }
 
+// ValidateStrategy checks that a partitioning strategy is known; empty means the default
+func ValidateStrategy(strategy string) error {
+	switch strategy {
+	case "", types.StrategyDependencyFirst, types.StrategyOwnership, types.StrategySemantic, types.StrategyMinCut, types.StrategyDirectory, types.StrategyWorkspace:
+		return nil
+	}
+	return fmt.Errorf("invalid strategy '%s' (expected %s, %s, %s, %s, %s or %s)", strategy,
+		types.StrategyDependencyFirst, types.StrategyOwnership, types.StrategySemantic, types.StrategyMinCut, types.StrategyDirectory, types.StrategyWorkspace)
+}
+
+// ValidateTopology checks that a branch topology is known; empty means linear
+func ValidateTopology(topology string) error {
+	switch topology {
+	case "", types.TopologyLinear, types.TopologyIndependent, types.TopologyDAG:
+		return nil
+	}
+	return fmt.Errorf("invalid topology '%s' (expected %s, %s or %s)", topology, types.TopologyLinear, types.TopologyIndependent, types.TopologyDAG)
+}
+
+// ValidateBinaryFiles checks that a binary files policy is known; empty means directory
+func ValidateBinaryFiles(policy string) error {
+	switch policy {
+	case "", types.BinaryFilesDirectory, types.BinaryFilesSeparate:
+		return nil
+	}
+	return fmt.Errorf("invalid binary files policy '%s' (expected %s or %s)", policy, types.BinaryFilesDirectory, types.BinaryFilesSeparate)
+}
+
 // ValidateConfig validates configuration consistency and constraints
 func ValidateConfig(cfg *types.Config) error {
 	if cfg.MaxFilesPerPartition <= 0 {
@@ -232,6 +590,10 @@ func ValidateConfig(cfg *types.Config) error {
 		return fmt.Errorf("max partitions seems excessive: %d (consider values under 20)", cfg.MaxPartitions)
 	}
 
+	if cfg.MaxLinesPerPartition < 0 {
+		return fmt.Errorf("max lines per partition cannot be negative, got %d", cfg.MaxLinesPerPartition)
+	}
+
 	if cfg.BranchPrefix == "" {
 		return fmt.Errorf("branch prefix cannot be empty")
 	}
@@ -244,6 +606,76 @@ func ValidateConfig(cfg *types.Config) error {
 		return fmt.Errorf("target branch cannot be empty")
 	}
 
+	if cfg.BranchTemplate != "" && !strings.Contains(cfg.BranchTemplate, "{id}") && !strings.Contains(cfg.BranchTemplate, "{slug}") {
+		return fmt.Errorf("branch template must contain {id} or {slug} to keep branch names unique: %s", cfg.BranchTemplate)
+	}
+
+	if cfg.Parallelism < 0 {
+		return fmt.Errorf("parallelism cannot be negative, got %d", cfg.Parallelism)
+	}
+
+	if cfg.NoPush && (cfg.CreatePRs || cfg.PostSummary) {
+		return fmt.Errorf("partition PRs and the split summary need pushed branches; drop no_push or disable them")
+	}
+
+	if cfg.APIConcurrency < 0 || cfg.APIRateLimit < 0 {
+		return fmt.Errorf("API concurrency and rate limit cannot be negative")
+	}
+
+	if strings.HasPrefix(cfg.BranchNamespace, "/") || strings.HasSuffix(cfg.BranchNamespace, "/") {
+		return fmt.Errorf("branch namespace cannot start or end with '/': %s", cfg.BranchNamespace)
+	}
+
+	for _, rule := range cfg.GeneratedCode {
+		if rule.Source == "" || len(rule.Generated) == 0 {
+			return fmt.Errorf("generated code rules need both a source glob and at least one generated glob")
+		}
+	}
+
+	for _, rule := range cfg.PairingRules {
+		if rule.File == "" || rule.With == "" || strings.Contains(rule.File+rule.With, "/") {
+			return fmt.Errorf("pairing rules need a file name and the name of its companion in the same directory")
+		}
+	}
+
+	if _, err := regexp.Compile(cfg.CommitPattern); err != nil {
+		return fmt.Errorf("invalid commit pattern '%s': %w", cfg.CommitPattern, err)
+	}
+
+	if cfg.MinDependencyStrength != "" && cfg.MinDependencyStrength.Rank() == 0 {
+		return fmt.Errorf("invalid minimum dependency strength '%s' (expected WEAK, MODERATE, STRONG or CRITICAL)", cfg.MinDependencyStrength)
+	}
+
+	for _, rule := range cfg.ChecklistRules {
+		if rule.Item == "" || len(rule.Patterns) == 0 {
+			return fmt.Errorf("checklist rules need an item and at least one pattern")
+		}
+	}
+
+	if err := ValidateStrategy(cfg.Strategy); err != nil {
+		return err
+	}
+
+	if err := ValidateTopology(cfg.Topology); err != nil {
+		return err
+	}
+
+	if cfg.ApplyMode != "" && cfg.ApplyMode != types.ApplyModeCheckout && cfg.ApplyMode != types.ApplyModePatch {
+		return fmt.Errorf("invalid apply mode '%s' (expected '%s' or '%s')", cfg.ApplyMode, types.ApplyModeCheckout, types.ApplyModePatch)
+	}
+
+	if cfg.CheckPolicy != "" && cfg.CheckPolicy != types.CheckPolicyAbort && cfg.CheckPolicy != types.CheckPolicyContinue {
+		return fmt.Errorf("invalid check policy '%s' (expected '%s' or '%s')", cfg.CheckPolicy, types.CheckPolicyAbort, types.CheckPolicyContinue)
+	}
+
+	if err := ValidateSeverities(cfg.ValidationSeverity); err != nil {
+		return err
+	}
+
+	if err := ValidateBinaryFiles(cfg.BinaryFiles); err != nil {
+		return err
+	}
+
 	totalCapacity := cfg.MaxFilesPerPartition * cfg.MaxPartitions
 	if totalCapacity < 10 {
 		fmt.Printf("⚠️  Warning: Configuration allows max %d total files across all partitions\n", totalCapacity)
@@ -253,6 +685,42 @@ func ValidateConfig(cfg *types.Config) error {
 	return nil
 }
 
+// ParseSeverities converts validation type to status names, in any case, to severity overrides
+func ParseSeverities(names map[string]string) types.Severities {
+	if len(names) == 0 {
+		return nil
+	}
+	severities := make(types.Severities, len(names))
+	for validationType, status := range names {
+		severities[types.ValidationType(strings.ToUpper(validationType))] = types.ValidationStatus(strings.ToUpper(status))
+	}
+	return severities
+}
+
+// ValidateSeverities checks that overrides name known validation types and statuses
+func ValidateSeverities(severities types.Severities) error {
+	for validationType, status := range severities {
+		known := false
+		for _, t := range types.ValidationTypes {
+			known = known || t == validationType
+		}
+		if !known {
+			return fmt.Errorf("invalid validation type '%s' in validation severity (expected one of %v)", validationType, types.ValidationTypes)
+		}
+		switch status {
+		case types.ValidationStatusPass, types.ValidationStatusWarn, types.ValidationStatusFail:
+		default:
+			return fmt.Errorf("invalid severity '%s' for %s (expected PASS, WARN or FAIL)", status, validationType)
+		}
+	}
+	return nil
+}
+
+// ResolveNamespace substitutes the {user} placeholder in a branch namespace
+func ResolveNamespace(namespace, user string) string {
+	return strings.ReplaceAll(namespace, "{user}", user)
+}
+
 // Prompter handles user input prompts
 type Prompter struct {
 	reader *bufio.Reader
@@ -370,7 +838,7 @@ func (p *Prompter) ShowCapacityAnalysis(maxFiles, maxPartitions, estimatedFiles
 }
 
 // PromptForSCCDecision prompts user when SCC exceeds size limit
-func PromptForSCCDecision(sccFiles []string, currentSize, limit int) (bool, error) {
+func PromptForSCCDecision(sccFiles []string, currentSize, limit int, suggestions []string) (bool, error) {
 	fmt.Printf("\n⚠️  Found circular dependency group with %d files (limit: %d)\n", currentSize, limit)
 	fmt.Println("Files in circular group:")
 
@@ -383,6 +851,14 @@ func PromptForSCCDecision(sccFiles []string, currentSize, limit int) (bool, erro
 		fmt.Printf("  - %s\n", file)
 	}
 
+	if len(suggestions) > 0 {
+		fmt.Println()
+		fmt.Printf("💡 Removing these dependencies would break the group into parts of at most %d files:\n", limit)
+		for _, suggestion := range suggestions {
+			fmt.Printf("  ✂ %s\n", suggestion)
+		}
+	}
+
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("[1] Proceed with extended partition")
@@ -412,9 +888,104 @@ func PromptForSCCDecision(sccFiles []string, currentSize, limit int) (bool, erro
 			fmt.Println()
 		case "3":
 			fmt.Println("❌ Aborting. Please break circular dependencies and try again.")
-			return false, fmt.Errorf("user chose to abort due to circular dependencies")
+			return false, nil
+		default:
+			fmt.Println("❌ Please choose 1, 2, or 3")
+		}
+	}
+}
+
+// DriftDecision is the user's choice when target-branch drift is detected
+type DriftDecision int
+
+const (
+	DriftUsePatchMode DriftDecision = iota
+	DriftOverwrite
+	DriftAbort
+)
+
+// PromptForDriftDecision prompts user when planned files also changed on the target branch
+func PromptForDriftDecision(driftedFiles []string, targetBranch string) (DriftDecision, error) {
+	fmt.Printf("\n⚠️  %d planned files also changed on %s since the merge-base\n", len(driftedFiles), targetBranch)
+	fmt.Println("Checkout-based application would overwrite these target-side changes:")
+
+	maxShow := 5
+	for i, file := range driftedFiles {
+		if i >= maxShow {
+			fmt.Printf("... and %d more files\n", len(driftedFiles)-maxShow)
+			break
+		}
+		fmt.Printf("  - %s\n", file)
+	}
+
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("[1] Switch to patch mode (apply only your changes)")
+	fmt.Println("[2] Continue with checkout mode (overwrite target changes)")
+	fmt.Printf("[3] Abort - let me rebase onto %s and re-plan\n", targetBranch)
+
+	prompter := NewPrompter()
+
+	for {
+		fmt.Print("Choose option (1-3): ")
+		input, err := prompter.reader.ReadString('\n')
+		if err != nil {
+			return DriftAbort, fmt.Errorf("failed to read input: %w", err)
+		}
+
+		switch strings.TrimSpace(input) {
+		case "1", "":
+			fmt.Println("✅ Using patch mode")
+			return DriftUsePatchMode, nil
+		case "2":
+			fmt.Println("⚠️  Continuing with checkout mode")
+			return DriftOverwrite, nil
+		case "3":
+			fmt.Println("❌ Aborting. Please rebase and try again.")
+			return DriftAbort, nil
 		default:
 			fmt.Println("❌ Please choose 1, 2, or 3")
 		}
 	}
 }
+
+// CollisionDecision is the user's choice when planned branches already exist on the remote
+type CollisionDecision int
+
+const (
+	CollisionUseSuffix CollisionDecision = iota
+	CollisionAbort
+)
+
+// PromptForCollisionDecision prompts user when planned branch names are taken on the remote
+func PromptForCollisionDecision(collisions []string, userSuffix string) (CollisionDecision, error) {
+	fmt.Printf("\n⚠️  %d planned branches already exist on the remote from another split:\n", len(collisions))
+	for _, collision := range collisions {
+		fmt.Printf("  - %s\n", collision)
+	}
+
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Printf("[1] Append a per-user suffix (-%s) to every branch\n", userSuffix)
+	fmt.Println("[2] Abort - let me pick a different prefix or namespace")
+
+	prompter := NewPrompter()
+
+	for {
+		fmt.Print("Choose option (1-2): ")
+		input, err := prompter.reader.ReadString('\n')
+		if err != nil {
+			return CollisionAbort, fmt.Errorf("failed to read input: %w", err)
+		}
+
+		switch strings.TrimSpace(input) {
+		case "1", "":
+			return CollisionUseSuffix, nil
+		case "2":
+			fmt.Println("❌ Aborting. Existing branches were left untouched.")
+			return CollisionAbort, nil
+		default:
+			fmt.Println("❌ Please choose 1 or 2")
+		}
+	}
+}
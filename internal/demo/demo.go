@@ -0,0 +1,179 @@
+package demo
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/git"
+)
+
+// SourceBranch is the oversized branch created in the demo repository
+const SourceBranch = "feature/checkout-flow"
+
+// Repo describes a generated demo repository
+type Repo struct {
+	Dir        string // Working copy
+	OriginDir  string // Bare repository used as origin so branches can be pushed
+	Branch     string
+	FilesAdded int
+}
+
+// baseFiles exist on main before the feature branch starts
+var baseFiles = map[string]string{
+	"README.md":    "# Demo Shop\n\nA tiny storefront used to try out pr-split.\n",
+	"package.json": "{\n  \"name\": \"demo-shop\",\n  \"version\": \"1.0.0\",\n  \"private\": true\n}\n",
+	"tsconfig.json": `{
+  "compilerOptions": {
+    "baseUrl": ".",
+    "paths": { "@models/*": ["src/models/*"] }
+  }
+}
+`,
+	"src/models/user.ts":    "export interface User {\n  id: string;\n  name: string;\n}\n",
+	"src/utils/format.ts":   "export function formatName(first: string, last: string): string {\n  return `${first} ${last}`;\n}\n",
+	"src/legacy/helpers.ts": "export function clamp(value: number, min: number, max: number): number {\n  return Math.min(Math.max(value, min), max);\n}\n",
+}
+
+// featureFiles are written on the feature branch; together they exceed a comfortable review size
+var featureFiles = map[string]string{
+	"src/models/user.ts":    "export interface User {\n  id: string;\n  name: string;\n  email: string;\n}\n",
+	"src/models/product.ts": "export interface Product {\n  sku: string;\n  title: string;\n  priceCents: number;\n}\n",
+	"src/models/order.ts":   "import { User } from \"./user\";\nimport { Product } from \"./product\";\n\nexport interface Order {\n  id: string;\n  buyer: User;\n  items: Product[];\n}\n",
+	"src/models/cart.ts":    "import { Product } from \"@models/product\";\n\nexport interface Cart {\n  items: Product[];\n}\n",
+
+	"src/utils/format.ts":   "export function formatName(first: string, last: string): string {\n  return `${first} ${last}`.trim();\n}\n",
+	"src/utils/currency.ts": "export function formatCents(cents: number): string {\n  return `$${(cents / 100).toFixed(2)}`;\n}\n",
+	"src/utils/dates.ts":    "export function isoDate(date: Date): string {\n  return date.toISOString().slice(0, 10);\n}\n",
+
+	// userService and orderService import each other: a circular group that must stay together
+	"src/services/userService.ts":  "import { User } from \"../models/user\";\nimport { ordersFor } from \"./orderService\";\n\nexport function describeUser(user: User): string {\n  return `${user.name} (${ordersFor(user).length} orders)`;\n}\n",
+	"src/services/orderService.ts": "import { Order } from \"../models/order\";\nimport { User } from \"../models/user\";\nimport { describeUser } from \"./userService\";\n\nconst orders: Order[] = [];\n\nexport function ordersFor(user: User): Order[] {\n  return orders.filter((order) => order.buyer.id === user.id);\n}\n\nexport function receipt(order: Order): string {\n  return `Order ${order.id} for ${describeUser(order.buyer)}`;\n}\n",
+	"src/services/cartService.ts":  "import { Cart } from \"../models/cart\";\nimport { formatCents } from \"../utils/currency\";\n\nexport function cartTotal(cart: Cart): string {\n  return formatCents(cart.items.reduce((sum, item) => sum + item.priceCents, 0));\n}\n",
+
+	"src/api/users.ts":    "import { describeUser } from \"../services/userService\";\nimport { User } from \"../models/user\";\n\nexport function getUser(user: User) {\n  return { summary: describeUser(user) };\n}\n",
+	"src/api/orders.ts":   "import { receipt } from \"../services/orderService\";\nimport { Order } from \"../models/order\";\n\nexport function getReceipt(order: Order) {\n  return { receipt: receipt(order) };\n}\n",
+	"src/api/checkout.ts": "import { cartTotal } from \"../services/cartService\";\nimport { Cart } from \"../models/cart\";\nimport { isoDate } from \"../utils/dates\";\n\nexport function checkout(cart: Cart) {\n  return { total: cartTotal(cart), date: isoDate(new Date()) };\n}\n",
+
+	"src/components/UserCard.tsx":    "import { User } from \"../models/user\";\nimport { formatName } from \"../utils/format\";\n\nexport function UserCard({ user }: { user: User }) {\n  return formatName(user.name, \"\");\n}\n",
+	"src/components/ProductGrid.tsx": "import { Product } from \"../models/product\";\nimport { formatCents } from \"../utils/currency\";\n\nexport function ProductGrid({ products }: { products: Product[] }) {\n  return products.map((p) => `${p.title}: ${formatCents(p.priceCents)}`);\n}\n",
+	"src/components/CartView.tsx":    "import { Cart } from \"../models/cart\";\nimport { cartTotal } from \"../services/cartService\";\n\nexport function CartView({ cart }: { cart: Cart }) {\n  return `Total: ${cartTotal(cart)}`;\n}\n",
+
+	"tests/userService.test.ts": "import { describeUser } from \"../src/services/userService\";\n\ndescribeUser({ id: \"1\", name: \"Ada\", email: \"ada@example.com\" });\n",
+	"tests/cart.test.ts":        "import { cartTotal } from \"../src/services/cartService\";\n\ncartTotal({ items: [] });\n",
+
+	"docs/checkout.md":     "# Checkout flow\n\nCart → checkout API → receipt.\n",
+	"docs/architecture.md": "# Architecture\n\nModels, services, API handlers and components.\n",
+}
+
+// renamedFiles are moved on the feature branch, old path to new path
+var renamedFiles = map[string]string{
+	"src/legacy/helpers.ts": "src/utils/helpers.ts",
+}
+
+// Generate creates a demo repository under dir with a main branch and an oversized feature branch
+func Generate(dir string) (*Repo, error) {
+	repo := &Repo{
+		Dir:       filepath.Join(dir, "demo-shop"),
+		OriginDir: filepath.Join(dir, "origin.git"),
+		Branch:    SourceBranch,
+	}
+
+	if _, err := os.Stat(repo.Dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", repo.Dir)
+	}
+
+	if err := run(dir, "init", "--quiet", "--bare", repo.OriginDir); err != nil {
+		return nil, err
+	}
+	if err := run(dir, "init", "--quiet", repo.Dir); err != nil {
+		return nil, err
+	}
+
+	setup := [][]string{
+		{"checkout", "--quiet", "-b", "main"},
+		{"config", "user.name", "PR Split Demo"},
+		{"config", "user.email", "demo@example.com"},
+		{"config", "commit.gpgsign", "false"},
+		{"remote", "add", "origin", repo.OriginDir},
+	}
+	for _, args := range setup {
+		if err := run(repo.Dir, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeFiles(repo.Dir, baseFiles); err != nil {
+		return nil, err
+	}
+	if err := commitAll(repo.Dir, "Initial storefront"); err != nil {
+		return nil, err
+	}
+	if err := run(repo.Dir, "push", "--quiet", "origin", "main"); err != nil {
+		return nil, err
+	}
+
+	if err := run(repo.Dir, "checkout", "--quiet", "-b", SourceBranch); err != nil {
+		return nil, err
+	}
+	for from, to := range renamedFiles {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(repo.Dir, to)), 0755); err != nil {
+			return nil, err
+		}
+		if err := run(repo.Dir, "mv", from, to); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeFiles(repo.Dir, featureFiles); err != nil {
+		return nil, err
+	}
+	if err := commitAll(repo.Dir, "Add checkout flow"); err != nil {
+		return nil, err
+	}
+
+	repo.FilesAdded = len(featureFiles) + len(renamedFiles)
+	return repo, nil
+}
+
+// writeFiles writes files in path order so commits are reproducible
+func writeFiles(root string, files map[string]string) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(fullPath, []byte(files[path]), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func commitAll(dir, message string) error {
+	if err := run(dir, "add", "-A"); err != nil {
+		return err
+	}
+	return run(dir, "commit", "--quiet", "--no-verify", "-m", message)
+}
+
+func run(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := git.Run(cmd); err != nil {
+		return fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(output.String()))
+	}
+	return nil
+}
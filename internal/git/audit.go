@@ -0,0 +1,242 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditLogName is the audit log file under the pr-split directory of the git common dir
+const AuditLogName = "audit.log"
+
+// AuditEntry is one line of the audit log: a git command that changed refs, the index,
+// the working tree or the remote
+type AuditEntry struct {
+	Time    time.Time         `json:"time"`
+	RunID   string            `json:"runId,omitempty"`
+	Dir     string            `json:"dir"`
+	Args    []string          `json:"args"`
+	OK      bool              `json:"ok"`
+	Error   string            `json:"error,omitempty"`
+	Head    string            `json:"head,omitempty"`    // HEAD after the command
+	Refs    map[string]string `json:"refs,omitempty"`    // Commits of the branches the command created, moved or pushed
+	Deleted map[string]string `json:"deleted,omitempty"` // Commits of the branches the command deleted
+}
+
+// mutatingCommands are the git subcommands recorded in the audit log
+var mutatingCommands = map[string]bool{
+	"add": true, "apply": true, "branch": true, "checkout": true, "cherry-pick": true, "commit": true,
+	"merge": true, "push": true, "rebase": true, "reset": true, "rm": true, "stash": true,
+	"update-ref": true, "worktree": true,
+}
+
+// readOnlyFlags mark invocations of mutating subcommands that only read, e.g. 'branch --show-current'
+var readOnlyFlags = map[string]bool{
+	"--show-current": true, "--format": true, "--list": true, "-r": true, "-a": true, "list": true,
+}
+
+var (
+	auditMu   sync.Mutex
+	auditLogs = map[string]string{} // Working dir to audit log path, "" when it cannot be found
+)
+
+// auditRecord is an audit entry being built around one git command
+type auditRecord struct {
+	entry AuditEntry
+	path  string
+}
+
+// isMutating reports whether a git invocation changes anything
+func isMutating(args []string) bool {
+	if len(args) == 0 || !mutatingCommands[args[0]] {
+		return false
+	}
+	for _, arg := range args[1:] {
+		if readOnlyFlags[strings.SplitN(arg, "=", 2)[0]] {
+			return false
+		}
+	}
+	return true
+}
+
+// beginAudit starts recording a mutating git command run for ctx, tagged with its run id; it
+// returns nil for read-only commands
+func beginAudit(ctx context.Context, dir string, args []string) *auditRecord {
+	if !isMutating(args) {
+		return nil
+	}
+	path := auditLogPath(dir)
+	if path == "" {
+		return nil
+	}
+
+	record := &auditRecord{path: path, entry: AuditEntry{
+		Time:  time.Now().UTC(),
+		RunID: runIDFrom(ctx),
+		Dir:   dir,
+		Args:  args,
+	}}
+
+	// Deleted branches can only be resolved before the command runs
+	for _, branch := range deletedBranches(args) {
+		if commit := resolveQuietly(dir, branch); commit != "" {
+			if record.entry.Deleted == nil {
+				record.entry.Deleted = map[string]string{}
+			}
+			record.entry.Deleted[branch] = commit
+		}
+	}
+	return record
+}
+
+// finish completes the entry with the outcome and resulting commits and appends it to the log
+func (r *auditRecord) finish(err error) {
+	if r == nil {
+		return
+	}
+	r.entry.OK = err == nil
+	if err != nil {
+		r.entry.Error = err.Error()
+	} else {
+		r.entry.Head = resolveQuietly(r.entry.Dir, "HEAD")
+		for _, branch := range touchedBranches(r.entry.Args) {
+			if commit := resolveQuietly(r.entry.Dir, branch); commit != "" {
+				if r.entry.Refs == nil {
+					r.entry.Refs = map[string]string{}
+				}
+				r.entry.Refs[branch] = commit
+			}
+		}
+	}
+
+	line, jsonErr := json.Marshal(r.entry)
+	if jsonErr != nil {
+		return
+	}
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	file, openErr := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if openErr != nil {
+		return
+	}
+	defer file.Close()
+	file.Write(append(line, '\n'))
+}
+
+// auditLogPath finds the audit log of the repository at dir, creating its directory
+func auditLogPath(dir string) string {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	if path, ok := auditLogs[dir]; ok {
+		return path
+	}
+
+	path := ""
+	if gitDir, err := NewClientInDir(dir).GitCommonDir(); err == nil {
+		if os.MkdirAll(filepath.Join(gitDir, "pr-split"), 0755) == nil {
+			path = filepath.Join(gitDir, "pr-split", AuditLogName)
+		}
+	}
+	auditLogs[dir] = path
+	return path
+}
+
+// deletedBranches lists the branches a command deletes, as <remote>/<name> for remote deletions
+func deletedBranches(args []string) []string {
+	switch args[0] {
+	case "branch":
+		if len(args) >= 3 && (args[1] == "-D" || args[1] == "-d") {
+			return args[2:]
+		}
+	case "update-ref":
+		if len(args) >= 3 && args[1] == "-d" {
+			return args[2:3]
+		}
+	case "push":
+		var branches []string
+		remote := DefaultRemote
+		for i, arg := range nonFlagArgs(args[1:]) {
+			switch {
+			case i == 0:
+				remote = arg
+			case isRemoteDeletion(args):
+				branches = append(branches, remote+"/"+arg)
+			case strings.HasPrefix(arg, ":"):
+				branches = append(branches, remote+"/"+strings.TrimPrefix(arg[1:], "refs/heads/"))
+			}
+		}
+		return branches
+	}
+	return nil
+}
+
+// isRemoteDeletion reports whether a push deletes every branch it names
+func isRemoteDeletion(args []string) bool {
+	for _, arg := range args {
+		if arg == "--delete" || arg == "-d" {
+			return true
+		}
+	}
+	return false
+}
+
+// touchedBranches lists the local branches a command creates, moves or pushes
+func touchedBranches(args []string) []string {
+	positional := nonFlagArgs(args[1:])
+	switch args[0] {
+	case "branch":
+		if len(deletedBranches(args)) == 0 && len(positional) > 0 {
+			return positional[:1]
+		}
+	case "checkout":
+		for i, arg := range args {
+			if (arg == "-b" || arg == "-B") && i+1 < len(args) {
+				return []string{args[i+1]}
+			}
+		}
+	case "update-ref":
+		if len(deletedBranches(args)) == 0 && len(positional) > 0 {
+			return positional[:1]
+		}
+	case "push":
+		if isRemoteDeletion(args) {
+			return nil
+		}
+		// Everything after the remote is a branch or a src:dst refspec
+		var branches []string
+		for i, arg := range positional {
+			if i == 0 {
+				continue
+			}
+			if src := strings.SplitN(arg, ":", 2)[0]; src != "" {
+				branches = append(branches, src)
+			}
+		}
+		return branches
+	}
+	return nil
+}
+
+func nonFlagArgs(args []string) []string {
+	var positional []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+		}
+	}
+	return positional
+}
+
+// resolveQuietly resolves a ref to a commit without auditing, returning "" when it does not exist
+func resolveQuietly(dir, ref string) string {
+	output, err := runGitCommand(context.Background(), dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return ""
+	}
+	return output
+}
@@ -0,0 +1,88 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// sourceCommit is a commit of the source branch that changed some of a partition's files
+type sourceCommit struct {
+	sha         string
+	authorName  string
+	authorEmail string
+	subject     string
+}
+
+// ident formats the author as "Name <email>", the form trailers use
+func (c sourceCommit) ident() string {
+	return fmt.Sprintf("%s <%s>", c.authorName, c.authorEmail)
+}
+
+// partitionSourceCommits lists the non-merge source commits, oldest first, that changed any of
+// the partition's files since the split base
+func (b *Brancher) partitionSourceCommits(partition types.Partition, plan *types.PartitionPlan, sourceBranch string, cfg *types.Config) ([]sourceCommit, error) {
+	var paths []string
+	for _, file := range partition.Files {
+		if !file.IsChanged {
+			continue
+		}
+		paths = append(paths, file.Path)
+		if file.OldPath != "" {
+			paths = append(paths, file.OldPath)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	base := plan.Metadata.MergeBase
+	if base == "" {
+		base = cfg.TargetRef()
+	}
+	args := []string{"log", "--no-merges", "--reverse", "--format=%H%x00%an%x00%ae%x00%s", base + ".." + sourceBranch, "--"}
+	output, err := runGitCommand(b.ctx, b.workingDir, append(args, paths...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source commits: %w", err)
+	}
+
+	var commits []sourceCommit
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		commits = append(commits, sourceCommit{sha: fields[0], authorName: fields[1], authorEmail: fields[2], subject: fields[3]})
+	}
+	return commits, nil
+}
+
+// dominantAuthor returns the author of most of the commits, the earliest of them on a tie,
+// or nil when there are no commits
+func dominantAuthor(commits []sourceCommit) *sourceCommit {
+	counts := make(map[string]int)
+	for _, commit := range commits {
+		counts[strings.ToLower(commit.authorEmail)]++
+	}
+
+	var author *sourceCommit
+	for i, commit := range commits {
+		if author == nil || counts[strings.ToLower(commit.authorEmail)] > counts[strings.ToLower(author.authorEmail)] {
+			author = &commits[i]
+		}
+	}
+	return author
+}
+
+// describeSourceCommits lists the source commits a partition commit squashes, for its message body
+func describeSourceCommits(commits []sourceCommit) string {
+	if len(commits) == 0 {
+		return ""
+	}
+	lines := []string{"Squashes changes from:"}
+	for _, commit := range commits {
+		lines = append(lines, fmt.Sprintf("  %s %s", commit.sha, commit.subject))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,7 +1,10 @@
 package git
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"pr-splitter-cli/internal/types"
@@ -9,99 +12,391 @@ import (
 
 // Brancher handles all git branch operations
 type Brancher struct {
-	workingDir string
+	ctx         context.Context
+	out         io.Writer // Progress output
+	workingDir  string
+	remote      string                    // Remote branches are pushed to
+	pushOpts    []string                  // Server-side push options sent with every push, e.g. "ci.skip"
+	lineDiffs   map[string]types.LineDiff // Source diff hunks, loaded when a plan splits files by hunk
+	previous    map[string]previousBranch // Branches of an earlier split, when updating it
+	updated     []string                  // Previous branches reset so far, restored on rollback
+	pushed      map[string]string         // Commits this run force-pushed previous branches to, by branch
+	onPushed    func(BranchProgress)      // Called after each branch is pushed, e.g. to record run state
+	done        map[string]bool           // Branches already pushed by the run being resumed
+	keepPushed  bool                      // Keep pushed branches on rollback so the run can be resumed
+	noPush      bool                      // Create branches locally only, never touching the remote
+	noVerify    bool                      // Skip pre-push hooks
+	sign        bool                      // Sign partition and merge commits
+	protected   ProtectedBranches         // Branches a rollback must never delete
+	failures    []PartitionCheckFailure   // Partition checks that failed in the last CreateBranches, under the continue policy
+	conflicts   []PartitionConflict       // Sibling partitions of the last CreateBranches predicted to conflict
+	conflictErr error                     // Why the last CreateBranches could not predict conflicts
+	guard       ForcePushCheck            // Called before a branch of an earlier split is force-pushed
+}
+
+// BranchProgress describes a partition branch that CreateBranches has pushed
+type BranchProgress struct {
+	PartitionID    int
+	Branch         string
+	Commit         string
+	Updated        bool   // Reset from an earlier split rather than created
+	Previous       string // Local tip before an update, empty if there was no local branch
+	PreviousRemote string // Tip on the push remote before an update
 }
 
 // NewBrancher creates a new git brancher
 func NewBrancher(workingDir string) *Brancher {
-	return &Brancher{workingDir: workingDir}
+	return &Brancher{ctx: context.Background(), out: os.Stdout, workingDir: workingDir, remote: DefaultRemote}
 }
 
-// CreateBranches creates branches for each partition with rollback support
-func (b *Brancher) CreateBranches(plan *types.PartitionPlan, cfg *types.Config, sourceBranch string) ([]string, error) {
-	originalBranch, err := b.GetCurrentBranch()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current branch for rollback: %w", err)
+// CreateBranches creates branches for each partition with rollback support. Each partition
+// commit is built from the source and base trees with plumbing commands in a private index,
+// so no checkout is ever touched, and up to cfg.Parallelism partitions whose bases exist are
+// built and pushed at once.
+func (b *Brancher) CreateBranches(plan *types.PartitionPlan, cfg *types.Config, sourceBranch string) (_ []string, err error) {
+	// Cancelling the context (Ctrl-C, SIGTERM, a timeout) stops at the next step and goes
+	// through the rollback below
+	defer func() {
+		if err != nil && b.ctx.Err() != nil {
+			err = fmt.Errorf("%w: %v", b.ctx.Err(), err)
+		}
+	}()
+
+	b.previous, b.updated, b.failures, b.conflicts, b.conflictErr = nil, nil, nil, nil, nil
+	b.pushed = make(map[string]string)
+	b.keepPushed = cfg.KeepProgress
+	b.pushOpts = cfg.PushOptions
+	b.noPush = cfg.NoPush
+	b.noVerify = cfg.NoVerify
+	b.sign = cfg.SignCommits || b.signingConfigured()
+	b.protected = NewProtectedBranches(cfg.TargetBranch, cfg.ProtectedBranches)
+	if cfg.UpdateExisting {
+		b.previous = b.findPreviousBranches(plan)
 	}
 
-	var createdBranches []string
-	var pushedBranches []string
+	if err := b.loadLineDiffs(plan, sourceBranch); err != nil {
+		return nil, err
+	}
 
-	// Rollback on error
-	defer func() {
-		if r := recover(); r != nil {
-			fmt.Printf("🔴 Panic occurred during branch creation, rolling back...\n")
-			b.rollbackBranches(createdBranches, pushedBranches, originalBranch)
-			panic(r)
+	run := newBranchRun(b, plan, cfg)
+	if err := run.execute(sourceBranch); err != nil {
+		b.rollbackBranches(run.created, run.pushed)
+		if run.panicked != nil {
+			panic(run.panicked)
 		}
-	}()
+		return nil, err
+	}
+	b.failures, b.conflicts, b.conflictErr = run.checkFailures, run.conflicts, run.conflictErr
+
+	var branches, updateSummary []string
+	for i, partition := range plan.Partitions {
+		branches = append(branches, partition.BranchName)
+		if run.summaries[i] != "" {
+			updateSummary = append(updateSummary, run.summaries[i])
+		}
+	}
+
+	if len(updateSummary) > 0 {
+		fmt.Fprintln(b.out, "📋 Changes to branches from the previous split:")
+		for _, line := range updateSummary {
+			fmt.Fprintf(b.out, "   %s\n", line)
+		}
+	}
+
+	fmt.Fprintf(b.out, "🎉 Successfully created %d branches\n", len(branches))
+	return branches, nil
+}
 
-	for _, partition := range plan.Partitions {
-		branchName := fmt.Sprintf("%s-%d-%s", cfg.BranchPrefix, partition.ID, partition.Name)
+// createPartitionBranch builds, creates or resets, and pushes the branch of one partition,
+// recording its progress in run
+func (b *Brancher) createPartitionBranch(run *branchRun, index int, partition types.Partition, sourceBranch string) error {
+	plan, cfg := run.plan, run.cfg
+	branchName := partition.BranchName
+	if branchName == "" {
+		return fmt.Errorf("partition %d has no branch name assigned", partition.ID)
+	}
+
+	if b.done[branchName] {
+		fmt.Fprintf(b.out, "⏭️  Already pushed: %s%s\n", branchName, run.finish())
+		run.built(partition.ID)
+		return nil
+	}
+
+	previous, updating := b.previous[branchName]
+	if !updating && b.branchExists(branchName) {
+		return fmt.Errorf("%w: %s (use --update to reset branches from a previous split)", ErrBranchExists, branchName)
+	}
+
+	if err := run.acquire(); err != nil {
+		return err
+	}
+	baseBranch, err := b.determineBaseBranch(partition, plan, cfg)
+	if err != nil {
+		run.release()
+		return fmt.Errorf("failed to determine base branch for partition %d: %w", partition.ID, err)
+	}
+
+	step := fmt.Sprintf("[%d/%d]", index+1, len(plan.Partitions))
+	if updating {
+		fmt.Fprintf(b.out, "♻️  %s Resetting branch: %s (from %s)\n", step, branchName, baseBranch)
+	} else {
+		fmt.Fprintf(b.out, "🌿 %s Creating branch: %s (from %s)\n", step, branchName, baseBranch)
+	}
 
-		if b.branchExists(branchName) {
-			err := fmt.Errorf("branch '%s' already exists", branchName)
-			b.rollbackBranches(createdBranches, pushedBranches, originalBranch)
-			return nil, err
+	commit, err := b.buildPartition(partition, plan, sourceBranch, baseBranch, cfg)
+	if err != nil {
+		run.release()
+		return fmt.Errorf("failed to build branch %s: %w", branchName, err)
+	}
+
+	if updating {
+		err = b.resetBranch(branchName, commit)
+		if err == nil {
+			run.record(&b.updated, branchName)
+		}
+	} else {
+		err = b.createBranch(branchName, commit)
+		if err == nil {
+			run.record(&run.created, branchName)
 		}
+	}
+	run.release()
+	if err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branchName, err)
+	}
 
-		baseBranch, err := b.determineBaseBranch(partition, plan, cfg)
+	if len(cfg.PartitionChecks) > 0 {
+		if err := run.acquire(); err != nil {
+			return err
+		}
+		failures, err := b.checkPartition(partition, commit, cfg)
+		run.release()
 		if err != nil {
-			b.rollbackBranches(createdBranches, pushedBranches, originalBranch)
-			return nil, fmt.Errorf("failed to determine base branch for partition %d: %w", partition.ID, err)
+			return err
+		}
+		run.recordCheckFailures(index, failures)
+	}
+
+	if updating {
+		summary, unchanged := b.describeUpdate(previous.tip(), commit)
+		run.summaries[index] = fmt.Sprintf("%s: %s", branchName, summary)
+
+		// Keep the old commit so open PRs and later partitions see no change at all
+		if unchanged {
+			if err := b.resetBranch(branchName, previous.tip()); err != nil {
+				return fmt.Errorf("failed to keep previous tip of %s: %w", branchName, err)
+			}
 		}
+		run.built(partition.ID)
 
-		fmt.Printf("🌿 Creating branch: %s (from %s)\n", branchName, baseBranch)
-		if err := b.createAndCheckoutBranch(branchName, baseBranch); err != nil {
-			b.rollbackBranches(createdBranches, pushedBranches, originalBranch)
-			return nil, fmt.Errorf("failed to create branch %s: %w", branchName, err)
+		if b.noPush {
+			fmt.Fprintf(b.out, "✅ Updated local branch (not pushed): %s%s\n", branchName, run.finish())
+			return nil
+		}
+		if unchanged && previous.remote == previous.tip() {
+			fmt.Fprintf(b.out, "✅ Branch unchanged: %s%s\n", branchName, run.finish())
+			run.report(partition.ID, branchName, true)
+			return nil
 		}
-		createdBranches = append(createdBranches, branchName)
 
-		fmt.Printf("📝 Applying changes to %s (%d files)\n", branchName, len(partition.Files))
-		if err := b.applyPartitionChanges(&partition, sourceBranch); err != nil {
-			b.rollbackBranches(createdBranches, pushedBranches, originalBranch)
-			return nil, fmt.Errorf("failed to apply changes to branch %s: %w", branchName, err)
+		if err := run.waitToPush(); err != nil {
+			return err
 		}
+		if err := run.acquire(); err != nil {
+			return err
+		}
+		defer run.release()
+		pushed := commit
+		if unchanged {
+			pushed = previous.tip()
+		}
+		if err := checkForcePush(b.guard, branchName, previous.remote, pushed); err != nil {
+			return err
+		}
+		fmt.Fprintf(b.out, "⬆️  Force-pushing branch: %s\n", branchName)
+		if err := b.forcePushBranch(branchName, previous.remote); err != nil {
+			return fmt.Errorf("failed to push branch %s (did someone else push to it?): %w", branchName, err)
+		}
+		run.recordPushed(branchName, pushed)
+		fmt.Fprintf(b.out, "✅ Successfully updated branch: %s%s\n", branchName, run.finish())
+		run.report(partition.ID, branchName, true)
+		return nil
+	}
+	run.built(partition.ID)
 
-		if hasChanges, err := b.hasUncommittedChanges(); err != nil {
-			b.rollbackBranches(createdBranches, pushedBranches, originalBranch)
-			return nil, fmt.Errorf("failed to check for changes in branch %s: %w", branchName, err)
-		} else if hasChanges {
-			commitMsg := fmt.Sprintf("Partition %d: %s\n\nUpdates %d files for %s",
-				partition.ID, partition.Description, len(partition.Files), partition.Description)
+	if b.noPush {
+		fmt.Fprintf(b.out, "✅ Created local branch (not pushed): %s%s\n", branchName, run.finish())
+		return nil
+	}
 
-			if err := b.commitChanges(commitMsg); err != nil {
-				b.rollbackBranches(createdBranches, pushedBranches, originalBranch)
-				return nil, fmt.Errorf("failed to commit changes to branch %s: %w", branchName, err)
-			}
-		} else {
-			fmt.Printf("⚠️  No changes to commit in branch %s\n", branchName)
+	if err := run.waitToPush(); err != nil {
+		return err
+	}
+	if err := run.acquire(); err != nil {
+		return err
+	}
+	defer run.release()
+	fmt.Fprintf(b.out, "⬆️  Pushing branch: %s\n", branchName)
+	if err := b.pushBranch(branchName); err != nil {
+		return fmt.Errorf("failed to push branch %s: %w", branchName, err)
+	}
+	run.record(&run.pushed, branchName)
+
+	fmt.Fprintf(b.out, "✅ Successfully created and pushed branch: %s%s\n", branchName, run.finish())
+	run.report(partition.ID, branchName, false)
+	return nil
+}
+
+// buildPartition commits a partition's changes on top of baseBranch and returns the commit.
+// Dependency branches of a DAG partition are merged in first.
+func (b *Brancher) buildPartition(partition types.Partition, plan *types.PartitionPlan, sourceBranch, baseBranch string, cfg *types.Config) (string, error) {
+	parent, err := runGitCommand(b.ctx, b.workingDir, "rev-parse", "--verify", baseBranch+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", baseBranch, err)
+	}
+
+	index, err := newIndexBuilder(b.ctx, b.workingDir, parent)
+	if err != nil {
+		return "", err
+	}
+	defer index.remove()
+
+	parent, err = b.mergeDependencyBranches(index, partition, plan, parent)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(b.out, "📝 Applying changes to %s (%d files)\n", partition.BranchName, len(partition.Files))
+	wholeFiles := withoutSplitFiles(partition, plan)
+	if err := b.applyPartition(index, &wholeFiles, plan, sourceBranch, cfg); err != nil {
+		return "", fmt.Errorf("failed to apply changes: %w", err)
+	}
+	if err := b.applySplitFiles(index, &partition, plan, sourceBranch); err != nil {
+		return "", fmt.Errorf("failed to apply split files: %w", err)
+	}
+
+	tree, err := index.writeTree()
+	if err != nil {
+		return "", fmt.Errorf("failed to write tree: %w", err)
+	}
+	if parentTree, err := runGitCommand(b.ctx, b.workingDir, "rev-parse", parent+"^{tree}"); err == nil && parentTree == tree {
+		fmt.Fprintf(b.out, "⚠️  No changes to commit in branch %s\n", partition.BranchName)
+		return parent, nil
+	}
+
+	// The commit is authored by whoever wrote most of the squashed source commits, so blame
+	// keeps pointing at them; the operator stays the committer
+	commits, err := b.partitionSourceCommits(partition, plan, sourceBranch, cfg)
+	if err != nil {
+		return "", err
+	}
+	opts := commitOptions{sign: b.sign}
+	var author *sourceCommit
+	if !cfg.OperatorAuthor {
+		if author = dominantAuthor(commits); author != nil {
+			opts.authorName, opts.authorEmail = author.authorName, author.authorEmail
 		}
+	}
+	trailers, err := b.commitTrailers(commits, author, plan, cfg)
+	if err != nil {
+		return "", err
+	}
+
+	commitMsg := fmt.Sprintf("Partition %d: %s\n\nUpdates %d files for %s",
+		partition.ID, partition.Description, len(partition.Files), partition.Description)
+	if sources := describeSourceCommits(commits); sources != "" {
+		commitMsg += "\n\n" + sources
+	}
+	if len(trailers) > 0 {
+		commitMsg += "\n\n" + strings.Join(trailers, "\n")
+	}
+
+	commit, err := commitTree(b.ctx, b.workingDir, tree, commitMsg, opts, parent)
+	if err != nil {
+		return "", fmt.Errorf("failed to commit: %w", err)
+	}
+	return commit, nil
+}
 
-		fmt.Printf("⬆️  Pushing branch: %s\n", branchName)
-		if err := b.pushBranch(branchName); err != nil {
-			b.rollbackBranches(createdBranches, pushedBranches, originalBranch)
-			return nil, fmt.Errorf("failed to push branch %s: %w", branchName, err)
+func containsBranch(branches []string, name string) bool {
+	for _, branch := range branches {
+		if branch == name {
+			return true
 		}
-		pushedBranches = append(pushedBranches, branchName)
+	}
+	return false
+}
 
-		fmt.Printf("✅ Successfully created and pushed branch: %s\n", branchName)
+// reportPushed passes a pushed branch and its tip to the onPushed callback, if any
+func (b *Brancher) reportPushed(partitionID int, branchName string, updated bool) {
+	if b.onPushed == nil {
+		return
+	}
+	// The branch is already pushed, so it is recorded even if the run is being cancelled
+	commit, err := runGitCommand(context.WithoutCancel(b.ctx), b.workingDir, "rev-parse", branchName)
+	if err != nil {
+		fmt.Fprintf(b.out, "⚠️  Warning: Could not resolve %s: %v\n", branchName, err)
+		return
+	}
+	progress := BranchProgress{PartitionID: partitionID, Branch: branchName, Commit: strings.TrimSpace(commit), Updated: updated}
+	if previous, ok := b.previous[branchName]; ok && updated {
+		progress.Previous = previous.local
+		progress.PreviousRemote = previous.remote
 	}
+	b.onPushed(progress)
+}
 
-	if err := b.CheckoutBranch(originalBranch); err != nil {
-		fmt.Printf("⚠️  Warning: Could not return to original branch %s: %v\n", originalBranch, err)
-		if err := b.CheckoutBranch(cfg.TargetBranch); err != nil {
-			fmt.Printf("⚠️  Warning: Could not return to target branch %s: %v\n", cfg.TargetBranch, err)
+// applyPartition stages a partition's changes using the configured apply mode
+func (b *Brancher) applyPartition(index *indexBuilder, partition *types.Partition, plan *types.PartitionPlan, sourceBranch string, cfg *types.Config) error {
+	switch cfg.ApplyMode {
+	case "", types.ApplyModeCheckout:
+		return b.applyPartitionChanges(index, partition, sourceBranch)
+	case types.ApplyModePatch:
+		return b.applyPartitionPatch(index, partition, sourceBranch, diffBase(plan, cfg))
+	default:
+		return fmt.Errorf("unknown apply mode: %s", cfg.ApplyMode)
+	}
+}
+
+// applyPartitionPatch applies only the source-vs-merge-base diff of the partition's files,
+// preserving any changes made to those files on the target after branching
+func (b *Brancher) applyPartitionPatch(index *indexBuilder, partition *types.Partition, sourceBranch, baseRange string) error {
+	var paths []string
+	for _, file := range partition.Files {
+		if !file.IsChanged {
+			continue
+		}
+		paths = append(paths, file.Path)
+		if file.ChangeType == types.ChangeTypeRename && file.OldPath != "" {
+			paths = append(paths, file.OldPath)
 		}
 	}
 
-	fmt.Printf("🎉 Successfully created %d branches\n", len(createdBranches))
-	return createdBranches, nil
+	if len(paths) == 0 {
+		return nil
+	}
+
+	args := []string{"diff", "--binary", "-M90", fmt.Sprintf("%s%s", baseRange, sourceBranch), "--"}
+	patch, err := runGitCommandRaw(b.ctx, b.workingDir, append(args, paths...)...)
+	if err != nil {
+		return fmt.Errorf("failed to generate patch: %w", err)
+	}
+
+	if strings.TrimSpace(patch) == "" {
+		return nil
+	}
+
+	if err := index.applyPatch(patch); err != nil {
+		return fmt.Errorf("failed to apply patch: %w", err)
+	}
+
+	return nil
 }
 
-// applyPartitionChanges applies file changes for a partition
-func (b *Brancher) applyPartitionChanges(partition *types.Partition, sourceBranch string) error {
+// applyPartitionChanges stages the source branch state of a partition's files
+func (b *Brancher) applyPartitionChanges(index *indexBuilder, partition *types.Partition, sourceBranch string) error {
+	var copied, removed []string
+	renames := make(map[string]string)
 	for _, file := range partition.Files {
 		if !file.IsChanged {
 			continue
@@ -109,118 +404,159 @@ func (b *Brancher) applyPartitionChanges(partition *types.Partition, sourceBranc
 
 		switch file.ChangeType {
 		case types.ChangeTypeAdd, types.ChangeTypeModify:
-			if err := b.checkoutFileFromBranch(file.Path, sourceBranch); err != nil {
-				return fmt.Errorf("failed to checkout file %s: %w", file.Path, err)
-			}
-
+			copied = append(copied, file.Path)
 		case types.ChangeTypeDelete:
-			if err := b.deleteFile(file.Path); err != nil {
-				return fmt.Errorf("failed to delete file %s: %w", file.Path, err)
-			}
-
+			removed = append(removed, file.Path)
 		case types.ChangeTypeRename:
 			if file.OldPath != "" {
-				if err := b.deleteFile(file.OldPath); err != nil {
-					fmt.Printf("⚠️  Warning: Could not delete old file %s: %v\n", file.OldPath, err)
-				}
-			}
-			if err := b.checkoutFileFromBranch(file.Path, sourceBranch); err != nil {
-				return fmt.Errorf("failed to checkout renamed file %s: %w", file.Path, err)
+				renames[file.OldPath] = file.Path
+			} else {
+				copied = append(copied, file.Path)
 			}
 		}
 	}
-	return nil
+
+	if err := index.renamePaths(sourceBranch, renames); err != nil {
+		return err
+	}
+	if err := index.removePaths(removed); err != nil {
+		return fmt.Errorf("failed to remove files: %w", err)
+	}
+	return index.copyPaths(sourceBranch, copied)
+}
+
+// diffBase returns the revision range prefix that source changes are measured from
+func diffBase(plan *types.PartitionPlan, cfg *types.Config) string {
+	if plan.Metadata.MergeBase != "" {
+		return plan.Metadata.MergeBase + ".."
+	}
+	return cfg.TargetRef() + "..."
 }
 
 // Branch utility methods
 
-func (b *Brancher) createAndCheckoutBranch(branchName, baseBranch string) error {
-	return runGitCommandQuiet(b.workingDir, "checkout", "-b", branchName, baseBranch)
+// createBranch creates a branch at commit, failing if it already exists
+func (b *Brancher) createBranch(branchName, commit string) error {
+	return runGitCommandQuiet(b.ctx, b.workingDir, "update-ref", "refs/heads/"+branchName, commit, "")
 }
 
-func (b *Brancher) checkoutFileFromBranch(filePath, branch string) error {
-	return runGitCommandQuiet(b.workingDir, "checkout", branch, "--", filePath)
+// pushBranch pushes a new branch and makes the pushed branch its upstream
+func (b *Brancher) pushBranch(branchName string) error {
+	return runGitCommandQuiet(b.ctx, b.workingDir, b.pushArgs("--set-upstream", b.remote, branchName)...)
 }
 
-func (b *Brancher) deleteFile(filePath string) error {
-	return runGitCommandQuiet(b.workingDir, "rm", filePath)
+// signingConfigured reports whether commit.gpgSign asks for every commit to be signed;
+// commit-tree does not read it, so partition commits would otherwise go out unsigned
+func (b *Brancher) signingConfigured() bool {
+	value, err := runGitCommand(b.ctx, b.workingDir, "config", "--type=bool", "--get", "commit.gpgsign")
+	return err == nil && value == "true"
 }
 
-func (b *Brancher) commitChanges(message string) error {
-	if err := runGitCommandQuiet(b.workingDir, "add", "."); err != nil {
-		return fmt.Errorf("git add failed: %w", err)
+// pushArgs builds a push command line carrying the configured push options
+func (b *Brancher) pushArgs(args ...string) []string {
+	push := hookArgs(b.noVerify, "push")
+	for _, option := range b.pushOpts {
+		push = append(push, "--push-option="+option)
 	}
-	return runGitCommandQuiet(b.workingDir, "commit", "-m", message)
-}
-
-func (b *Brancher) pushBranch(branchName string) error {
-	return runGitCommandQuiet(b.workingDir, "push", "origin", branchName)
+	return append(push, args...)
 }
 
 func (b *Brancher) CheckoutBranch(branchName string) error {
-	return runGitCommandQuiet(b.workingDir, "checkout", branchName)
+	return runGitCommandQuiet(b.ctx, b.workingDir, "checkout", branchName)
 }
 
 func (b *Brancher) GetCurrentBranch() (string, error) {
-	return runGitCommand(b.workingDir, "branch", "--show-current")
+	return runGitCommand(b.ctx, b.workingDir, "branch", "--show-current")
 }
 
 func (b *Brancher) branchExists(branchName string) bool {
-	return runGitCommandQuiet(b.workingDir, "rev-parse", "--verify", branchName) == nil
+	return runGitCommandQuiet(b.ctx, b.workingDir, "rev-parse", "--verify", branchName) == nil
 }
 
-func (b *Brancher) hasUncommittedChanges() (bool, error) {
-	// Check for staged changes
-	if err := runGitCommandQuiet(b.workingDir, "diff", "--cached", "--quiet"); err != nil {
-		return true, nil
+// determineBaseBranch picks the branch a partition is created from according to the plan topology
+func (b *Brancher) determineBaseBranch(partition types.Partition, plan *types.PartitionPlan, cfg *types.Config) (string, error) {
+	base := cfg.TargetRef()
+	if plan.Metadata.BaseCommit != "" {
+		base = plan.Metadata.BaseCommit
 	}
 
-	// Check for unstaged changes
-	if err := runGitCommandQuiet(b.workingDir, "diff", "--quiet"); err != nil {
-		return true, nil
+	switch plan.Metadata.Topology {
+	case types.TopologyIndependent:
+		return base, nil
+	case types.TopologyDAG:
+		if len(partition.Dependencies) == 0 {
+			return base, nil
+		}
+		return b.partitionBranch(partition.Dependencies[0], plan)
+	default:
+		for i, p := range plan.Partitions {
+			if p.ID == partition.ID {
+				if i == 0 {
+					return base, nil
+				}
+				return b.partitionBranch(plan.Partitions[i-1].ID, plan)
+			}
+		}
+		return "", fmt.Errorf("partition %d is not in the plan", partition.ID)
 	}
+}
 
-	// Check for untracked files
-	output, err := runGitCommand(b.workingDir, "status", "--porcelain")
-	if err != nil {
-		return false, fmt.Errorf("failed to check git status: %w", err)
+// partitionBranch returns the existing branch of the partition with the given ID
+func (b *Brancher) partitionBranch(id int, plan *types.PartitionPlan) (string, error) {
+	for _, p := range plan.Partitions {
+		if p.ID == id {
+			if !b.branchExists(p.BranchName) {
+				return "", fmt.Errorf("dependency branch '%s' does not exist", p.BranchName)
+			}
+			return p.BranchName, nil
+		}
 	}
-
-	return len(strings.TrimSpace(output)) > 0, nil
+	return "", fmt.Errorf("could not find partition with ID %d", id)
 }
 
-func (b *Brancher) determineBaseBranch(partition types.Partition, plan *types.PartitionPlan, cfg *types.Config) (string, error) {
-	if len(partition.Dependencies) == 0 {
-		return cfg.TargetBranch, nil
+// mergeDependencyBranches merges the remaining dependency branches of a DAG partition into
+// parent, the commit of its first dependency. It stages the merged tree and returns the
+// commit the partition builds on.
+func (b *Brancher) mergeDependencyBranches(index *indexBuilder, partition types.Partition, plan *types.PartitionPlan, parent string) (string, error) {
+	if plan.Metadata.Topology != types.TopologyDAG || len(partition.Dependencies) < 2 {
+		return parent, nil
 	}
 
-	lastDep := partition.Dependencies[len(partition.Dependencies)-1]
-
-	for _, p := range plan.Partitions {
-		if p.ID == lastDep {
-			baseBranch := fmt.Sprintf("%s-%d-%s", cfg.BranchPrefix, p.ID, p.Name)
-			if !b.branchExists(baseBranch) {
-				return "", fmt.Errorf("dependency branch '%s' does not exist", baseBranch)
-			}
-			return baseBranch, nil
+	for _, id := range partition.Dependencies[1:] {
+		branch, err := b.partitionBranch(id, plan)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(b.out, "🔀 Merging dependency branch %s\n", branch)
+		tree, err := mergeTrees(b.ctx, b.workingDir, parent, branch)
+		if err != nil {
+			return "", fmt.Errorf("failed to merge dependency branch %s: %w", branch, err)
+		}
+		message := fmt.Sprintf("Merge branch '%s' into %s", branch, partition.BranchName)
+		parent, err = commitTree(b.ctx, b.workingDir, tree, message, commitOptions{sign: b.sign}, parent, branch)
+		if err != nil {
+			return "", fmt.Errorf("failed to commit merge of %s: %w", branch, err)
 		}
 	}
 
-	return "", fmt.Errorf("could not find partition with ID %d", lastDep)
+	if err := index.readTree(parent); err != nil {
+		return "", fmt.Errorf("failed to stage merged dependencies: %w", err)
+	}
+	return parent, nil
 }
 
 // Branch management methods
 
 func (b *Brancher) DeleteLocalBranch(branchName string) error {
-	return runGitCommandQuiet(b.workingDir, "branch", "-D", branchName)
+	return runGitCommandQuiet(b.ctx, b.workingDir, "branch", "-D", branchName)
 }
 
 func (b *Brancher) DeleteRemoteBranch(branchName string) error {
-	return runGitCommandQuiet(b.workingDir, "push", "origin", "--delete", branchName)
+	return runGitCommandQuiet(b.ctx, b.workingDir, hookArgs(b.noVerify, "push", b.remote, "--delete", branchName)...)
 }
 
 func (b *Brancher) GetLocalBranches() ([]string, error) {
-	output, err := runGitCommand(b.workingDir, "branch", "--format=%(refname:short)")
+	output, err := runGitCommand(b.ctx, b.workingDir, "branch", "--format=%(refname:short)")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get local branches: %w", err)
 	}
@@ -239,7 +575,7 @@ func (b *Brancher) GetLocalBranches() ([]string, error) {
 }
 
 func (b *Brancher) GetRemoteBranches() ([]string, error) {
-	output, err := runGitCommand(b.workingDir, "branch", "-r", "--format=%(refname:short)")
+	output, err := runGitCommand(b.ctx, b.workingDir, "branch", "-r", "--format=%(refname:short)")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get remote branches: %w", err)
 	}
@@ -258,41 +594,61 @@ func (b *Brancher) GetRemoteBranches() ([]string, error) {
 }
 
 // rollbackBranches cleans up created branches when an error occurs
-func (b *Brancher) rollbackBranches(createdBranches, pushedBranches []string, originalBranch string) {
-	if len(createdBranches) == 0 && len(pushedBranches) == 0 {
+func (b *Brancher) rollbackBranches(createdBranches, pushedBranches []string) {
+	if len(createdBranches) == 0 && len(pushedBranches) == 0 && len(b.updated) == 0 {
 		return
 	}
 
-	fmt.Printf("🔄 Rolling back branch creation...\n")
+	fmt.Fprintf(b.out, "🔄 Rolling back branch creation...\n")
+
+	// Roll back even when the run was cancelled
+	defer func(ctx context.Context) { b.ctx = ctx }(b.ctx)
+	b.ctx = context.WithoutCancel(b.ctx)
 
-	if err := b.CheckoutBranch(originalBranch); err != nil {
-		fmt.Printf("⚠️  Warning: Could not checkout original branch %s during rollback: %v\n", originalBranch, err)
+	if b.keepPushed {
+		pushed := make(map[string]bool)
+		for _, branchName := range pushedBranches {
+			pushed[branchName] = true
+			fmt.Fprintf(b.out, "📌 Keeping pushed branch: %s\n", branchName)
+		}
+		var unpushed []string
+		for _, branchName := range createdBranches {
+			if !pushed[branchName] {
+				unpushed = append(unpushed, branchName)
+			}
+		}
+		createdBranches, pushedBranches = unpushed, nil
 	}
 
 	// Delete remote branches first
 	for _, branchName := range pushedBranches {
-		fmt.Printf("🗑️  Deleting remote branch: %s\n", branchName)
+		if b.protected.Protects(branchName) {
+			fmt.Fprintf(b.out, "🛡️  Not deleting protected remote branch: %s\n", branchName)
+			continue
+		}
+		fmt.Fprintf(b.out, "🗑️  Deleting remote branch: %s\n", branchName)
 		if err := b.DeleteRemoteBranch(branchName); err != nil {
-			fmt.Printf("⚠️  Warning: Could not delete remote branch %s: %v\n", branchName, err)
+			fmt.Fprintf(b.out, "⚠️  Warning: Could not delete remote branch %s: %v\n", branchName, err)
 		} else {
-			fmt.Printf("✅ Deleted remote branch: %s\n", branchName)
+			fmt.Fprintf(b.out, "✅ Deleted remote branch: %s\n", branchName)
 		}
 	}
 
 	// Delete local branches
 	for _, branchName := range createdBranches {
-		if branchName == originalBranch {
-			fmt.Printf("⚠️  Skipping current branch: %s\n", branchName)
+		if b.protected.Protects(branchName) {
+			fmt.Fprintf(b.out, "🛡️  Not deleting protected local branch: %s\n", branchName)
 			continue
 		}
-
-		fmt.Printf("🗑️  Deleting local branch: %s\n", branchName)
+		fmt.Fprintf(b.out, "🗑️  Deleting local branch: %s\n", branchName)
 		if err := b.DeleteLocalBranch(branchName); err != nil {
-			fmt.Printf("⚠️  Warning: Could not delete local branch %s: %v\n", branchName, err)
+			fmt.Fprintf(b.out, "⚠️  Warning: Could not delete local branch %s: %v\n", branchName, err)
 		} else {
-			fmt.Printf("✅ Deleted local branch: %s\n", branchName)
+			fmt.Fprintf(b.out, "✅ Deleted local branch: %s\n", branchName)
 		}
 	}
 
-	fmt.Printf("🔄 Rollback completed. Repository returned to clean state.\n")
+	b.restorePreviousBranches()
+
+	fmt.Fprintf(b.out, "🔄 Rollback completed. Repository returned to clean state.\n")
 }
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// PartitionCheckFailure describes a partition check command that failed on a branch
+type PartitionCheckFailure struct {
+	PartitionID int    `json:"partitionId"`
+	Branch      string `json:"branch"`
+	Command     string `json:"command"`
+	Output      string `json:"output"` // Last lines of the command's output
+}
+
+// checkOutputLines is how much of a failed check's output is kept and shown
+const checkOutputLines = 20
+
+// checkPartition runs the configured check commands in a temporary worktree of a partition's
+// commit, before the branch is pushed. With the abort policy the first failure is returned as
+// an error; with the continue policy every command runs and the failures are returned.
+func (b *Brancher) checkPartition(partition types.Partition, commit string, cfg *types.Config) ([]PartitionCheckFailure, error) {
+	worktree, err := AddTemporaryWorktreeAt(b.ctx, b.workingDir, commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check out %s for its checks: %w", partition.BranchName, err)
+	}
+	defer worktree.Remove()
+
+	// Reuse installed dependencies from the main checkout
+	if root, err := runGitCommand(b.ctx, b.workingDir, "rev-parse", "--show-toplevel"); err == nil {
+		nodeModules := filepath.Join(root, "node_modules")
+		if _, err := os.Stat(nodeModules); err == nil {
+			os.Symlink(nodeModules, filepath.Join(worktree.Path, "node_modules"))
+		}
+	}
+
+	var failures []PartitionCheckFailure
+	for _, command := range cfg.PartitionChecks {
+		fmt.Fprintf(b.out, "🧪 Checking %s: %s\n", partition.BranchName, command)
+		check := exec.CommandContext(b.ctx, "sh", "-c", command)
+		check.Dir = worktree.Path
+		check.Env = append(runEnv(b.ctx),
+			"PR_SPLIT_PARTITION="+strconv.Itoa(partition.ID),
+			"PR_SPLIT_BRANCH="+partition.BranchName)
+		output, err := check.CombinedOutput()
+		if err == nil {
+			continue
+		}
+		if b.ctx.Err() != nil {
+			return nil, b.ctx.Err()
+		}
+
+		failure := PartitionCheckFailure{
+			PartitionID: partition.ID,
+			Branch:      partition.BranchName,
+			Command:     command,
+			Output:      tailLines(string(output), checkOutputLines),
+		}
+		fmt.Fprintf(b.out, "❌ Check failed on %s: %s\n", partition.BranchName, command)
+		if failure.Output != "" {
+			fmt.Fprintln(b.out, failure.Output)
+		}
+		if cfg.CheckPolicy != types.CheckPolicyContinue {
+			return nil, fmt.Errorf("%w: %q on partition %d (%s): %v", ErrPartitionCheckFailed, command, partition.ID, partition.BranchName, err)
+		}
+		failures = append(failures, failure)
+	}
+	return failures, nil
+}
+
+// tailLines keeps the last n lines of command output
+func tailLines(output string, n int) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,30 +1,98 @@
 package git
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"pr-splitter-cli/internal/projectcontext"
 	"pr-splitter-cli/internal/types"
 )
 
 // Client handles git operations with focused responsibilities
 type Client struct {
+	ctx        context.Context
 	workingDir string
+	remote     string // Remote partition branches are pushed to
+	noVerify   bool   // Skip git hooks on the commits and pushes the tool makes
 	validator  *Validator
 	differ     *Differ
 	brancher   *Brancher
+	guard      ForcePushCheck // Called before a branch on the push remote is rewritten
 }
 
 // NewClient creates a new git client with all sub-components
 func NewClient() *Client {
 	wd, _ := os.Getwd()
+	return NewClientInDir(wd)
+}
+
+// WithContext returns a copy of the client whose git commands are cancelled when ctx is done
+func (c *Client) WithContext(ctx context.Context) *Client {
+	// A run id set on the client stays with it
+	ctx = withRunID(ctx, runIDFrom(c.ctx))
+	validator, differ, brancher := *c.validator, *c.differ, *c.brancher
+	validator.ctx, differ.ctx, brancher.ctx = ctx, ctx, ctx
+
+	bound := *c
+	bound.ctx = ctx
+	bound.validator, bound.differ, bound.brancher = &validator, &differ, &brancher
+	return &bound
+}
+
+// SetRunID tags the git commands the client runs with a run id: it is recorded in their audit
+// log entries and exported as RunIDEnvVar to git hooks and partition checks
+func (c *Client) SetRunID(id string) {
+	ctx := withRunID(c.ctx, id)
+	c.ctx, c.validator.ctx, c.differ.ctx, c.brancher.ctx = ctx, ctx, ctx, ctx
+}
+
+// SetOutput sends the progress output of git operations to w instead of stdout
+func (c *Client) SetOutput(w io.Writer) {
+	c.validator.out, c.differ.out, c.brancher.out = w, w, w
+}
+
+// SetRemote pushes, deletes and looks up partition branches on the named remote instead of
+// origin; an empty name restores origin
+func (c *Client) SetRemote(name string) {
+	if name == "" {
+		name = DefaultRemote
+	}
+	c.remote, c.brancher.remote = name, name
+}
+
+// SetNoVerify skips pre-commit, commit-msg and pre-push hooks on the commits and pushes the
+// client makes, like git's --no-verify
+func (c *Client) SetNoVerify(noVerify bool) {
+	c.noVerify, c.brancher.noVerify = noVerify, noVerify
+}
+
+// Remote returns the remote partition branches are pushed to
+func (c *Client) Remote() string {
+	return c.remote
+}
+
+// Context returns the context the client's git commands run under
+func (c *Client) Context() context.Context {
+	return c.ctx
+}
+
+// NewClientInDir creates a git client that operates on the checkout at dir
+func NewClientInDir(wd string) *Client {
 	validator := NewValidator(wd)
 	differ := NewDiffer(wd)
 	brancher := NewBrancher(wd)
 
 	return &Client{
+		ctx:        context.Background(),
 		workingDir: wd,
+		remote:     DefaultRemote,
 		validator:  validator,
 		differ:     differ,
 		brancher:   brancher,
@@ -36,6 +104,12 @@ func (c *Client) ValidateGitRepository() error {
 	return c.validator.ValidateRepository()
 }
 
+// CheckRepository checks only that the working directory is in a git repository, for
+// commands that read branches without touching the checkout, so local changes are fine
+func (c *Client) CheckRepository() error {
+	return c.validator.checkGitRepository()
+}
+
 // ValidateBranches validates that source and target branches exist
 func (c *Client) ValidateBranches(sourceBranch, targetBranch string) error {
 	return c.validator.ValidateBranches(sourceBranch, targetBranch)
@@ -43,7 +117,8 @@ func (c *Client) ValidateBranches(sourceBranch, targetBranch string) error {
 
 // GetChanges analyzes git changes between source and target branches
 func (c *Client) GetChanges(sourceBranch, targetBranch string) ([]types.FileChange, error) {
-	if err := c.ValidateGitRepository(); err != nil {
+	// Branches are built with commit-tree and a private index, so local changes do not get in the way
+	if err := c.validator.checkGitRepository(); err != nil {
 		return nil, err
 	}
 
@@ -54,11 +129,238 @@ func (c *Client) GetChanges(sourceBranch, targetBranch string) ([]types.FileChan
 	return c.differ.GetChanges(sourceBranch, targetBranch)
 }
 
+// GetChangesFromBase analyzes git changes between a pinned base commit and the source branch
+func (c *Client) GetChangesFromBase(sourceBranch, targetBranch, baseCommit string) ([]types.FileChange, error) {
+	// Branches are built with commit-tree and a private index, so local changes do not get in the way
+	if err := c.validator.checkGitRepository(); err != nil {
+		return nil, err
+	}
+
+	if err := c.ValidateBranches(sourceBranch, targetBranch); err != nil {
+		return nil, err
+	}
+
+	return c.differ.GetChangesFromBase(sourceBranch, baseCommit)
+}
+
+// GetTargetDrift returns paths that changed between the merge-base and the partition base commit
+func (c *Client) GetTargetDrift(mergeBase, baseCommit string, paths []string) ([]string, error) {
+	return c.differ.GetTargetDrift(mergeBase, baseCommit, paths)
+}
+
+// GetLineDiffs returns per-file changed lines between a base commit and the source branch
+func (c *Client) GetLineDiffs(baseCommit, sourceBranch string) (map[string]types.LineDiff, error) {
+	return c.differ.GetLineDiffs(baseCommit, sourceBranch)
+}
+
+// GetCommitFileSets lists the files touched by each of the last maxCommits non-merge commits reachable from rev
+func (c *Client) GetCommitFileSets(rev string, maxCommits int) ([][]string, error) {
+	output, err := runGitCommand(c.ctx, c.workingDir, "log", "--no-merges", "--no-renames", "--name-only", "--format=%x00", fmt.Sprintf("-n%d", maxCommits), rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit history: %w", err)
+	}
+
+	var commits [][]string
+	for _, block := range strings.Split(output, "\x00") {
+		var files []string
+		for _, line := range strings.Split(block, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				files = append(files, line)
+			}
+		}
+		if len(files) > 0 {
+			commits = append(commits, files)
+		}
+	}
+	return commits, nil
+}
+
+// BranchCommit is a non-merge commit of a branch and the files it touches
+type BranchCommit struct {
+	SHA     string
+	Message string
+	Files   []string
+}
+
+// GetBranchCommits lists the non-merge commits of base..rev, newest first, with the files each
+// touches; a rename touches both its old and new path
+func (c *Client) GetBranchCommits(base, rev string) ([]BranchCommit, error) {
+	output, err := runGitCommand(c.ctx, c.workingDir, "log", "--no-merges", "--no-renames", "--name-only", "--format=%x00%H%x1f%B%x1e", base+".."+rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commits of %s..%s: %w", base, rev, err)
+	}
+
+	var commits []BranchCommit
+	for _, block := range strings.Split(output, "\x00") {
+		header, files, ok := strings.Cut(block, "\x1e")
+		if !ok {
+			continue
+		}
+		sha, message, _ := strings.Cut(header, "\x1f")
+		commit := BranchCommit{SHA: strings.TrimSpace(sha), Message: strings.TrimSpace(message)}
+		for _, line := range strings.Split(files, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				commit.Files = append(commit.Files, line)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// GetFileAuthors counts, per path, the commits each author email made to it among the last maxCommits commits reachable from rev
+func (c *Client) GetFileAuthors(rev string, paths []string, maxCommits int) (map[string]map[string]int, error) {
+	authors := make(map[string]map[string]int)
+	if len(paths) == 0 {
+		return authors, nil
+	}
+
+	args := append([]string{"log", "--no-merges", "--no-renames", "--name-only", "--format=%x00%ae", fmt.Sprintf("-n%d", maxCommits), rev, "--"}, paths...)
+	output, err := runGitCommand(c.ctx, c.workingDir, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file history: %w", err)
+	}
+
+	for _, block := range strings.Split(output, "\x00") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		if len(lines) < 2 {
+			continue
+		}
+		email := strings.ToLower(strings.TrimSpace(lines[0]))
+		for _, file := range lines[1:] {
+			if file = strings.TrimSpace(file); file == "" {
+				continue
+			}
+			if authors[file] == nil {
+				authors[file] = make(map[string]int)
+			}
+			authors[file][email]++
+		}
+	}
+	return authors, nil
+}
+
+// ReadFileAt returns the content of a file as of rev
+func (c *Client) ReadFileAt(rev, path string) (string, error) {
+	return runGitCommandRaw(c.ctx, c.workingDir, "show", rev+":"+path)
+}
+
+// ListFiles returns the path of every file in the tree of rev
+func (c *Client) ListFiles(rev string) ([]string, error) {
+	output, err := runGitCommandRaw(c.ctx, c.workingDir, "ls-tree", "-r", "-z", "--name-only", "--full-tree", rev)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, file := range strings.Split(output, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// GetMergeBase returns the merge-base SHA of two refs
+func (c *Client) GetMergeBase(refA, refB string) (string, error) {
+	mergeBase, err := runGitCommand(c.ctx, c.workingDir, "merge-base", refA, refB)
+	if err != nil && c.differ.clone().shallow {
+		return "", shallowHistoryError(refA, refB)
+	}
+	return mergeBase, err
+}
+
+// ResolveCommit returns the commit SHA a ref points to
+func (c *Client) ResolveCommit(ref string) (string, error) {
+	return runGitCommand(c.ctx, c.workingDir, "rev-parse", "--verify", ref+"^{commit}")
+}
+
+// GetProjectFiles returns all relevant files in the working tree as unchanged context
+func (c *Client) GetProjectFiles() ([]types.FileChange, error) {
+	return c.differ.getAllProjectFiles()
+}
+
+// WithProjectContext returns the changes preceded by the unchanged project files they import,
+// directly or through up to depth levels of imports, as of the commit rev. Only those files are
+// read. A negative depth includes every relevant project file. An empty rev reads the working
+// tree instead.
+func (c *Client) WithProjectContext(changes []types.FileChange, rev string, depth int) ([]types.FileChange, error) {
+	if depth < 0 {
+		return c.withAllProjectFiles(changes, rev)
+	}
+
+	var paths []string
+	var err error
+	if rev == "" {
+		paths, err = c.differ.listProjectFiles()
+	} else {
+		paths, _, err = c.differ.listProjectFilesAt(rev)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project files: %w", err)
+	}
+	imported := projectcontext.Closure(changes, paths, c.differ.projectFileReader(rev), depth)
+	fmt.Fprintf(c.differ.out, "📚 Sending %d of %d project files imported by the changes (depth %d)\n", len(imported), len(paths), depth)
+	return append(imported, changes...), nil
+}
+
+// withAllProjectFiles returns the changes preceded by the project files of the commit rev, or
+// of the working tree when rev is empty, that are not among them
+func (c *Client) withAllProjectFiles(changes []types.FileChange, rev string) ([]types.FileChange, error) {
+	var projectFiles []types.FileChange
+	var err error
+	if rev == "" {
+		projectFiles, err = c.differ.getAllProjectFiles()
+	} else {
+		projectFiles, err = c.differ.getAllProjectFilesAt(rev)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project files: %w", err)
+	}
+
+	changed := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		changed[change.Path] = true
+	}
+	var files []types.FileChange
+	for _, file := range projectFiles {
+		if !changed[file.Path] {
+			files = append(files, file)
+		}
+	}
+	return append(files, changes...), nil
+}
+
 // CreateBranches creates branches for each partition
 func (c *Client) CreateBranches(plan *types.PartitionPlan, cfg *types.Config, sourceBranch string) ([]string, error) {
 	return c.brancher.CreateBranches(plan, cfg, sourceBranch)
 }
 
+// PartitionCheckFailures returns the partition checks that failed in the last CreateBranches
+// under the continue policy
+func (c *Client) PartitionCheckFailures() []PartitionCheckFailure {
+	return c.brancher.failures
+}
+
+// PredictedConflicts returns the sibling partitions of the last CreateBranches that would
+// conflict once both are merged, for independent and DAG topologies, or why they could not
+// be predicted
+func (c *Client) PredictedConflicts() ([]PartitionConflict, error) {
+	return c.brancher.conflicts, c.brancher.conflictErr
+}
+
+// SkipBranches makes the next CreateBranches keep these branches as they are, for resuming a run
+func (c *Client) SkipBranches(branches []string) {
+	c.brancher.done = make(map[string]bool)
+	for _, branch := range branches {
+		c.brancher.done[branch] = true
+	}
+}
+
+// OnBranchPushed registers fn to be called after each partition branch is pushed by CreateBranches
+func (c *Client) OnBranchPushed(fn func(BranchProgress)) {
+	c.brancher.onPushed = fn
+}
+
 // Utility methods for external access
 func (c *Client) GetCurrentBranch() (string, error) {
 	return c.brancher.GetCurrentBranch()
@@ -84,20 +386,148 @@ func (c *Client) GetRemoteBranches() ([]string, error) {
 	return c.brancher.GetRemoteBranches()
 }
 
+// WorkingDir returns the checkout the client operates on
+func (c *Client) WorkingDir() string {
+	return c.workingDir
+}
+
+// RepoRoot returns the top-level directory of the checkout
+func (c *Client) RepoRoot() (string, error) {
+	root, err := runGitCommand(c.ctx, c.workingDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository root: %w", err)
+	}
+	return strings.TrimSpace(root), nil
+}
+
+// GitCommonDir returns the absolute git directory shared by all worktrees of the repository
+func (c *Client) GitCommonDir() (string, error) {
+	dir, err := runGitCommand(c.ctx, c.workingDir, "rev-parse", "--git-common-dir")
+	if err != nil {
+		return "", fmt.Errorf("failed to find git directory: %w", err)
+	}
+	dir = strings.TrimSpace(dir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(c.workingDir, dir)
+	}
+	return filepath.Abs(dir)
+}
+
+// GetUserSlug returns a branch-safe identifier for the current git user
+func (c *Client) GetUserSlug() string {
+	candidates := []string{}
+	if email, err := runGitCommand(c.ctx, c.workingDir, "config", "user.email"); err == nil && email != "" {
+		candidates = append(candidates, strings.SplitN(email, "@", 2)[0])
+	}
+	if name, err := runGitCommand(c.ctx, c.workingDir, "config", "user.name"); err == nil {
+		candidates = append(candidates, name)
+	}
+	candidates = append(candidates, os.Getenv("USER"), os.Getenv("USERNAME"))
+
+	for _, candidate := range candidates {
+		if slug := slugify(candidate); slug != "" {
+			return slug
+		}
+	}
+	return "user"
+}
+
+// slugify lowercases a string and replaces characters that are unsafe in ref names
+func slugify(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(value)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-.")
+}
+
+// HasLocalChanges reports whether the checkout has uncommitted, staged, or untracked changes
+func (c *Client) HasLocalChanges() (bool, error) {
+	output, err := runGitCommand(c.ctx, c.workingDir, "status", "--porcelain")
+	if err != nil {
+		return false, fmt.Errorf("failed to check git status: %w", err)
+	}
+	return output != "", nil
+}
+
+// hookArgs adds --no-verify after the git subcommand in args when hooks are skipped
+func hookArgs(noVerify bool, args ...string) []string {
+	if !noVerify {
+		return args
+	}
+	return append([]string{args[0], "--no-verify"}, args[1:]...)
+}
+
 // runGitCommand executes a git command and returns output
-func runGitCommand(dir string, args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
+func runGitCommand(ctx context.Context, dir string, args ...string) (string, error) {
+	audit := beginAudit(ctx, dir, args)
+	cmd := gitCommand(ctx, dir, args)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := Run(cmd)
+	audit.finish(err)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// gitCommand prepares a git command in dir that ctx cancels. Cancelling interrupts git rather
+// than killing it, so it can remove its lock files.
+func gitCommand(ctx context.Context, dir string, args []string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = dir
-	output, err := cmd.Output()
+	cmd.Env = runEnv(ctx)
+	cmd.Cancel = func() error {
+		if err := cmd.Process.Signal(os.Interrupt); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+	cmd.WaitDelay = 10 * time.Second
+	return cmd
+}
+
+// runGitCommandRaw executes a git command and returns untrimmed output
+func runGitCommandRaw(ctx context.Context, dir string, args ...string) (string, error) {
+	audit := beginAudit(ctx, dir, args)
+	cmd := gitCommand(ctx, dir, args)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := Run(cmd)
+	audit.finish(err)
 	if err != nil {
 		return "", err
 	}
-	return strings.TrimSpace(string(output)), nil
+	return stdout.String(), nil
+}
+
+// runGitCommandWithInput executes a git command with the given stdin
+func runGitCommandWithInput(ctx context.Context, dir, input string, args ...string) error {
+	cmd := gitCommand(ctx, dir, args)
+	cmd.Stdin = strings.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	audit := beginAudit(ctx, dir, args)
+	err := Run(cmd)
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+	}
+	audit.finish(err)
+	return err
 }
 
 // runGitCommandQuiet executes a git command without capturing output
-func runGitCommandQuiet(dir string, args ...string) error {
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
-	return cmd.Run()
+func runGitCommandQuiet(ctx context.Context, dir string, args ...string) error {
+	audit := beginAudit(ctx, dir, args)
+	cmd := gitCommand(ctx, dir, args)
+	err := Run(cmd)
+	audit.finish(err)
+	return err
 }
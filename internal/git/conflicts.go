@@ -0,0 +1,85 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// PartitionConflict is a pair of sibling partitions predicted to conflict when both are merged
+type PartitionConflict struct {
+	Partitions [2]int    `json:"partitions"` // IDs of the two partitions
+	Branches   [2]string `json:"branches"`
+	Files      []string  `json:"files"` // Files that would conflict
+}
+
+// hasSiblings reports whether a topology creates partitions that are not based on each other
+func hasSiblings(topology string) bool {
+	return topology == types.TopologyIndependent || topology == types.TopologyDAG
+}
+
+// predictConflicts test-merges every pair of sibling partition branches, those where neither
+// is based on the other, and returns the pairs that would conflict once both are merged
+func (b *Brancher) predictConflicts(plan *types.PartitionPlan) ([]PartitionConflict, error) {
+	ancestors := partitionAncestors(plan)
+	var conflicts []PartitionConflict
+	for i, first := range plan.Partitions {
+		for _, second := range plan.Partitions[i+1:] {
+			if ancestors[first.ID][second.ID] || ancestors[second.ID][first.ID] {
+				continue
+			}
+			files, err := conflictingFiles(b.ctx, b.workingDir, first.BranchName, second.BranchName)
+			if err != nil {
+				return nil, err
+			}
+			if len(files) > 0 {
+				conflicts = append(conflicts, PartitionConflict{
+					Partitions: [2]int{first.ID, second.ID},
+					Branches:   [2]string{first.BranchName, second.BranchName},
+					Files:      files,
+				})
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// partitionAncestors maps each partition ID to the IDs of the partitions its branch contains
+func partitionAncestors(plan *types.PartitionPlan) map[int]map[int]bool {
+	ancestors := make(map[int]map[int]bool)
+	if plan.Metadata.Topology != types.TopologyDAG {
+		return ancestors
+	}
+
+	dependencies := make(map[int][]int)
+	for _, partition := range plan.Partitions {
+		dependencies[partition.ID] = partition.Dependencies
+	}
+	var collect func(id int, into map[int]bool)
+	collect = func(id int, into map[int]bool) {
+		for _, dep := range dependencies[id] {
+			if !into[dep] {
+				into[dep] = true
+				collect(dep, into)
+			}
+		}
+	}
+	for _, partition := range plan.Partitions {
+		ancestors[partition.ID] = make(map[int]bool)
+		collect(partition.ID, ancestors[partition.ID])
+	}
+	return ancestors
+}
+
+// reportConflicts prints the predicted conflicts before any sibling branch is pushed
+func (b *Brancher) reportConflicts(conflicts []PartitionConflict) {
+	if len(conflicts) == 0 {
+		fmt.Fprintln(b.out, "✅ No conflicts predicted between sibling partitions")
+		return
+	}
+	for _, conflict := range conflicts {
+		fmt.Fprintf(b.out, "⚠️  Partitions %d and %d will conflict when both are merged (%s, %s): %s\n",
+			conflict.Partitions[0], conflict.Partitions[1], conflict.Branches[0], conflict.Branches[1], strings.Join(conflict.Files, ", "))
+	}
+}
@@ -2,64 +2,242 @@ package git
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
+	"pr-splitter-cli/internal/output"
 	"pr-splitter-cli/internal/types"
 )
 
 // Differ handles git diff operations and file analysis
 type Differ struct {
+	ctx        context.Context
+	out        io.Writer // Progress output
 	workingDir string
+	cloneInfo  *cloneInfo // Shallow and partial clone details, nil until first needed
 }
 
 // NewDiffer creates a new git differ
 func NewDiffer(workingDir string) *Differ {
-	return &Differ{workingDir: workingDir}
+	return &Differ{ctx: context.Background(), out: os.Stdout, workingDir: workingDir}
 }
 
 // GetChanges analyzes git changes between source and target branches
 func (d *Differ) GetChanges(sourceBranch, targetBranch string) ([]types.FileChange, error) {
+	// The three-dot range diffs against the merge-base, which a shallow clone may not have
+	if d.clone().shallow {
+		if err := runGitCommandQuiet(d.ctx, d.workingDir, "merge-base", targetBranch, sourceBranch); err != nil {
+			return nil, shallowHistoryError(targetBranch, sourceBranch)
+		}
+	}
+	return d.getChangesForRange(sourceBranch, targetBranch, fmt.Sprintf("%s...%s", targetBranch, sourceBranch))
+}
+
+// GetChangesFromBase analyzes git changes between a pinned base commit and the source branch
+func (d *Differ) GetChangesFromBase(sourceBranch, baseCommit string) ([]types.FileChange, error) {
+	return d.getChangesForRange(sourceBranch, baseCommit, fmt.Sprintf("%s..%s", baseCommit, sourceBranch))
+}
+
+// getChangesForRange analyzes git changes for a revision range
+func (d *Differ) getChangesForRange(sourceBranch, targetBranch, revRange string) ([]types.FileChange, error) {
+	modes, err := d.getFileModes(revRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file modes: %w", err)
+	}
+
+	// A partial clone fetches the blobs the line counts and contents need up front
+	if err := d.prefetchBlobs(changedBlobs(modes)); err != nil {
+		return nil, err
+	}
+
 	// Get file changes with rename detection and line count stats
-	output, err := runGitCommand(d.workingDir, "diff", "--numstat", "-M90",
-		fmt.Sprintf("%s...%s", targetBranch, sourceBranch))
+	output, err := runGitCommand(d.ctx, d.workingDir, "diff", "--numstat", "-M90", revRange)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git diff: %w", err)
 	}
 
-	changes, err := d.parseGitDiff(output, sourceBranch)
+	changes, err := d.parseGitDiff(output, sourceBranch, modes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse git diff: %w", err)
 	}
 
-	relevantChanges, err := d.filterAndEnrichChanges(changes)
+	// Unchanged project files are only read for plugins that ask for them, see GetProjectFiles
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no relevant file changes found between %s and %s", sourceBranch, targetBranch)
+	}
+
+	return changes, nil
+}
+
+// GetTargetDrift returns the given paths that changed between the merge-base and the partition base
+func (d *Differ) GetTargetDrift(mergeBase, baseCommit string, paths []string) ([]string, error) {
+	if len(paths) == 0 || mergeBase == baseCommit {
+		return nil, nil
+	}
+
+	args := append([]string{"diff", "--name-only", "--no-renames", mergeBase, baseCommit, "--"}, paths...)
+	output, err := runGitCommand(d.ctx, d.workingDir, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process changes: %w", err)
+		return nil, fmt.Errorf("failed to diff target since merge-base: %w", err)
 	}
 
-	if len(relevantChanges) == 0 {
-		return nil, fmt.Errorf("no relevant file changes found between %s and %s", sourceBranch, targetBranch)
+	var drifted []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			drifted = append(drifted, line)
+		}
+	}
+
+	return drifted, nil
+}
+
+// GetLineDiffs returns the changed lines of every file between a base commit and the source branch, keyed by new path
+func (d *Differ) GetLineDiffs(baseCommit, sourceBranch string) (map[string]types.LineDiff, error) {
+	output, err := runGitCommandRaw(d.ctx, d.workingDir, "diff", "-U0", "-M90", "--no-color", "--no-ext-diff", baseCommit+".."+sourceBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get line diff: %w", err)
+	}
+
+	diffs := make(map[string]types.LineDiff)
+	var current, oldPath string
+	var hunk *types.DiffHunk
+	inHunk := false
+	lastSign := byte(0)
+
+	flush := func() {
+		if current != "" && hunk != nil {
+			diff := diffs[current]
+			diff.Hunks = append(diff.Hunks, *hunk)
+			diffs[current] = diff
+		}
+		hunk = nil
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			current, oldPath, inHunk = "", "", false
+		case !inHunk && strings.HasPrefix(line, "rename to "):
+			current = strings.TrimPrefix(line, "rename to ")
+			diffs[current] = types.LineDiff{}
+		case !inHunk && strings.HasPrefix(line, "--- a/"):
+			oldPath = strings.TrimPrefix(line, "--- a/")
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			current = strings.TrimPrefix(line, "+++ b/")
+			if line == "+++ /dev/null" {
+				current = oldPath
+			}
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			hunk = &types.DiffHunk{}
+			hunk.OldStart, hunk.OldLines = parseHunkHeader(line)
+			inHunk = true
+		case inHunk && strings.HasPrefix(line, "-"):
+			hunk.Removed = append(hunk.Removed, line[1:])
+			lastSign = '-'
+		case inHunk && strings.HasPrefix(line, "+"):
+			hunk.Added = append(hunk.Added, line[1:])
+			lastSign = '+'
+		case inHunk && strings.HasPrefix(line, "\\"):
+			if lastSign == '+' {
+				hunk.AddedNoEOL = true
+			} else {
+				hunk.RemovedNoEOL = true
+			}
+		}
 	}
+	flush()
 
-	return relevantChanges, nil
+	return diffs, nil
+}
+
+// parseHunkHeader reads the old start line and line count from "@@ -start,count +start,count @@"
+func parseHunkHeader(header string) (int, int) {
+	fields := strings.Fields(header)
+	if len(fields) < 2 || !strings.HasPrefix(fields[1], "-") {
+		return 0, 0
+	}
+
+	old := strings.SplitN(strings.TrimPrefix(fields[1], "-"), ",", 2)
+	start, _ := strconv.Atoi(old[0])
+	count := 1
+	if len(old) == 2 {
+		count, _ = strconv.Atoi(old[1])
+	}
+	return start, count
+}
+
+// rawEntry is the mode, blobs and status of a changed file from git diff --raw
+type rawEntry struct {
+	oldMode string
+	newMode string
+	oldBlob string // nullOID for an added file
+	newBlob string // nullOID for a deleted file
+	status  byte   // A, D, M, R, T...
+}
+
+// getFileModes returns the modes and status of every changed file in a revision range, keyed by new path
+func (d *Differ) getFileModes(revRange string) (map[string]rawEntry, error) {
+	output, err := runGitCommand(d.ctx, d.workingDir, "diff", "--raw", "--no-abbrev", "-M90", revRange)
+	if err != nil {
+		return nil, err
+	}
+	return parseRawDiff(output), nil
+}
+
+// parseRawDiff parses lines like ":100644 100755 8b2fe54 8b2fe54 M\trun.sh", where renames
+// list the old and new path
+func parseRawDiff(output string) map[string]rawEntry {
+	entries := make(map[string]rawEntry)
+	for _, line := range strings.Split(output, "\n") {
+		meta, paths, ok := strings.Cut(strings.TrimPrefix(line, ":"), "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) < 5 || fields[4] == "" {
+			continue
+		}
+		names := strings.Split(paths, "\t")
+		entries[names[len(names)-1]] = rawEntry{oldMode: fields[0], newMode: fields[1], oldBlob: fields[2], newBlob: fields[3], status: fields[4][0]}
+	}
+	return entries
+}
+
+// changedBlobs returns the blobs on either side of the changed files, leaving out submodules,
+// whose commits live in another repository
+func changedBlobs(modes map[string]rawEntry) []string {
+	seen := make(map[string]bool)
+	var oids []string
+	for _, entry := range modes {
+		for _, side := range [][2]string{{entry.oldMode, entry.oldBlob}, {entry.newMode, entry.newBlob}} {
+			if side[0] == types.FileModeGitlink || side[1] == nullOID || side[1] == "" || seen[side[1]] {
+				continue
+			}
+			seen[side[1]] = true
+			oids = append(oids, side[1])
+		}
+	}
+	sort.Strings(oids)
+	return oids
 }
 
 // parseGitDiff parses the output of git diff --numstat -M
-func (d *Differ) parseGitDiff(output, sourceBranch string) ([]types.FileChange, error) {
+func (d *Differ) parseGitDiff(numstat, sourceBranch string, modes map[string]rawEntry) ([]types.FileChange, error) {
 	var changes []types.FileChange
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-
-	for _, line := range lines {
+	for _, line := range strings.Split(strings.TrimSpace(numstat), "\n") {
 		if line == "" {
 			continue
 		}
 
-		change, err := d.parseDiffLine(line, sourceBranch)
+		change, err := d.parseDiffLine(line, modes)
 		if err != nil {
-			fmt.Printf("⚠️  Warning: %v\n", err)
+			fmt.Fprintf(d.out, "⚠️  Warning: %v\n", err)
 			continue
 		}
 
@@ -68,11 +246,52 @@ func (d *Differ) parseGitDiff(output, sourceBranch string) ([]types.FileChange,
 		}
 	}
 
+	d.loadContents(changes, sourceBranch, modes)
 	return changes, nil
 }
 
+// loadContents fills in the content of the changed text files. Their blobs are read with one
+// git cat-file rather than a git show per file; a file whose blob is unknown falls back to it.
+func (d *Differ) loadContents(changes []types.FileChange, sourceBranch string, modes map[string]rawEntry) {
+	var pending []int
+	var oids []string
+	for i, change := range changes {
+		if change.IsBinary || change.IsSubmodule || change.ChangeType == types.ChangeTypeDelete {
+			continue
+		}
+		pending = append(pending, i)
+		if blob := modes[change.Path].newBlob; blob != "" && blob != nullOID {
+			oids = append(oids, blob)
+		}
+	}
+
+	// Reading the contents takes a while on large diffs
+	progress := output.NewProgress(d.out, "Reading changed files", len(pending), "files")
+	defer progress.Done()
+
+	blobs, err := d.readBlobs(oids, progress)
+	if err != nil {
+		progress.Clear()
+		fmt.Fprintf(d.out, "⚠️  Warning: Could not read the changed files in one batch, reading them one by one: %v\n", err)
+		blobs = nil
+	}
+
+	for _, i := range pending {
+		content, ok := blobs[modes[changes[i].Path].newBlob]
+		if !ok {
+			content, err = d.getFileContent(changes[i].Path, sourceBranch, changes[i].ChangeType)
+			progress.Add(1)
+			if err != nil {
+				progress.Clear()
+				fmt.Fprintf(d.out, "⚠️  Warning: Could not read content for %s: %v\n", changes[i].Path, err)
+			}
+		}
+		changes[i].Content = content
+	}
+}
+
 // parseDiffLine parses a single line from git diff output
-func (d *Differ) parseDiffLine(line, sourceBranch string) (*types.FileChange, error) {
+func (d *Differ) parseDiffLine(line string, modes map[string]rawEntry) (*types.FileChange, error) {
 	parts := strings.Fields(line)
 	if len(parts) < 3 {
 		return nil, fmt.Errorf("invalid diff line format: %s", line)
@@ -100,22 +319,55 @@ func (d *Differ) parseDiffLine(line, sourceBranch string) (*types.FileChange, er
 		_, actualPath = parseGitRenameFormat(filePath)
 	}
 
-	content, err := d.getFileContent(actualPath, sourceBranch, changeType)
-	if err != nil && changeType != types.ChangeTypeDelete {
-		fmt.Printf("⚠️  Warning: Could not read content for %s: %v\n", filePath, err)
+	var mode, oldMode string
+	isSubmodule := false
+	if entry, ok := modes[actualPath]; ok {
+		isSubmodule = entry.oldMode == types.FileModeGitlink || entry.newMode == types.FileModeGitlink
+		changeType = correctChangeType(changeType, entry.status)
+		if changeType != types.ChangeTypeDelete {
+			mode = entry.newMode
+		}
+		if changeType != types.ChangeTypeAdd && changeType != types.ChangeTypeDelete && entry.oldMode != entry.newMode {
+			oldMode = entry.oldMode
+		}
 	}
 
+	// Binary files show "-" for line counts; their content is neither loaded nor analyzed, and a
+	// submodule has no content in this repository, only the commit it points at. Other contents
+	// are loaded afterwards, see loadContents.
+	isBinary := added == "-" && deleted == "-"
+
 	return &types.FileChange{
 		Path:         actualPath,
 		ChangeType:   changeType,
-		Content:      content,
 		LinesAdded:   linesAdded,
 		LinesDeleted: linesDeleted,
 		IsChanged:    true,
 		OldPath:      oldPath,
+		Mode:         mode,
+		OldMode:      oldMode,
+		IsBinary:     isBinary,
+		IsSubmodule:  isSubmodule,
 	}, nil
 }
 
+// correctChangeType replaces the change type guessed from line counts with the diff status, since
+// counts cannot tell an empty, binary or submodule change, nor a file that only gained lines from
+// an added one
+func correctChangeType(changeType types.ChangeType, status byte) types.ChangeType {
+	if changeType == types.ChangeTypeRename {
+		return changeType
+	}
+	switch status {
+	case 'A', 'C', 'R': // Copied and renamed paths are new where line counts saw no rename
+		return types.ChangeTypeAdd
+	case 'D':
+		return types.ChangeTypeDelete
+	default:
+		return types.ChangeTypeModify
+	}
+}
+
 // determineChangeType determines the type of change and handles renames
 func (d *Differ) determineChangeType(filePath, added, deleted string, parts []string) (types.ChangeType, string) {
 	// Handle Git's {oldname => newname} rename format
@@ -175,7 +427,7 @@ func (d *Differ) getFileContent(filePath, branch string, changeType types.Change
 		return "", fmt.Errorf("invalid file path: %s", filePath)
 	}
 
-	output, err := runGitCommand(d.workingDir, "show", fmt.Sprintf("%s:%s", branch, filePath))
+	output, err := runGitCommand(d.ctx, d.workingDir, "show", fmt.Sprintf("%s:%s", branch, filePath))
 	if err != nil {
 		return "", fmt.Errorf("git show failed for %s: %w", filePath, err)
 	}
@@ -183,78 +435,145 @@ func (d *Differ) getFileContent(filePath, branch string, changeType types.Change
 	return output, nil
 }
 
-// filterAndEnrichChanges filters relevant files and adds project context
-func (d *Differ) filterAndEnrichChanges(changes []types.FileChange) ([]types.FileChange, error) {
-	var relevantChanges []types.FileChange
-
-	// Get all project files for plugin context
-	projectFiles, err := d.getAllProjectFiles()
+// getAllProjectFiles gets all relevant project files for plugin context
+func (d *Differ) getAllProjectFiles() ([]types.FileChange, error) {
+	paths, err := d.listProjectFiles()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project files: %w", err)
+		return nil, err
 	}
 
-	// Add project files as context (not changed)
-	for _, projectFile := range projectFiles {
-		if !d.fileExistsInChanges(projectFile.Path, changes) {
-			relevantChanges = append(relevantChanges, projectFile)
+	progress := output.NewProgress(d.out, "Reading project files", len(paths), "files")
+	defer progress.Done()
+
+	projectFiles := make([]types.FileChange, 0, len(paths))
+	for _, relPath := range paths {
+		content, err := d.readProjectFile(relPath)
+		progress.Add(1)
+		if err != nil {
+			progress.Clear()
+			fmt.Fprintf(d.out, "⚠️  Warning: Could not read %s: %v\n", relPath, err)
+			content = ""
 		}
-	}
 
-	// Add changed files
-	relevantChanges = append(relevantChanges, changes...)
+		projectFiles = append(projectFiles, types.FileChange{
+			Path:      relPath,
+			Content:   content,
+			IsChanged: false,
+		})
+	}
 
-	return relevantChanges, nil
+	return projectFiles, nil
 }
 
-// fileExistsInChanges checks if a file path exists in the changes list
-func (d *Differ) fileExistsInChanges(path string, changes []types.FileChange) bool {
-	for _, change := range changes {
-		if change.Path == path {
-			return true
-		}
-	}
-	return false
-}
-
-// getAllProjectFiles gets all relevant project files for plugin context
-func (d *Differ) getAllProjectFiles() ([]types.FileChange, error) {
-	var projectFiles []types.FileChange
+// listProjectFiles returns the repository paths of the relevant files in the working tree
+// without reading them, skipping ignored directories such as node_modules
+func (d *Differ) listProjectFiles() ([]string, error) {
+	var paths []string
+	progress := output.NewProgress(d.out, "Scanning project files", 0, "files")
+	defer progress.Done()
 
 	err := filepath.Walk(d.workingDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
-			return nil
-		}
-
-		if shouldIgnoreFile(path) || !isRelevantFile(path) {
-			return nil
-		}
-
 		relPath, err := filepath.Rel(d.workingDir, path)
 		if err != nil {
 			return err
 		}
 
+		// Ignore patterns use forward slashes, so match them against the repository path
 		relPath = filepath.ToSlash(relPath)
-		content, err := d.readFileFromDisk(path)
-		if err != nil {
-			fmt.Printf("⚠️  Warning: Could not read %s: %v\n", relPath, err)
-			content = ""
+		if info.IsDir() {
+			if relPath != "." && shouldIgnoreFile(relPath+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(info.Name(), ".") || shouldIgnoreFile(relPath) || !isRelevantFile(relPath) {
+			return nil
+		}
+
+		paths = append(paths, relPath)
+		progress.Add(1)
+		return nil
+	})
+
+	return paths, err
+}
+
+// readProjectFile reads a working tree file by its repository path
+func (d *Differ) readProjectFile(relPath string) (string, error) {
+	return d.readFileFromDisk(filepath.Join(d.workingDir, filepath.FromSlash(relPath)))
+}
+
+// listProjectFilesAt returns the repository paths of the relevant files of the commit rev and
+// their blob IDs, skipping what listProjectFiles skips in the working tree
+func (d *Differ) listProjectFilesAt(rev string) ([]string, map[string]string, error) {
+	out, err := runGitCommandRaw(d.ctx, d.workingDir, "ls-tree", "-r", "-z", "--full-tree", rev)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the files of %s: %w", rev, err)
+	}
+
+	var paths []string
+	blobs := make(map[string]string)
+	// Each entry is "<mode> <type> <oid>\t<path>"
+	for _, entry := range strings.Split(out, "\x00") {
+		meta, relPath, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		if strings.HasPrefix(path.Base(relPath), ".") || shouldIgnoreFile(relPath) || !isRelevantFile(relPath) {
+			continue
 		}
+		paths = append(paths, relPath)
+		blobs[relPath] = fields[2]
+	}
+	return paths, blobs, nil
+}
 
+// getAllProjectFilesAt reads the relevant files of the commit rev in one batch
+func (d *Differ) getAllProjectFilesAt(rev string) ([]types.FileChange, error) {
+	paths, blobs, err := d.listProjectFilesAt(rev)
+	if err != nil {
+		return nil, err
+	}
+	oids := make([]string, 0, len(paths))
+	for _, relPath := range paths {
+		oids = append(oids, blobs[relPath])
+	}
+	if err := d.prefetchBlobs(oids); err != nil {
+		return nil, err
+	}
+
+	progress := output.NewProgress(d.out, "Reading project files", len(oids), "files")
+	defer progress.Done()
+	contents, err := d.readBlobs(oids, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	projectFiles := make([]types.FileChange, 0, len(paths))
+	for _, relPath := range paths {
 		projectFiles = append(projectFiles, types.FileChange{
 			Path:      relPath,
-			Content:   content,
+			Content:   contents[blobs[relPath]],
 			IsChanged: false,
 		})
+	}
+	return projectFiles, nil
+}
 
-		return nil
-	})
-
-	return projectFiles, err
+// projectFileReader returns a reader of project files as of the commit rev, or of the working
+// tree when rev is empty
+func (d *Differ) projectFileReader(rev string) func(relPath string) (string, error) {
+	if rev == "" {
+		return d.readProjectFile
+	}
+	return func(relPath string) (string, error) {
+		return runGitCommandRaw(d.ctx, d.workingDir, "cat-file", "blob", rev+":"+relPath)
+	}
 }
 
 // readFileFromDisk reads file content from disk
@@ -333,11 +652,16 @@ func isValidFilePath(filePath string) bool {
 	return !strings.Contains(filePath, "../") && !strings.Contains(filePath, "..\\")
 }
 
-// isGitRenameFormat checks if a file path represents a valid Git rename format
+// isGitRenameFormat checks if a file path is in one of Git's rename formats: "old => new", or
+// "dir/{old => new}/file" when the paths share a prefix or suffix
 func isGitRenameFormat(filePath string) bool {
-	if !strings.Contains(filePath, "{") || !strings.Contains(filePath, " => ") || !strings.Contains(filePath, "}") {
+	arrowPos := strings.Index(filePath, " => ")
+	if arrowPos == -1 {
 		return false
 	}
+	if !strings.Contains(filePath, "{") && !strings.Contains(filePath, "}") {
+		return true
+	}
 
 	openBraces := strings.Count(filePath, "{")
 	closeBraces := strings.Count(filePath, "}")
@@ -347,21 +671,25 @@ func isGitRenameFormat(filePath string) bool {
 
 	braceStart := strings.Index(filePath, "{")
 	braceEnd := strings.LastIndex(filePath, "}")
-	arrowPos := strings.Index(filePath, " => ")
 
-	return braceStart != -1 && braceEnd != -1 && arrowPos != -1 && arrowPos > braceStart && arrowPos < braceEnd
+	return braceStart != -1 && braceEnd != -1 && arrowPos > braceStart && arrowPos < braceEnd
 }
 
-// parseGitRenameFormat parses Git's {oldname => newname} rename format
+// parseGitRenameFormat parses Git's "old => new" and "dir/{old => new}/file" rename formats
 func parseGitRenameFormat(filePath string) (oldPath, newPath string) {
 	braceStart := strings.Index(filePath, "{")
-	braceEnd := strings.Index(filePath, "}")
+	braceEnd := strings.LastIndex(filePath, "}")
 
 	if braceStart == -1 || braceEnd == -1 {
-		return filePath, filePath
+		parts := strings.Split(filePath, " => ")
+		if len(parts) != 2 {
+			return filePath, filePath
+		}
+		return parts[0], parts[1]
 	}
 
-	basePath := filePath[:braceStart]
+	prefix := filePath[:braceStart]
+	suffix := filePath[braceEnd+1:]
 	renameContent := filePath[braceStart+1 : braceEnd]
 
 	parts := strings.Split(renameContent, " => ")
@@ -369,8 +697,8 @@ func parseGitRenameFormat(filePath string) (oldPath, newPath string) {
 		return filePath, filePath
 	}
 
-	oldName := strings.TrimSpace(parts[0])
-	newName := strings.TrimSpace(parts[1])
-
-	return basePath + oldName, basePath + newName
+	// An empty side, as in "src/{ => lib}/a.ts", leaves a doubled slash
+	oldPath = strings.Replace(prefix+parts[0]+suffix, "//", "/", 1)
+	newPath = strings.Replace(prefix+parts[1]+suffix, "//", "/", 1)
+	return oldPath, newPath
 }
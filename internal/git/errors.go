@@ -0,0 +1,17 @@
+package git
+
+import "errors"
+
+// Failure causes callers can test for with errors.Is
+var (
+	// ErrDirtyWorktree is returned when an operation needs a checkout without local changes
+	ErrDirtyWorktree = errors.New("working tree has local changes")
+	// ErrBranchExists is returned when a branch to be created already exists locally or on the remote
+	ErrBranchExists = errors.New("branch already exists")
+	// ErrNoChanges is returned when the source branch has nothing to split against the target
+	ErrNoChanges = errors.New("no changes to split")
+	// ErrPartitionCheckFailed is returned when a partition check command fails under the abort policy
+	ErrPartitionCheckFailed = errors.New("partition check failed")
+	// ErrShallowHistory is returned when a shallow clone lacks the history back to the merge-base
+	ErrShallowHistory = errors.New("shallow clone does not reach the merge-base")
+)
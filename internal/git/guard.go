@@ -0,0 +1,38 @@
+package git
+
+import "strings"
+
+// ForcePushCheck is called before a branch on the push remote is rewritten from oldTip to
+// newTip. newTip is empty when the branch is deleted and oldTip when its remote tip is
+// unknown. An error cancels the push.
+type ForcePushCheck func(branch, oldTip, newTip string) error
+
+// SetForcePushCheck runs check before every force-push and remote restore of a branch; nil
+// removes it
+func (c *Client) SetForcePushCheck(check ForcePushCheck) {
+	c.guard, c.brancher.guard = check, check
+}
+
+// checkForcePush runs the force-push check, if any
+func checkForcePush(check ForcePushCheck, branch, oldTip, newTip string) error {
+	if check == nil {
+		return nil
+	}
+	return check(branch, oldTip, newTip)
+}
+
+// ChangedPaths returns the files that differ between two commits, including files added to
+// or removed from the later one
+func (c *Client) ChangedPaths(from, to string) ([]string, error) {
+	output, err := runGitCommand(c.ctx, c.workingDir, "diff", "--name-only", "--no-renames", from, to)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, line := range strings.Split(output, "\n") {
+		if line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, nil
+}
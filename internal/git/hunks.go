@@ -0,0 +1,178 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// ApplyHunks rebuilds a file from its base content and a subset of its zero-context diff hunks.
+// Hunks are positioned against the base file, so any subset can be applied independently.
+func ApplyHunks(base string, hunks []types.DiffHunk, selected map[int]bool) string {
+	var lines []string
+	if base != "" {
+		lines = strings.Split(strings.TrimSuffix(base, "\n"), "\n")
+	}
+	trailingNewline := base == "" || strings.HasSuffix(base, "\n")
+
+	var out []string
+	next := 0 // index of the next base line to copy
+	for i, hunk := range hunks {
+		if !selected[i] {
+			continue
+		}
+
+		// With nothing removed, OldStart is the line the insertion follows
+		start := hunk.OldStart - 1
+		if hunk.OldLines == 0 {
+			start = hunk.OldStart
+		}
+		if start > len(lines) {
+			start = len(lines)
+		}
+		if start > next {
+			out = append(out, lines[next:start]...)
+		}
+		out = append(out, hunk.Added...)
+		next = start + hunk.OldLines
+
+		if hunk.AddedNoEOL {
+			trailingNewline = false
+		} else if hunk.RemovedNoEOL {
+			trailingNewline = true
+		}
+	}
+	if next < len(lines) {
+		out = append(out, lines[next:]...)
+	}
+
+	if len(out) == 0 {
+		return ""
+	}
+	content := strings.Join(out, "\n")
+	if trailingNewline {
+		content += "\n"
+	}
+	return content
+}
+
+// applySplitFiles stages the hunk-level share of split files for a partition branch. Each file
+// gets every hunk assigned to a partition already on this branch, so stacked branches
+// accumulate hunks until the last one matches the source file.
+func (b *Brancher) applySplitFiles(index *indexBuilder, partition *types.Partition, plan *types.PartitionPlan, sourceBranch string) error {
+	if len(partition.SplitFiles) == 0 {
+		return nil
+	}
+
+	onBranch := partitionsOnBranch(partition, plan)
+	for _, selection := range partition.SplitFiles {
+		selected := make(map[int]bool)
+		for _, p := range plan.Partitions {
+			if !onBranch[p.ID] {
+				continue
+			}
+			for _, other := range p.SplitFiles {
+				if other.Path == selection.Path {
+					for _, hunk := range other.Hunks {
+						selected[hunk] = true
+					}
+				}
+			}
+		}
+
+		base, err := runGitCommandRaw(b.ctx, b.workingDir, "show", plan.Metadata.MergeBase+":"+selection.Path)
+		if err != nil {
+			base = "" // the file is new on the source branch
+		}
+
+		// The file gets its source mode with its first hunks, so a new executable bit is not lost
+		mode := treeMode(b.ctx, b.workingDir, sourceBranch, selection.Path)
+		if mode == "" {
+			mode = index.fileMode(selection.Path)
+		}
+		content := ApplyHunks(base, b.lineDiffs[selection.Path].Hunks, selected)
+		if err := index.writeFile(selection.Path, content, mode); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", selection.Path, err)
+		}
+		fmt.Fprintf(b.out, "✂️  Applied %d of %d hunks of %s\n", len(selected), selection.Total, selection.Path)
+	}
+	return nil
+}
+
+// loadLineDiffs loads the source diff hunks once, before any branch is built, when the plan
+// splits files by hunk
+func (b *Brancher) loadLineDiffs(plan *types.PartitionPlan, sourceBranch string) error {
+	b.lineDiffs = nil
+	splitsFiles := false
+	for _, partition := range plan.Partitions {
+		splitsFiles = splitsFiles || len(partition.SplitFiles) > 0
+	}
+	if !splitsFiles {
+		return nil
+	}
+
+	base := plan.Metadata.MergeBase
+	if base == "" {
+		return fmt.Errorf("plan has no merge-base to split files against")
+	}
+	diffs, err := (&Differ{ctx: b.ctx, out: b.out, workingDir: b.workingDir}).GetLineDiffs(base, sourceBranch)
+	if err != nil {
+		return fmt.Errorf("failed to load hunks of split files: %w", err)
+	}
+	b.lineDiffs = diffs
+	return nil
+}
+
+// partitionsOnBranch returns the IDs of partitions whose changes are present on a partition's branch
+func partitionsOnBranch(partition *types.Partition, plan *types.PartitionPlan) map[int]bool {
+	included := map[int]bool{partition.ID: true}
+
+	switch plan.Metadata.Topology {
+	case types.TopologyIndependent:
+	case types.TopologyDAG:
+		byID := make(map[int]types.Partition)
+		for _, p := range plan.Partitions {
+			byID[p.ID] = p
+		}
+		pending := append([]int(nil), partition.Dependencies...)
+		for len(pending) > 0 {
+			id := pending[0]
+			pending = pending[1:]
+			if !included[id] {
+				included[id] = true
+				pending = append(pending, byID[id].Dependencies...)
+			}
+		}
+	default:
+		for _, p := range plan.Partitions {
+			included[p.ID] = true
+			if p.ID == partition.ID {
+				break
+			}
+		}
+	}
+	return included
+}
+
+// withoutSplitFiles returns a copy of the partition without files that are applied by hunk
+func withoutSplitFiles(partition types.Partition, plan *types.PartitionPlan) types.Partition {
+	split := make(map[string]bool)
+	for _, p := range plan.Partitions {
+		for _, selection := range p.SplitFiles {
+			split[selection.Path] = true
+		}
+	}
+	if len(split) == 0 {
+		return partition
+	}
+
+	var files []types.FileChange
+	for _, file := range partition.Files {
+		if !split[file.Path] {
+			files = append(files, file)
+		}
+	}
+	partition.Files = files
+	return partition
+}
@@ -0,0 +1,54 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MergeBranch merges branch into the checked-out HEAD, as a merge commit or squashed into a
+// single commit. On conflicts the merge is aborted and the conflicting files are reported.
+func (c *Client) MergeBranch(branch string, squash bool) error {
+	args := []string{"merge", "--quiet", "--no-ff", "--no-edit", branch}
+	if squash {
+		args = []string{"merge", "--quiet", "--squash", branch}
+	}
+
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", hookArgs(c.noVerify, args...)...); err != nil {
+		conflicts, _ := runGitCommand(c.ctx, c.workingDir, "diff", "--name-only", "--diff-filter=U")
+		_ = runGitCommandQuiet(context.WithoutCancel(c.ctx), c.workingDir, "reset", "--quiet", "--merge")
+		if conflicts != "" {
+			return fmt.Errorf("merging %s conflicts in: %s", branch, strings.ReplaceAll(conflicts, "\n", ", "))
+		}
+		return fmt.Errorf("failed to merge %s: %w", branch, err)
+	}
+
+	if squash {
+		// Nothing staged means the branch was already merged
+		if runGitCommandQuiet(c.ctx, c.workingDir, "diff", "--cached", "--quiet") == nil {
+			return nil
+		}
+		subject, _ := runGitCommand(c.ctx, c.workingDir, "log", "-1", "--format=%s", branch)
+		message := fmt.Sprintf("%s (squashed from %s)", subject, branch)
+		if err := runGitCommandWithInput(c.ctx, c.workingDir, "", hookArgs(c.noVerify, "commit", "--quiet", "-m", message)...); err != nil {
+			return fmt.Errorf("failed to commit squashed %s: %w", branch, err)
+		}
+	}
+	return nil
+}
+
+// ResetHard moves the checked-out branch and working tree to rev
+func (c *Client) ResetHard(rev string) error {
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", "reset", "--quiet", "--hard", rev); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w", rev, err)
+	}
+	return nil
+}
+
+// PushBranch pushes a branch to the push remote
+func (c *Client) PushBranch(branch string) error {
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", hookArgs(c.noVerify, "push", "--quiet", c.remote, branch)...); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// DefaultParallelism is how many partition branches are built and pushed at once when the
+// configuration does not say
+const DefaultParallelism = 4
+
+// branchRun schedules the partitions of one CreateBranches call: each partition starts once
+// the branches it is based on exist, and at most parallelism partitions build or push at once
+type branchRun struct {
+	brancher *Brancher
+	plan     *types.PartitionPlan
+	cfg      *types.Config
+	slots    chan struct{}
+	stop     context.Context // Done once a partition failed or the caller cancelled
+	cancel   context.CancelFunc
+
+	mu            sync.Mutex
+	ready         map[int]chan struct{}   // Closed once the partition's branch points at its final commit, by partition ID
+	created       []string                // Branches created so far, deleted on rollback
+	pushed        []string                // Branches pushed so far, deleted on rollback
+	summaries     []string                // Update summary line per partition, by plan position
+	finished      int                     // Partitions whose branch is done, for progress lines
+	checkFailures []PartitionCheckFailure // Partition checks that failed under the continue policy
+	pushGate      chan struct{}           // Closed once sibling conflicts are predicted, nil without siblings
+	conflicts     []PartitionConflict     // Sibling partitions predicted to conflict
+	conflictErr   error                   // Why the conflicts could not be predicted
+	err           error                   // First failure; later ones are caused by its cancellation
+	panicked      any                     // Panic of a worker, re-raised after the rollback
+}
+
+// newBranchRun prepares the schedule of a plan
+func newBranchRun(b *Brancher, plan *types.PartitionPlan, cfg *types.Config) *branchRun {
+	parallelism := cfg.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	run := &branchRun{
+		brancher:  b,
+		plan:      plan,
+		cfg:       cfg,
+		slots:     make(chan struct{}, parallelism),
+		ready:     make(map[int]chan struct{}),
+		summaries: make([]string, len(plan.Partitions)),
+	}
+	for _, partition := range plan.Partitions {
+		run.ready[partition.ID] = make(chan struct{})
+	}
+	if hasSiblings(plan.Metadata.Topology) && len(plan.Partitions) > 1 {
+		run.pushGate = make(chan struct{})
+	}
+	return run
+}
+
+// execute creates every partition branch and returns the first failure. Once a partition
+// fails the others stop at their next step; what they created is left in created and pushed
+// for the rollback.
+func (r *branchRun) execute(sourceBranch string) error {
+	b := r.brancher
+
+	// The first failure keeps the other workers from starting new steps, but git commands
+	// already running finish, so a branch that reaches the remote is always recorded for the
+	// rollback. Progress lines are kept whole.
+	r.stop, r.cancel = context.WithCancel(b.ctx)
+	defer r.cancel()
+	defer func(out io.Writer) { b.out = out }(b.out)
+	b.out = &syncWriter{w: b.out}
+
+	var wg sync.WaitGroup
+	if r.pushGate != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.predictConflicts()
+		}()
+	}
+	for i, partition := range r.plan.Partitions {
+		wg.Add(1)
+		go func(i int, partition types.Partition) {
+			defer wg.Done()
+			defer r.built(partition.ID)
+			defer func() {
+				if p := recover(); p != nil {
+					r.mu.Lock()
+					r.panicked = p
+					r.mu.Unlock()
+					r.fail(fmt.Errorf("panic while creating branch %s: %v", partition.BranchName, p))
+				}
+			}()
+
+			for _, id := range r.basedOn(i, partition) {
+				ready, ok := r.ready[id]
+				if !ok {
+					continue // determineBaseBranch reports partitions missing from the plan
+				}
+				select {
+				case <-ready:
+				case <-r.stop.Done():
+				}
+			}
+			if err := r.stop.Err(); err != nil {
+				r.fail(err)
+				return
+			}
+			if err := b.createPartitionBranch(r, i, partition, sourceBranch); err != nil {
+				r.fail(err)
+			}
+		}(i, partition)
+	}
+	wg.Wait()
+
+	return r.err
+}
+
+// basedOn returns the IDs of the partitions whose branches a partition is created from or
+// merges, which must be in place before it starts
+func (r *branchRun) basedOn(index int, partition types.Partition) []int {
+	switch r.plan.Metadata.Topology {
+	case types.TopologyIndependent:
+		return nil
+	case types.TopologyDAG:
+		return partition.Dependencies
+	default:
+		if index == 0 {
+			return nil
+		}
+		return []int{r.plan.Partitions[index-1].ID}
+	}
+}
+
+// predictConflicts waits until every partition branch is built, test-merges the siblings and
+// then lets the pushes start. A prediction that cannot run only warns.
+func (r *branchRun) predictConflicts() {
+	defer close(r.pushGate)
+	for _, ready := range r.ready {
+		<-ready
+	}
+	if r.stop.Err() != nil {
+		return
+	}
+
+	fmt.Fprintln(r.brancher.out, "🔮 Predicting merge conflicts between sibling partitions...")
+	conflicts, err := r.brancher.predictConflicts(r.plan)
+	if err != nil {
+		fmt.Fprintf(r.brancher.out, "⚠️  Warning: Could not predict conflicts: %v\n", err)
+	} else {
+		r.brancher.reportConflicts(conflicts)
+	}
+	r.mu.Lock()
+	r.conflicts, r.conflictErr = conflicts, err
+	r.mu.Unlock()
+}
+
+// waitToPush holds a push back until sibling conflicts have been predicted
+func (r *branchRun) waitToPush() error {
+	if r.pushGate == nil {
+		return nil
+	}
+	select {
+	case <-r.pushGate:
+		return r.stop.Err()
+	case <-r.stop.Done():
+		return r.stop.Err()
+	}
+}
+
+// built marks a partition's branch as in place, releasing the partitions based on it
+func (r *branchRun) built(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	select {
+	case <-r.ready[id]:
+	default:
+		close(r.ready[id])
+	}
+}
+
+// finish counts a partition whose branch is done and returns the progress to print after it,
+// e.g. " (3/8 done)"
+func (r *branchRun) finish() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finished++
+	return fmt.Sprintf(" (%d/%d done)", r.finished, len(r.plan.Partitions))
+}
+
+// acquire waits for a free slot, giving up once the run is stopped
+func (r *branchRun) acquire() error {
+	if err := r.stop.Err(); err != nil {
+		return err
+	}
+	select {
+	case r.slots <- struct{}{}:
+		return nil
+	case <-r.stop.Done():
+		return r.stop.Err()
+	}
+}
+
+// release frees a slot taken by acquire
+func (r *branchRun) release() {
+	<-r.slots
+}
+
+// record appends a branch to one of the run's rollback lists
+func (r *branchRun) record(list *[]string, branchName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*list = append(*list, branchName)
+}
+
+// recordPushed remembers the commit a previous branch was force-pushed to
+func (r *branchRun) recordPushed(branchName, commit string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.brancher.pushed[branchName] = commit
+}
+
+// recordCheckFailures marks the partition at a plan position with the checks that failed on it
+func (r *branchRun) recordCheckFailures(index int, failures []PartitionCheckFailure) {
+	if len(failures) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, failure := range failures {
+		r.plan.Partitions[index].FailedChecks = append(r.plan.Partitions[index].FailedChecks, failure.Command)
+	}
+	r.checkFailures = append(r.checkFailures, failures...)
+}
+
+// report passes a pushed branch to the brancher's onPushed callback, one partition at a time
+func (r *branchRun) report(partitionID int, branchName string, updated bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.brancher.reportPushed(partitionID, branchName, updated)
+}
+
+// fail keeps the first failure and stops the other workers
+func (r *branchRun) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+		r.cancel()
+	}
+}
+
+// syncWriter serializes writes so concurrent workers' progress lines do not interleave
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
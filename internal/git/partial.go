@@ -0,0 +1,147 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"pr-splitter-cli/internal/output"
+)
+
+// nullOID is the object ID git diff --raw shows for the missing side of an added or deleted file
+const nullOID = "0000000000000000000000000000000000000000"
+
+// cloneInfo is what a clone leaves out: history before its shallow boundary, or the blobs a
+// partial clone fetches from its promisor remote when they are first read
+type cloneInfo struct {
+	shallow  bool
+	promisor string // Remote a partial clone fetches missing objects from, empty for a full clone
+}
+
+// inspectClone reports whether the repository in dir is a shallow or a partial clone
+func inspectClone(ctx context.Context, dir string) cloneInfo {
+	var info cloneInfo
+	if out, err := runGitCommand(ctx, dir, "rev-parse", "--is-shallow-repository"); err == nil {
+		info.shallow = out == "true"
+	}
+
+	if out, err := runGitCommand(ctx, dir, "config", "--get-regexp", `^remote\..*\.promisor$`); err == nil {
+		for _, line := range strings.Split(out, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			if value == "true" {
+				info.promisor = strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".promisor")
+				break
+			}
+		}
+	}
+	// Clones made by older git versions name the promisor remote in extensions.partialClone
+	if info.promisor == "" {
+		if out, err := runGitCommand(ctx, dir, "config", "--get", "extensions.partialClone"); err == nil {
+			info.promisor = out
+		}
+	}
+	return info
+}
+
+// clone returns what the clone leaves out, inspecting the repository on first use
+func (d *Differ) clone() cloneInfo {
+	if d.cloneInfo == nil {
+		info := inspectClone(d.ctx, d.workingDir)
+		d.cloneInfo = &info
+	}
+	return *d.cloneInfo
+}
+
+// shallowHistoryError explains that the merge-base of two branches lies beyond the history a
+// shallow clone fetched
+func shallowHistoryError(target, source string) error {
+	return fmt.Errorf("%w of %s and %s; fetch more history with 'git fetch --deepen=<commits>' or 'git fetch --unshallow' and retry",
+		ErrShallowHistory, target, source)
+}
+
+// prefetchBlobs fetches the blobs a partial clone does not have yet in a single request, so
+// reading the changed files does not fetch them one at a time. Full clones skip it.
+func (d *Differ) prefetchBlobs(oids []string) error {
+	remote := d.clone().promisor
+	if remote == "" || len(oids) == 0 {
+		return nil
+	}
+	input := strings.Join(oids, "\n") + "\n"
+
+	// With lazy fetching off the check fails on the first missing blob instead of fetching it
+	check := gitCommand(d.ctx, d.workingDir, []string{"cat-file", "--batch-check"})
+	check.Env = append(check.Environ(), "GIT_NO_LAZY_FETCH=1")
+	check.Stdin = strings.NewReader(input)
+	if Run(check) == nil {
+		return nil
+	}
+
+	// The arguments git itself uses when it fetches missing objects of a partial clone
+	fmt.Fprintf(d.out, "📥 Partial clone: fetching %d blobs from %s in one batch...\n", len(oids), remote)
+	err := runGitCommandWithInput(d.ctx, d.workingDir, input,
+		"-c", "fetch.negotiationAlgorithm=noop", "fetch", remote,
+		"--no-tags", "--no-write-fetch-head", "--recurse-submodules=no", "--filter=blob:none", "--stdin")
+	if err != nil {
+		return fmt.Errorf("failed to fetch the changed files' blobs from %s: %w", remote, err)
+	}
+	return nil
+}
+
+// readBlobs reads blobs with a single git cat-file --batch and returns their content keyed by
+// object ID, counting each blob read on progress. Missing blobs are left out.
+func (d *Differ) readBlobs(oids []string, progress *output.Progress) (map[string]string, error) {
+	blobs := make(map[string]string, len(oids))
+	if len(oids) == 0 {
+		return blobs, nil
+	}
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	cmd := gitCommand(d.ctx, d.workingDir, []string{"cat-file", "--batch"})
+	cmd.Stdin = strings.NewReader(strings.Join(oids, "\n") + "\n")
+	cmd.Stdout = writer
+	done := make(chan error, 1)
+	go func() {
+		err := Run(cmd)
+		writer.CloseWithError(err)
+		done <- err
+	}()
+
+	// Each blob is "<oid> blob <size>\n<content>\n", or "<oid> missing\n"
+	out := bufio.NewReader(reader)
+	for {
+		header, err := out.ReadString('\n')
+		if err == io.EOF && header == "" {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read blobs: %w", err)
+		}
+
+		fields := strings.Fields(header)
+		if len(fields) == 2 && fields[1] == "missing" {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected git cat-file output: %q", strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected git cat-file output: %q", strings.TrimSpace(header))
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(out, content); err != nil {
+			return nil, fmt.Errorf("failed to read blob %s: %w", fields[0], err)
+		}
+		blobs[fields[0]] = string(content[:size])
+		progress.Add(1)
+	}
+
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("git cat-file failed: %w", err)
+	}
+	return blobs, nil
+}
@@ -0,0 +1,252 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// indexBuilder stages a tree in a private index file, so commits can be built with plumbing
+// commands without a checkout
+type indexBuilder struct {
+	ctx     context.Context
+	dir     string
+	tempDir string
+	path    string // GIT_INDEX_FILE
+}
+
+// newIndexBuilder creates a private index holding the tree of rev
+func newIndexBuilder(ctx context.Context, dir, rev string) (*indexBuilder, error) {
+	tempDir, err := os.MkdirTemp("", "pr-split-index-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	index := &indexBuilder{ctx: ctx, dir: dir, tempDir: tempDir, path: filepath.Join(tempDir, "index")}
+	if _, err := index.run("", "read-tree", rev); err != nil {
+		index.remove()
+		return nil, fmt.Errorf("failed to read tree of %s: %w", rev, err)
+	}
+	return index, nil
+}
+
+// remove deletes the index file
+func (i *indexBuilder) remove() {
+	os.RemoveAll(i.tempDir)
+}
+
+// run executes a git command against the private index with the given stdin and returns its
+// trimmed output
+func (i *indexBuilder) run(input string, args ...string) (string, error) {
+	cmd := gitCommand(i.ctx, i.dir, args)
+	cmd.Env = append(cmd.Environ(), "GIT_INDEX_FILE="+i.path)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	audit := beginAudit(i.ctx, i.dir, args)
+	err := Run(cmd)
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+	}
+	audit.finish(err)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// readTree replaces the index with the tree of rev
+func (i *indexBuilder) readTree(rev string) error {
+	_, err := i.run("", "read-tree", rev)
+	return err
+}
+
+// copyPaths stages paths as they are in rev, keeping their modes
+func (i *indexBuilder) copyPaths(rev string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	entries, err := i.run("", append([]string{"ls-tree", "-r", "-z", "--full-tree", rev, "--"}, paths...)...)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", rev, err)
+	}
+	if _, err := i.run(entries, "update-index", "-z", "--index-info"); err != nil {
+		return fmt.Errorf("failed to stage files from %s: %w", rev, err)
+	}
+	return nil
+}
+
+// renamePaths stages renames, from old to new path, in one index update: each old path is
+// removed and its new path staged as it is in rev, so edits made along with the rename come too.
+// Nothing is staged when an old path is not in the index.
+func (i *indexBuilder) renamePaths(rev string, renames map[string]string) error {
+	if len(renames) == 0 {
+		return nil
+	}
+	var oldPaths, newPaths []string
+	for oldPath, newPath := range renames {
+		oldPaths = append(oldPaths, oldPath)
+		newPaths = append(newPaths, newPath)
+	}
+
+	staged, err := i.run("", append([]string{"ls-files", "-z", "--full-name", "--"}, oldPaths...)...)
+	if err != nil {
+		return fmt.Errorf("failed to list index: %w", err)
+	}
+	present := make(map[string]bool)
+	for _, path := range strings.Split(staged, "\x00") {
+		present[path] = true
+	}
+	for oldPath, newPath := range renames {
+		if !present[oldPath] {
+			return fmt.Errorf("cannot rename %s to %s: %s is not on the base of this partition", oldPath, newPath, oldPath)
+		}
+	}
+
+	entries, err := i.run("", append([]string{"ls-tree", "-r", "-z", "--full-tree", rev, "--"}, newPaths...)...)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", rev, err)
+	}
+
+	// Mode 0 removes a path in --index-info input
+	var input strings.Builder
+	for _, oldPath := range oldPaths {
+		input.WriteString("0 0000000000000000000000000000000000000000\t" + oldPath + "\x00")
+	}
+	input.WriteString(entries)
+	if _, err := i.run(input.String(), "update-index", "-z", "--index-info"); err != nil {
+		return fmt.Errorf("failed to stage renames from %s: %w", rev, err)
+	}
+	return nil
+}
+
+// removePaths unstages paths; paths that are not in the index are ignored
+func (i *indexBuilder) removePaths(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	_, err := i.run(strings.Join(paths, "\x00"), "update-index", "-z", "--force-remove", "--stdin")
+	return err
+}
+
+// writeFile stores content as a blob and stages it at path
+func (i *indexBuilder) writeFile(path, content, mode string) error {
+	blob, err := i.run(content, "hash-object", "-w", "--stdin")
+	if err != nil {
+		return fmt.Errorf("failed to store %s: %w", path, err)
+	}
+	_, err = i.run("", "update-index", "--add", "--cacheinfo", mode+","+blob+","+path)
+	return err
+}
+
+// fileMode returns the mode of path in the index, or a regular file mode if it is not there
+func (i *indexBuilder) fileMode(path string) string {
+	entry, err := i.run("", "ls-files", "--stage", "--full-name", "--", path)
+	if err != nil || entry == "" {
+		return "100644"
+	}
+	return strings.Fields(entry)[0]
+}
+
+// treeMode returns the mode of path in rev, or an empty string if it is not there
+func treeMode(ctx context.Context, dir, rev, path string) string {
+	entry, err := runGitCommand(ctx, dir, "ls-tree", "--full-tree", rev, "--", path)
+	if err != nil || entry == "" {
+		return ""
+	}
+	return strings.Fields(entry)[0]
+}
+
+// applyPatch applies a patch to the index, merging with three-way fallback like 'git apply --3way'
+func (i *indexBuilder) applyPatch(patch string) error {
+	_, err := i.run(patch, "apply", "--cached", "--3way", "--whitespace=nowarn")
+	return err
+}
+
+// writeTree writes the index as a tree and returns its ID
+func (i *indexBuilder) writeTree() (string, error) {
+	return i.run("", "write-tree")
+}
+
+// commitOptions controls how commitTree creates a commit
+type commitOptions struct {
+	sign        bool   // Sign with the configured GPG, SSH or X.509 key (gpg.format, user.signingKey)
+	authorName  string // Author of the commit, the committer when empty
+	authorEmail string
+}
+
+// commitTree creates a commit of tree with the given parents and returns its ID
+func commitTree(ctx context.Context, dir, tree, message string, opts commitOptions, parents ...string) (string, error) {
+	args := []string{"commit-tree", tree}
+	if opts.sign {
+		args = append(args, "-S")
+	}
+	for _, parent := range parents {
+		args = append(args, "-p", parent)
+	}
+	args = append(args, "-F", "-")
+
+	cmd := gitCommand(ctx, dir, args)
+	if opts.authorEmail != "" {
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME="+opts.authorName, "GIT_AUTHOR_EMAIL="+opts.authorEmail)
+	}
+	cmd.Stdin = strings.NewReader(message)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := Run(cmd); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// mergeTrees merges two commits without a checkout and returns the merged tree
+func mergeTrees(ctx context.Context, dir, ours, theirs string) (string, error) {
+	output, err := runGitCommand(ctx, dir, "merge-tree", "--write-tree", "--no-messages", ours, theirs)
+	if err != nil {
+		return "", fmt.Errorf("merge has conflicts or failed (git 2.38 or newer is required): %w", err)
+	}
+	return strings.SplitN(output, "\n", 2)[0], nil
+}
+
+// conflictingFiles test-merges two commits without a checkout and returns the files that would
+// conflict, none when they merge cleanly
+func conflictingFiles(ctx context.Context, dir, ours, theirs string) ([]string, error) {
+	cmd := gitCommand(ctx, dir, []string{"merge-tree", "--write-tree", "--name-only", "--no-messages", ours, theirs})
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := Run(cmd)
+	if err == nil {
+		return nil, nil
+	}
+
+	// Exit status 1 with a tree means the merge has conflicts; the tree is followed by the
+	// conflicted files. Without one the commits could not be merged at all.
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 || strings.TrimSpace(stdout.String()) == "" {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("failed to test-merge %s and %s (git 2.38 or newer is required): %w", ours, theirs, err)
+	}
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n")[1:] {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
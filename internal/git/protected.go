@@ -0,0 +1,32 @@
+package git
+
+import (
+	"pr-splitter-cli/internal/pathglob"
+)
+
+// DefaultProtectedBranches are long-lived branches that rollbacks never delete
+var DefaultProtectedBranches = []string{"main", "master", "develop", "release/*"}
+
+// ProtectedBranches holds the branch names and globs that rollbacks refuse to delete
+type ProtectedBranches []string
+
+// NewProtectedBranches protects the defaults, the extra names or globs and the target branch
+func NewProtectedBranches(target string, extra []string) ProtectedBranches {
+	protected := append(ProtectedBranches{}, DefaultProtectedBranches...)
+	protected = append(protected, extra...)
+	if target != "" {
+		protected = append(protected, target)
+	}
+	return protected
+}
+
+// Protects reports whether a branch matches a protected name or glob. Globs match the whole
+// branch name, so "main" does not protect "split/main".
+func (p ProtectedBranches) Protects(branch string) bool {
+	for _, glob := range p {
+		if pathglob.Compile(glob).MatchString(branch) {
+			return true
+		}
+	}
+	return false
+}
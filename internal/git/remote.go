@@ -0,0 +1,203 @@
+package git
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RunTrailerKey is the commit trailer that identifies which split run created a branch
+const RunTrailerKey = "Pr-Split-Run"
+
+// DefaultRemote is the remote partition branches are pushed to unless configured otherwise
+const DefaultRemote = "origin"
+
+// RunIDEnvVar exposes the current run id to git hooks and validation commands
+const RunIDEnvVar = "PR_SPLIT_RUN_ID"
+
+// BranchOwner describes who committed the tip commit of a branch. Partition commits keep the
+// original author, so the committer is the person who ran the split.
+type BranchOwner struct {
+	Branch         string
+	Commit         string
+	CommitterName  string
+	CommitterEmail string
+	CommittedAt    time.Time
+	RunID          string // Value of the Pr-Split-Run trailer, empty if absent
+}
+
+// NewRunID generates a short random identifier for a split run
+func NewRunID() string {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// runIDKey is the context key of the run id git commands are tagged with
+type runIDKey struct{}
+
+// withRunID returns ctx tagged with a run id, or ctx itself when id is empty
+func withRunID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, runIDKey{}, id)
+}
+
+// runIDFrom returns the run id ctx is tagged with, empty when there is none
+func runIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(runIDKey{}).(string)
+	return id
+}
+
+// runEnv returns the environment of a child process started for ctx: this process's with the
+// run id, if any, exported as RunIDEnvVar
+func runEnv(ctx context.Context) []string {
+	env := os.Environ()
+	if id := runIDFrom(ctx); id != "" {
+		env = append(env, RunIDEnvVar+"="+id)
+	}
+	return env
+}
+
+// RunTrailer formats the commit trailer line for a run id
+func RunTrailer(runID string) string {
+	return fmt.Sprintf("%s: %s", RunTrailerKey, runID)
+}
+
+// FindRemoteOwners lists which of the given branch names already exist on the push remote and who owns them
+func (c *Client) FindRemoteOwners(branchNames []string) ([]BranchOwner, error) {
+	remoteHeads, err := c.listRemoteHeads()
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []string
+	var refspecs []string
+	for _, name := range branchNames {
+		if _, ok := remoteHeads[name]; ok {
+			existing = append(existing, name)
+			refspecs = append(refspecs, "refs/heads/"+name)
+		}
+	}
+
+	if len(existing) == 0 {
+		return nil, nil
+	}
+
+	// Fetch the tip commits so their authors and trailers can be read
+	fetchArgs := append([]string{"fetch", "--quiet", "--no-tags", c.remote}, refspecs...)
+	if err := runGitCommandQuiet(c.ctx, c.workingDir, fetchArgs...); err != nil {
+		return nil, fmt.Errorf("failed to fetch remote branches: %w", err)
+	}
+
+	var owners []BranchOwner
+	for _, name := range existing {
+		owner, err := c.readOwner(remoteHeads[name])
+		if err != nil {
+			return nil, err
+		}
+		owner.Branch = name
+		owners = append(owners, *owner)
+	}
+
+	return owners, nil
+}
+
+// PruneTrackingRefs deletes the <remote>/<branch> tracking refs of branches that no longer
+// exist on the push remote, which would otherwise still be listed as remote branches, and
+// returns the refs it deleted
+func (c *Client) PruneTrackingRefs(branchNames []string) ([]string, error) {
+	remoteHeads, err := c.listRemoteHeads()
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []string
+	for _, name := range branchNames {
+		ref := c.remote + "/" + name
+		if _, ok := remoteHeads[name]; ok {
+			continue
+		}
+		if runGitCommandQuiet(c.ctx, c.workingDir, "rev-parse", "--verify", "--quiet", "refs/remotes/"+ref) != nil {
+			continue
+		}
+		if err := runGitCommandQuiet(c.ctx, c.workingDir, "update-ref", "-d", "refs/remotes/"+ref); err != nil {
+			return pruned, fmt.Errorf("failed to delete tracking ref %s: %w", ref, err)
+		}
+		pruned = append(pruned, ref)
+	}
+	return pruned, nil
+}
+
+// listRemoteHeads maps the branch names on the push remote to their tip commits
+func (c *Client) listRemoteHeads() (map[string]string, error) {
+	output, err := runGitCommand(c.ctx, c.workingDir, "ls-remote", "--heads", c.remote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote branches: %w", err)
+	}
+
+	remoteHeads := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) == 2 {
+			remoteHeads[strings.TrimPrefix(parts[1], "refs/heads/")] = parts[0]
+		}
+	}
+	return remoteHeads, nil
+}
+
+// FetchRemoteBranch updates the tracking ref <remote>/<branch>, e.g. the upstream target of a fork
+func (c *Client) FetchRemoteBranch(remote, branch string) error {
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, remote, branch)
+	if err := runGitCommandQuiet(c.ctx, c.workingDir, "fetch", "--quiet", "--no-tags", remote, refspec); err != nil {
+		return fmt.Errorf("failed to fetch %s from %s: %w", branch, remote, err)
+	}
+	return nil
+}
+
+// GetBranchOwner reads the committer and run id of a branch's tip commit
+func (c *Client) GetBranchOwner(ref string) (*BranchOwner, error) {
+	owner, err := c.readOwner(ref)
+	if err != nil {
+		return nil, err
+	}
+	owner.Branch = ref
+	return owner, nil
+}
+
+// GetUserEmail returns the configured git user email
+func (c *Client) GetUserEmail() string {
+	email, _ := runGitCommand(c.ctx, c.workingDir, "config", "user.email")
+	return email
+}
+
+// readOwner extracts the committer and run trailer of a commit
+func (c *Client) readOwner(rev string) (*BranchOwner, error) {
+	format := fmt.Sprintf("%%H%%n%%cn%%n%%ce%%n%%ct%%n%%(trailers:key=%s,valueonly,separator=%%x2C)", RunTrailerKey)
+	output, err := runGitCommand(c.ctx, c.workingDir, "log", "-1", "--format="+format, rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", rev, err)
+	}
+
+	lines := strings.SplitN(output, "\n", 5)
+	for len(lines) < 5 {
+		lines = append(lines, "")
+	}
+	committed, _ := strconv.ParseInt(lines[3], 10, 64)
+
+	return &BranchOwner{
+		Commit:         lines[0],
+		CommitterName:  lines[1],
+		CommitterEmail: lines[2],
+		CommittedAt:    time.Unix(committed, 0),
+		RunID:          strings.TrimSpace(lines[4]),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// FetchRemote updates the remote-tracking branches of the push remote
+func (c *Client) FetchRemote() error {
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", "fetch", "--quiet", "--no-tags", c.remote); err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", c.remote, err)
+	}
+	return nil
+}
+
+// RemoteRef returns <remote>/<branch> when the push remote's tracking branch exists, otherwise the branch itself
+func (c *Client) RemoteRef(branch string) string {
+	if runGitCommandQuiet(c.ctx, c.workingDir, "rev-parse", "--verify", "--quiet", "refs/remotes/"+c.remote+"/"+branch) == nil {
+		return c.remote + "/" + branch
+	}
+	return branch
+}
+
+// SyncWithRemote fast-forwards a local branch to the push remote when it is behind, so commits
+// pushed by others are not dropped by a rewrite. It fails when local and remote have diverged.
+func (c *Client) SyncWithRemote(branch string) error {
+	remote := c.RemoteRef(branch)
+	if remote == branch {
+		return nil
+	}
+	if runGitCommandQuiet(c.ctx, c.workingDir, "merge-base", "--is-ancestor", remote, branch) == nil {
+		return nil
+	}
+	if runGitCommandQuiet(c.ctx, c.workingDir, "merge-base", "--is-ancestor", branch, remote) != nil {
+		return fmt.Errorf("%s and %s have diverged; push or reset the local branch first", branch, remote)
+	}
+
+	current, _ := c.GetCurrentBranch()
+	if current == branch {
+		return runGitCommandWithInput(c.ctx, c.workingDir, "", "merge", "--quiet", "--ff-only", remote)
+	}
+	return runGitCommandWithInput(c.ctx, c.workingDir, "", "update-ref", "refs/heads/"+branch, remote)
+}
+
+// RebaseOnto replays the commits of branch that are not in upstream onto newBase. On conflicts
+// the rebase is aborted and the branch is left unchanged.
+func (c *Client) RebaseOnto(branch, upstream, newBase string) error {
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", "rebase", "--quiet", "--onto", newBase, upstream, branch); err != nil {
+		_ = runGitCommandQuiet(context.WithoutCancel(c.ctx), c.workingDir, "rebase", "--abort")
+		return fmt.Errorf("failed to rebase %s onto %s: %w", branch, newBase, err)
+	}
+	return nil
+}
+
+// ForcePushWithLease pushes a rewritten branch unless the push remote moved away from the expected commit
+func (c *Client) ForcePushWithLease(branch, expected string) error {
+	tip, err := c.ResolveCommit(branch)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", branch, err)
+	}
+	if err := checkForcePush(c.guard, branch, expected, tip); err != nil {
+		return err
+	}
+
+	lease := "--force-with-lease=" + branch
+	if expected != "" {
+		lease += ":" + expected
+	}
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", hookArgs(c.noVerify, "push", "--quiet", lease, c.remote, branch)...); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package git
+
+import "fmt"
+
+// AheadBehind counts the commits branch has that base lacks (ahead) and the commits base has that branch lacks (behind)
+func (c *Client) AheadBehind(base, branch string) (int, int, error) {
+	output, err := runGitCommand(c.ctx, c.workingDir, "rev-list", "--left-right", "--count", branch+"..."+base)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compare %s with %s: %w", branch, base, err)
+	}
+
+	var ahead, behind int
+	if _, err := fmt.Sscanf(output, "%d %d", &ahead, &behind); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q", output)
+	}
+	return ahead, behind, nil
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BranchFiles lists the files changed by the non-merge commits of branch that are not reachable from any of exclude
+func (c *Client) BranchFiles(branch string, exclude []string) ([]string, error) {
+	args := append([]string{"log", "--no-merges", "--no-renames", "--format=", "--name-only", branch, "--not"}, exclude...)
+	output, err := runGitCommand(c.ctx, c.workingDir, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s: %w", branch, err)
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !seen[line] {
+			seen[line] = true
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// SameContent reports whether a path has the same content (or is absent in both) at two revisions
+func (c *Client) SameContent(revA, revB, path string) bool {
+	blobA, errA := runGitCommand(c.ctx, c.workingDir, "rev-parse", "--verify", "--quiet", revA+":"+path)
+	blobB, errB := runGitCommand(c.ctx, c.workingDir, "rev-parse", "--verify", "--quiet", revB+":"+path)
+	if errA != nil || errB != nil {
+		return (errA != nil) == (errB != nil)
+	}
+	return blobA == blobB
+}
+
+// IsAncestor reports whether commit a is reachable from b
+func (c *Client) IsAncestor(a, b string) bool {
+	return runGitCommandQuiet(c.ctx, c.workingDir, "merge-base", "--is-ancestor", a, b) == nil
+}
+
+// IsMergedInto reports whether every change of branch is already in target: the branch is
+// reachable from target, or each of its commits has a patch-equivalent commit there (as after
+// a rebase or squash merge), which is what git cherry checks
+func (c *Client) IsMergedInto(branch, target string) bool {
+	if c.IsAncestor(branch, target) {
+		return true
+	}
+	output, err := runGitCommand(c.ctx, c.workingDir, "cherry", target, branch)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "+") {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateBranchAt creates a local branch pointing at rev without checking it out
+func (c *Client) CreateBranchAt(branch, rev string) error {
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", "branch", branch, rev); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// CommitPathsFrom checks out branch and commits the state of paths as of source. Paths missing
+// from source are deleted.
+func (c *Client) CommitPathsFrom(branch, source string, paths []string, message string) error {
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", "checkout", "--quiet", branch); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", branch, err)
+	}
+
+	for _, path := range paths {
+		if runGitCommandQuiet(c.ctx, c.workingDir, "cat-file", "-e", source+":"+path) == nil {
+			if err := runGitCommandWithInput(c.ctx, c.workingDir, "", "checkout", source, "--", path); err != nil {
+				return fmt.Errorf("failed to check out %s from %s: %w", path, source, err)
+			}
+		} else if err := runGitCommandWithInput(c.ctx, c.workingDir, "", "rm", "--quiet", "--ignore-unmatch", "--", path); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", path, err)
+		}
+	}
+
+	if runGitCommandQuiet(c.ctx, c.workingDir, "diff", "--cached", "--quiet") == nil {
+		return nil
+	}
+	if err := runGitCommandWithInput(c.ctx, c.workingDir, "", hookArgs(c.noVerify, "commit", "--quiet", "-m", message)...); err != nil {
+		return fmt.Errorf("failed to commit to %s: %w", branch, err)
+	}
+	return nil
+}
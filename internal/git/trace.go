@@ -0,0 +1,86 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	traceMu  sync.Mutex
+	traceOut io.Writer // Receives a line per git command, nil when tracing is off
+)
+
+// SetTrace logs every git command to w: its arguments, working directory, duration and exit
+// code, followed by its stderr when it fails. A nil w turns tracing off.
+func SetTrace(w io.Writer) {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	traceOut = w
+}
+
+// Run runs a git command prepared by the caller, tracing it when tracing is on
+func Run(cmd *exec.Cmd) error {
+	traceMu.Lock()
+	out := traceOut
+	traceMu.Unlock()
+	if out == nil {
+		return cmd.Run()
+	}
+
+	// Keep a copy of stderr for the trace without taking it from the caller
+	var stderr bytes.Buffer
+	if cmd.Stderr == nil {
+		cmd.Stderr = &stderr
+	} else {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, &stderr)
+	}
+
+	start := time.Now()
+	err := cmd.Run()
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		exitCode = -1
+	}
+
+	dir := cmd.Dir
+	if dir == "" {
+		dir = "."
+	}
+	var line strings.Builder
+	fmt.Fprintf(&line, "[git] %s exit=%d dir=%s: %s\n", elapsed, exitCode, dir, strings.Join(cmd.Args, " "))
+	if err != nil {
+		if exitCode == -1 {
+			fmt.Fprintf(&line, "[git]   error: %v\n", err)
+		}
+		for _, stderrLine := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+			if stderrLine != "" {
+				fmt.Fprintf(&line, "[git]   %s\n", stderrLine)
+			}
+		}
+	}
+
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	io.WriteString(out, line.String())
+	return err
+}
+
+// Output runs a git command prepared by the caller and returns its stdout, tracing it when
+// tracing is on
+func Output(cmd *exec.Cmd) ([]byte, error) {
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := Run(cmd)
+	return stdout.Bytes(), err
+}
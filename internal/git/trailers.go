@@ -0,0 +1,66 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// commitTrailers returns the trailer lines of a partition commit: the run id, a Co-authored-by
+// line per author of the partition's source commits other than the operator and the commit's
+// author, and the operator's Signed-off-by
+func (b *Brancher) commitTrailers(commits []sourceCommit, author *sourceCommit, plan *types.PartitionPlan, cfg *types.Config) ([]string, error) {
+	var trailers []string
+	if plan.Metadata.RunID != "" {
+		trailers = append(trailers, RunTrailer(plan.Metadata.RunID))
+	}
+	if !cfg.CoAuthorTrailers && !cfg.SignOff {
+		return trailers, nil
+	}
+
+	operator, err := b.committerIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CoAuthorTrailers {
+		seen := map[string]bool{identEmail(operator): true}
+		if author != nil {
+			seen[strings.ToLower(author.authorEmail)] = true
+		}
+		for _, commit := range commits {
+			if email := strings.ToLower(commit.authorEmail); !seen[email] {
+				seen[email] = true
+				trailers = append(trailers, "Co-authored-by: "+commit.ident())
+			}
+		}
+	}
+	if cfg.SignOff {
+		trailers = append(trailers, "Signed-off-by: "+operator)
+	}
+	return trailers, nil
+}
+
+// committerIdent returns "Name <email>" of the person running the split, as git commit
+// --signoff would
+func (b *Brancher) committerIdent() (string, error) {
+	ident, err := runGitCommand(b.ctx, b.workingDir, "var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		return "", fmt.Errorf("failed to read committer identity (set user.name and user.email): %w", err)
+	}
+	// Drop the timestamp and timezone
+	if end := strings.LastIndex(ident, ">"); end >= 0 {
+		ident = ident[:end+1]
+	}
+	return ident, nil
+}
+
+// identEmail returns the lowercased email of a "Name <email>" identity
+func identEmail(ident string) string {
+	start, end := strings.Index(ident, "<"), strings.LastIndex(ident, ">")
+	if start < 0 || end < start {
+		return strings.ToLower(ident)
+	}
+	return strings.ToLower(ident[start+1 : end])
+}
@@ -0,0 +1,28 @@
+package git
+
+import "fmt"
+
+// SetBranch points a local branch at commit, creating it if needed
+func (c *Client) SetBranch(branch, commit string) error {
+	if err := runGitCommandQuiet(c.ctx, c.workingDir, "branch", "-f", branch, commit); err != nil {
+		return fmt.Errorf("failed to reset %s to %s: %w", branch, commit, err)
+	}
+	return nil
+}
+
+// RestoreRemoteBranch points a branch on the push remote back at previous, unless it moved away from expected.
+// An empty previous deletes the branch.
+func (c *Client) RestoreRemoteBranch(branch, expected, previous string) error {
+	if err := checkForcePush(c.guard, branch, expected, previous); err != nil {
+		return err
+	}
+
+	refspec := previous + ":refs/heads/" + branch
+	if previous == "" {
+		refspec = ":refs/heads/" + branch
+	}
+	if err := runGitCommandQuiet(c.ctx, c.workingDir, hookArgs(c.noVerify, "push", "--force-with-lease="+branch+":"+expected, c.remote, refspec)...); err != nil {
+		return fmt.Errorf("failed to restore %s/%s (did someone push to it?): %w", c.remote, branch, err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// previousBranch records a branch left by an earlier split so an update can be rolled back
+type previousBranch struct {
+	local  string // Local tip, empty when the branch only exists on the remote
+	remote string // Tip on the push remote, empty when it was never pushed
+}
+
+// tip is the commit the branch pointed to before the update
+func (p previousBranch) tip() string {
+	if p.local != "" {
+		return p.local
+	}
+	return p.remote
+}
+
+// findPreviousBranches records the tips of planned branches that already exist locally or on the push remote
+func (b *Brancher) findPreviousBranches(plan *types.PartitionPlan) map[string]previousBranch {
+	previous := make(map[string]previousBranch)
+	for _, partition := range plan.Partitions {
+		local, _ := runGitCommand(b.ctx, b.workingDir, "rev-parse", "--verify", "--quiet", "refs/heads/"+partition.BranchName)
+		remote, _ := runGitCommand(b.ctx, b.workingDir, "rev-parse", "--verify", "--quiet", "refs/remotes/"+b.remote+"/"+partition.BranchName)
+		if local != "" || remote != "" {
+			previous[partition.BranchName] = previousBranch{local: local, remote: remote}
+		}
+	}
+	return previous
+}
+
+// resetBranch points an existing branch at commit. A branch checked out in any worktree is
+// refused, since moving it would leave that checkout out of step with its branch.
+func (b *Brancher) resetBranch(branchName, commit string) error {
+	worktrees, err := runGitCommand(b.ctx, b.workingDir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	for _, line := range strings.Split(worktrees, "\n") {
+		if line == "branch refs/heads/"+branchName {
+			return fmt.Errorf("%s is checked out; switch to another branch first", branchName)
+		}
+	}
+	return runGitCommandQuiet(b.ctx, b.workingDir, "update-ref", "refs/heads/"+branchName, commit)
+}
+
+// describeUpdate summarizes how a rebuilt branch commit differs from its previous tip
+func (b *Brancher) describeUpdate(previous, commit string) (string, bool) {
+	output, err := runGitCommand(b.ctx, b.workingDir, "diff", "--name-status", "--no-renames", previous, commit)
+	if err != nil {
+		return "could not compare with the previous tip", false
+	}
+	if output == "" {
+		return "unchanged", true
+	}
+
+	counts := map[string]int{}
+	for _, line := range strings.Split(output, "\n") {
+		if line != "" {
+			counts[line[:1]]++
+		}
+	}
+	var parts []string
+	for _, status := range []struct{ code, label string }{{"A", "added"}, {"M", "modified"}, {"D", "removed"}} {
+		if counts[status.code] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status.code], status.label))
+		}
+	}
+	return strings.Join(parts, ", ") + " since the previous split", false
+}
+
+// forcePushBranch replaces a branch on the push remote unless it moved away from the expected commit
+func (b *Brancher) forcePushBranch(branchName, expected string) error {
+	return runGitCommandQuiet(b.ctx, b.workingDir, b.pushArgs("--force-with-lease="+branchName+":"+expected, b.remote, branchName)...)
+}
+
+// restorePreviousBranches puts updated branches back where the previous split left them. A
+// remote branch is only restored while it still has the commit this run pushed, so commits
+// others pushed since are never overwritten.
+func (b *Brancher) restorePreviousBranches() {
+	for _, branchName := range b.updated {
+		previous := b.previous[branchName]
+		fmt.Fprintf(b.out, "↩️  Restoring branch: %s\n", branchName)
+
+		if pushed, ok := b.pushed[branchName]; ok && previous.remote != "" && !b.noPush {
+			args := b.pushArgs("--force-with-lease="+branchName+":"+pushed, b.remote, previous.remote+":refs/heads/"+branchName)
+			if err := runGitCommandQuiet(b.ctx, b.workingDir, args...); err != nil {
+				fmt.Fprintf(b.out, "⚠️  Warning: Could not restore remote branch %s: %v\n", branchName, err)
+			}
+		}
+
+		var err error
+		if previous.local != "" {
+			err = runGitCommandQuiet(b.ctx, b.workingDir, "branch", "-f", branchName, previous.local)
+		} else {
+			err = b.DeleteLocalBranch(branchName)
+		}
+		if err != nil {
+			fmt.Fprintf(b.out, "⚠️  Warning: Could not restore local branch %s: %v\n", branchName, err)
+		}
+	}
+}
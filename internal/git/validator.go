@@ -1,19 +1,24 @@
 package git
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 )
 
 // Validator handles all git repository validation
 type Validator struct {
+	ctx        context.Context
+	out        io.Writer // Progress output
 	workingDir string
 }
 
 // NewValidator creates a new git validator
 func NewValidator(workingDir string) *Validator {
-	return &Validator{workingDir: workingDir}
+	return &Validator{ctx: context.Background(), out: os.Stdout, workingDir: workingDir}
 }
 
 // ValidateRepository checks if we're in a valid git repository
@@ -52,7 +57,7 @@ func (v *Validator) ValidateBranches(sourceBranch, targetBranch string) error {
 
 // checkGitRepository verifies we're in a git repository
 func (v *Validator) checkGitRepository() error {
-	if err := runGitCommandQuiet(v.workingDir, "rev-parse", "--git-dir"); err != nil {
+	if err := runGitCommandQuiet(v.ctx, v.workingDir, "rev-parse", "--git-dir"); err != nil {
 		return fmt.Errorf("not in a git repository: %w", err)
 	}
 	return nil
@@ -60,16 +65,16 @@ func (v *Validator) checkGitRepository() error {
 
 // checkWorkingDirectoryClean ensures no uncommitted changes
 func (v *Validator) checkWorkingDirectoryClean() error {
-	if err := runGitCommandQuiet(v.workingDir, "diff", "--quiet"); err != nil {
-		return fmt.Errorf("working directory has uncommitted changes - please commit or stash changes first")
+	if err := runGitCommandQuiet(v.ctx, v.workingDir, "diff", "--quiet"); err != nil {
+		return fmt.Errorf("%w - please commit or stash changes first", ErrDirtyWorktree)
 	}
 	return nil
 }
 
 // checkNoStagedChanges ensures no staged changes exist
 func (v *Validator) checkNoStagedChanges() error {
-	if err := runGitCommandQuiet(v.workingDir, "diff", "--cached", "--quiet"); err != nil {
-		return fmt.Errorf("working directory has staged changes - please commit or reset staged changes first")
+	if err := runGitCommandQuiet(v.ctx, v.workingDir, "diff", "--cached", "--quiet"); err != nil {
+		return fmt.Errorf("%w (staged) - please commit or reset staged changes first", ErrDirtyWorktree)
 	}
 	return nil
 }
@@ -96,7 +101,7 @@ func (v *Validator) validateBranchName(branchName string) error {
 
 // verifyBranch checks if a branch exists
 func (v *Validator) verifyBranch(branch string) error {
-	if err := runGitCommandQuiet(v.workingDir, "rev-parse", "--verify", branch); err != nil {
+	if err := runGitCommandQuiet(v.ctx, v.workingDir, "rev-parse", "--verify", branch); err != nil {
 		return fmt.Errorf("branch does not exist or is not accessible")
 	}
 	return nil
@@ -110,10 +115,10 @@ func (v *Validator) validateBranchDistance(sourceBranch, targetBranch string) er
 	}
 
 	if ahead == 0 {
-		return fmt.Errorf("source branch '%s' has no changes compared to '%s'", sourceBranch, targetBranch)
+		return fmt.Errorf("%w: source branch '%s' has no commits that are not in '%s'", ErrNoChanges, sourceBranch, targetBranch)
 	}
 
-	fmt.Printf("📊 Branch analysis: %s is %d commits ahead and %d commits behind %s\n",
+	fmt.Fprintf(v.out, "📊 Branch analysis: %s is %d commits ahead and %d commits behind %s\n",
 		sourceBranch, ahead, behind, targetBranch)
 
 	return nil
@@ -121,7 +126,7 @@ func (v *Validator) validateBranchDistance(sourceBranch, targetBranch string) er
 
 // getBranchDistance returns how many commits ahead and behind source is compared to target
 func (v *Validator) getBranchDistance(sourceBranch, targetBranch string) (ahead, behind int, err error) {
-	output, err := runGitCommand(v.workingDir, "rev-list", "--left-right", "--count",
+	output, err := runGitCommand(v.ctx, v.workingDir, "rev-list", "--left-right", "--count",
 		fmt.Sprintf("%s...%s", targetBranch, sourceBranch))
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to get branch distance: %w", err)
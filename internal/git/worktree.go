@@ -0,0 +1,43 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Worktree is a temporary linked worktree used to isolate branch operations
+type Worktree struct {
+	ctx     context.Context
+	repoDir string
+	tempDir string
+	Path    string
+}
+
+// AddTemporaryWorktreeAt creates a detached worktree of repoDir at rev
+func AddTemporaryWorktreeAt(ctx context.Context, repoDir, rev string) (*Worktree, error) {
+	tempDir, err := os.MkdirTemp("", "pr-split-worktree-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	path := filepath.Join(tempDir, "worktree")
+	if err := runGitCommandQuiet(ctx, repoDir, "worktree", "add", "--detach", path, rev); err != nil {
+		os.RemoveAll(tempDir)
+		return nil, fmt.Errorf("failed to add worktree: %w", err)
+	}
+
+	return &Worktree{ctx: ctx, repoDir: repoDir, tempDir: tempDir, Path: path}, nil
+}
+
+// Remove deletes the worktree and its temporary directory
+func (w *Worktree) Remove() error {
+	// Cleanup runs even when the work in the worktree was cancelled
+	err := runGitCommandQuiet(context.WithoutCancel(w.ctx), w.repoDir, "worktree", "remove", "--force", w.Path)
+	os.RemoveAll(w.tempDir)
+	if err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", w.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Style is how progress output is decorated
+type Style string
+
+const (
+	StyleAuto  Style = "auto"  // Emoji on a terminal, plain otherwise
+	StyleEmoji Style = "emoji" // Emoji status markers and box-drawing rules
+	StylePlain Style = "plain" // ASCII only, for CI log viewers and limited terminals
+)
+
+// replacements spell out the symbols that carry meaning; other emoji are decoration and dropped
+var replacements = map[rune]string{
+	'✅': "[OK]",
+	'✓': "[OK]",
+	'❌': "[FAIL]",
+	'✗': "[FAIL]",
+	'⚠': "[WARN]",
+	'ℹ': "[INFO]",
+	'💡': "[TIP]",
+	'🛑': "[STOP]",
+	'⏭': "[SKIP]",
+	'☐': "[ ]",
+	'━': "=",
+	'→': "->",
+	'↩': "<-",
+	'—': "--",
+	'–': "-",
+	'−': "-",
+	'•': "*",
+	'·': "-",
+	'🔸': "-",
+	'…': "...",
+	'×': "x",
+	'➕': "+",
+	'➖': "-",
+	'⬆': "^",
+	'⬇': "v",
+}
+
+// What Plain does with the spaces after a rewritten symbol
+const (
+	keepSpaces     = iota
+	collapseSpaces // Keep one, after a marker like [OK]
+	dropSpaces     // Drop them, after a dropped emoji
+)
+
+// Resolve turns StyleAuto into the style for this process: plain when NO_COLOR is set, TERM is
+// dumb or stdout is not a terminal, emoji otherwise. FORCE_COLOR keeps emoji in pipes.
+func Resolve(style Style) Style {
+	if style != StyleAuto && style != "" {
+		return style
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return StylePlain
+	}
+	if os.Getenv("FORCE_COLOR") != "" {
+		return StyleEmoji
+	}
+	if info, err := os.Stdout.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return StylePlain
+	}
+	return StyleEmoji
+}
+
+// Plain rewrites text as ASCII: meaningful symbols become markers like [OK] or [WARN], rules
+// become '=' and decorative emoji are dropped with the spaces after them. Other non-ASCII text,
+// such as file names, is kept.
+func Plain(text string) string {
+	var b strings.Builder
+	spaces := keepSpaces
+	for _, r := range text {
+		if r == ' ' && spaces != keepSpaces {
+			if spaces == collapseSpaces {
+				b.WriteRune(r)
+				spaces = dropSpaces
+			}
+			continue
+		}
+		if r == '\uFE0F' || r == '\u200D' {
+			continue // Emoji presentation selector and joiner
+		}
+		spaces = keepSpaces
+
+		if replacement, ok := replacements[r]; ok {
+			b.WriteString(replacement)
+			if strings.HasPrefix(replacement, "[") {
+				spaces = collapseSpaces // "⚠️  Warning" reads "[WARN] Warning"
+			}
+			continue
+		}
+		if unicode.Is(unicode.So, r) && r > unicode.MaxLatin1 {
+			spaces = dropSpaces
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// plainWriter applies Plain to everything written through it, holding back a UTF-8 sequence
+// split across writes until it is complete
+type plainWriter struct {
+	w       io.Writer
+	pending []byte
+}
+
+// NewPlainWriter returns a writer that writes ASCII-only output to w
+func NewPlainWriter(w io.Writer) io.Writer {
+	return &plainWriter{w: w}
+}
+
+// Write rewrites p as ASCII; it reports len(p) written when the rewritten text is written
+func (pw *plainWriter) Write(p []byte) (int, error) {
+	data := append(pw.pending, p...)
+	cut := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				cut = i
+			}
+			break
+		}
+	}
+	pw.pending = append([]byte(nil), data[cut:]...)
+
+	if _, err := io.WriteString(pw.w, Plain(string(data[:cut]))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Setup applies a style to everything the process writes to stdout, including the output of
+// child processes sharing it. For plain output stdout is replaced by a pipe whose content is
+// rewritten as ASCII; call the returned function before exiting to flush it.
+func Setup(style Style) (func(), error) {
+	if Resolve(style) != StylePlain {
+		return func() {}, nil
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		io.Copy(NewPlainWriter(stdout), reader)
+		reader.Close()
+	}()
+
+	return func() {
+		os.Stdout = stdout
+		writer.Close()
+		<-done
+	}, nil
+}
@@ -0,0 +1,111 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	redrawInterval = 100 * time.Millisecond // Between redraws of a progress line on a terminal
+	lineInterval   = 5 * time.Second        // Between progress lines in logs
+	barWidth       = 24
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Progress counts the steps of a long phase, such as the files of a scan. On a terminal it
+// redraws one line with a bar, or a spinner when the total is unknown; in logs it prints a
+// line every few seconds, so a phase finishing quickly adds nothing. It is not safe for
+// concurrent use.
+type Progress struct {
+	out     io.Writer
+	label   string
+	unit    string
+	total   int // 0 when unknown
+	count   int
+	live    bool
+	drawn   bool // A live line is on screen
+	started time.Time
+	last    time.Time
+	frame   int
+}
+
+// NewProgress starts counting a phase of total steps, 0 when the total is unknown. unit names
+// a step in the counts, e.g. "files".
+func NewProgress(out io.Writer, label string, total int, unit string) *Progress {
+	now := time.Now()
+	return &Progress{
+		out:     out,
+		label:   label,
+		unit:    unit,
+		total:   total,
+		live:    isTerminal(out),
+		started: now,
+		last:    now,
+	}
+}
+
+// Add counts n more steps
+func (p *Progress) Add(n int) {
+	p.count += n
+	now := time.Now()
+	if p.live {
+		if now.Sub(p.last) >= redrawInterval {
+			p.last = now
+			p.draw()
+		}
+		return
+	}
+	if now.Sub(p.last) >= lineInterval {
+		p.last = now
+		fmt.Fprintf(p.out, "⏳ %s: %s (%s)\n", p.label, p.counts(), now.Sub(p.started).Round(time.Second))
+	}
+}
+
+// Clear removes the live line so other output can be printed; the next Add draws it again
+func (p *Progress) Clear() {
+	if p.drawn {
+		fmt.Fprint(p.out, "\r\033[K")
+		p.drawn = false
+	}
+}
+
+// Done ends the phase, removing the live line
+func (p *Progress) Done() {
+	p.Clear()
+}
+
+// draw redraws the live line
+func (p *Progress) draw() {
+	var indicator string
+	if p.total > 0 {
+		filled := barWidth * min(p.count, p.total) / p.total
+		indicator = "[" + strings.Repeat("#", filled) + strings.Repeat(".", barWidth-filled) + "]"
+	} else {
+		indicator = spinnerFrames[p.frame%len(spinnerFrames)]
+		p.frame++
+	}
+	fmt.Fprintf(p.out, "\r\033[K%s %s %s", indicator, p.label, p.counts())
+	p.drawn = true
+}
+
+// counts formats the count, with the total when known
+func (p *Progress) counts() string {
+	if p.total > 0 {
+		return fmt.Sprintf("%d/%d %s", p.count, p.total, p.unit)
+	}
+	return fmt.Sprintf("%d %s", p.count, p.unit)
+}
+
+// isTerminal reports whether w is a terminal that can redraw a line in place
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
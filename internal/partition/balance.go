@@ -0,0 +1,117 @@
+package partition
+
+import (
+	"fmt"
+	"sort"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// PartitionLines is the review weight of a partition: lines added plus lines deleted
+func PartitionLines(partition types.Partition) int {
+	total := 0
+	for _, file := range partition.Files {
+		total += fileLines(file)
+	}
+	return total
+}
+
+// fileLines is the review weight of a single file
+func fileLines(file types.FileChange) int {
+	return file.LinesAdded + file.LinesDeleted
+}
+
+// balancePartitions splits partitions whose changed lines exceed maxLines into line-balanced
+// parts. Files are ordered dependencies-first before splitting so the parts still stack.
+// Circular groups cannot be split and are left whole with a warning.
+func (p *Partitioner) balancePartitions(partitions []types.Partition, graph *types.DependencyGraph, sccs []types.StronglyConnectedComponent, maxLines int) []types.Partition {
+	if maxLines <= 0 {
+		return partitions
+	}
+
+	circular := make(map[string]bool)
+	for _, scc := range sccs {
+		for _, file := range scc.Files {
+			circular[file] = true
+		}
+	}
+
+	var balanced []types.Partition
+	for _, partition := range partitions {
+		lines := PartitionLines(partition)
+		if lines <= maxLines || len(partition.Files) < 2 {
+			balanced = append(balanced, partition)
+			continue
+		}
+
+		if p.containsAny(partition.Files, circular) {
+			fmt.Fprintf(p.out, "⚠️  Warning: Circular group '%s' has %d changed lines (limit %d) and cannot be split\n", partition.Name, lines, maxLines)
+			balanced = append(balanced, partition)
+			continue
+		}
+
+		parts := splitByLines(p.orderDependenciesFirst(partition.Files, graph), lines, maxLines)
+		fmt.Fprintf(p.out, "⚖️  Split '%s' (%d lines) into %d parts of at most %d lines\n", partition.Name, lines, len(parts), maxLines)
+		for _, files := range parts {
+			balanced = append(balanced, types.Partition{
+				Name:         p.generateName(files),
+				Description:  p.generateDescription(files),
+				Files:        files,
+				Dependencies: partition.Dependencies,
+			})
+		}
+	}
+
+	for i := range balanced {
+		balanced[i].ID = i + 1
+	}
+	return balanced
+}
+
+// splitByLines cuts an ordered file list into consecutive parts, aiming for equal line counts.
+// A single file larger than maxLines gets a part of its own.
+func splitByLines(files []types.FileChange, totalLines, maxLines int) [][]types.FileChange {
+	count := (totalLines + maxLines - 1) / maxLines
+	target := (totalLines + count - 1) / count
+
+	var parts [][]types.FileChange
+	var current []types.FileChange
+	currentLines := 0
+	for _, file := range files {
+		lines := fileLines(file)
+		if len(current) > 0 && (currentLines+lines > maxLines || currentLines >= target) {
+			parts = append(parts, current)
+			current, currentLines = nil, 0
+		}
+		current = append(current, file)
+		currentLines += lines
+	}
+	if len(current) > 0 {
+		parts = append(parts, current)
+	}
+	return parts
+}
+
+// orderDependenciesFirst sorts files so that each file comes after the files it depends on
+func (p *Partitioner) orderDependenciesFirst(files []types.FileChange, graph *types.DependencyGraph) []types.FileChange {
+	ordered := append([]types.FileChange(nil), files...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		di := p.calculateDependencyDepth(ordered[i].Path, graph, make(map[string]bool))
+		dj := p.calculateDependencyDepth(ordered[j].Path, graph, make(map[string]bool))
+		if di != dj {
+			return di < dj
+		}
+		return ordered[i].Path < ordered[j].Path
+	})
+	return ordered
+}
+
+// containsAny reports whether any file is in the set
+func (p *Partitioner) containsAny(files []types.FileChange, set map[string]bool) bool {
+	for _, file := range files {
+		if set[file.Path] {
+			return true
+		}
+	}
+	return false
+}
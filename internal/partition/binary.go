@@ -0,0 +1,153 @@
+package partition
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// splitBinaryFiles separates binary files, which no analyzer reports dependencies for, from the
+// files partitioned by their dependencies
+func splitBinaryFiles(files []types.FileChange) (code, binaries []types.FileChange) {
+	for _, file := range files {
+		if file.IsBinary {
+			binaries = append(binaries, file)
+		} else {
+			code = append(code, file)
+		}
+	}
+	return code, binaries
+}
+
+// placeBinaryFiles adds binary files to the partitions following the binary files policy. With
+// the directory policy a file imported by code (e.g. an image imported by a component) joins the
+// first partition importing it, and any other file the partition holding most changed files of
+// its nearest directory that has room; files that fit nowhere get partitions of their own.
+func (p *Partitioner) placeBinaryFiles(partitions []types.Partition, binaries []types.FileChange, cfg *types.Config) []types.Partition {
+	if len(binaries) == 0 {
+		return partitions
+	}
+
+	policy := cfg.BinaryFiles
+	if policy == "" {
+		policy = types.BinaryFilesDirectory
+	}
+	fmt.Fprintf(p.out, "🖼️  Placing %d binary files (%s)\n", len(binaries), policy)
+
+	if policy == types.BinaryFilesSeparate || len(partitions) == 0 {
+		return prependPartitions(p.createGroupPartitions(binaries, cfg.MaxFilesPerPartition, "Binary files"), partitions)
+	}
+
+	var homeless []types.FileChange
+	for _, file := range binaries {
+		if i := p.importingPartition(partitions, file.Path); i >= 0 {
+			p.addFile(&partitions[i], file)
+			continue
+		}
+		if i := directoryPartition(partitions, file.Path, cfg.MaxFilesPerPartition); i >= 0 {
+			p.addFile(&partitions[i], file)
+			continue
+		}
+		homeless = append(homeless, file)
+	}
+	if len(homeless) > 0 {
+		partitions = prependPartitions(p.createGroupPartitions(homeless, cfg.MaxFilesPerPartition, "Binary files"), partitions)
+	}
+	return partitions
+}
+
+// addFile adds a file to a partition, keeping its description and file count in step with its files
+func (p *Partitioner) addFile(partition *types.Partition, file types.FileChange) {
+	generated := partition.Description == p.generateDescription(partition.Files)
+	partition.Files = append(partition.Files, file)
+	if generated {
+		partition.Description = p.generateDescription(partition.Files)
+	} else {
+		partition.Description = recountDescription(partition.Description, len(partition.Files))
+	}
+}
+
+// importingPartition returns the first partition with a file that depends on filePath, or -1
+func (p *Partitioner) importingPartition(partitions []types.Partition, filePath string) int {
+	if p.graph == nil {
+		return -1
+	}
+	importers := make(map[string]bool)
+	for _, edge := range p.graph.Edges {
+		if edge.To == filePath {
+			importers[edge.From] = true
+		}
+	}
+	if len(importers) == 0 {
+		return -1
+	}
+	for i, partition := range partitions {
+		for _, file := range partition.Files {
+			if importers[file.Path] {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// directoryPartition returns the partition with room holding most changed files under the
+// nearest directory of filePath, or -1 when every partition is full
+func directoryPartition(partitions []types.Partition, filePath string, limit int) int {
+	for dir := path.Dir(filePath); ; dir = path.Dir(dir) {
+		best, bestCount := -1, 0
+		for i, partition := range partitions {
+			if len(partition.Files) >= limit {
+				continue
+			}
+			count := 0
+			for _, file := range partition.Files {
+				if dir == "." || strings.HasPrefix(file.Path, dir+"/") {
+					count++
+				}
+			}
+			if count > bestCount {
+				best, bestCount = i, count
+			}
+		}
+		if best >= 0 || dir == "." {
+			return best
+		}
+	}
+}
+
+// createGroupPartitions groups files placed outside dependency analysis, e.g. binary files,
+// into partitions of at most limit files described by label
+func (p *Partitioner) createGroupPartitions(group []types.FileChange, limit int, label string) []types.Partition {
+	var partitions []types.Partition
+	for start := 0; start < len(group); start += limit {
+		end := start + limit
+		if end > len(group) {
+			end = len(group)
+		}
+		files := group[start:end]
+		partitions = append(partitions, types.Partition{
+			Name:        p.generateName(files),
+			Description: fmt.Sprintf("%s (%d files)", label, len(files)),
+			Files:       files,
+		})
+	}
+	return partitions
+}
+
+// prependPartitions puts partitions without dependencies ahead of the others, renumbering both
+func prependPartitions(front, rest []types.Partition) []types.Partition {
+	shift := len(front)
+	for i := range front {
+		front[i].ID = i + 1
+	}
+	for i := range rest {
+		rest[i].ID += shift
+		for j := range rest[i].Dependencies {
+			rest[i].Dependencies[j] += shift
+		}
+	}
+	return append(front, rest...)
+}
@@ -0,0 +1,69 @@
+package partition
+
+import (
+	"strconv"
+	"strings"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/types"
+)
+
+// BranchNamer is the single source of truth for partition branch names
+type BranchNamer struct {
+	prefix    string
+	template  string
+	namespace string
+	suffix    string
+}
+
+// NewBranchNamer creates a branch namer from configuration
+func NewBranchNamer(cfg *types.Config) *BranchNamer {
+	template := cfg.BranchTemplate
+	if template == "" {
+		template = config.ConfigDefaults.BranchTemplate
+	}
+
+	return &BranchNamer{
+		prefix:    cfg.BranchPrefix,
+		template:  template,
+		namespace: strings.Trim(cfg.BranchNamespace, "/"),
+		suffix:    cfg.BranchSuffix,
+	}
+}
+
+// BranchName renders the branch name for a partition
+func (b *BranchNamer) BranchName(partition types.Partition) string {
+	replacer := strings.NewReplacer(
+		"{prefix}", b.prefix,
+		"{id}", strconv.Itoa(partition.ID),
+		"{name}", partition.Name,
+		"{slug}", partition.Slug,
+	)
+	name := replacer.Replace(b.template)
+	if b.suffix != "" {
+		name += "-" + b.suffix
+	}
+	if b.namespace != "" {
+		name = b.namespace + "/" + name
+	}
+	return name
+}
+
+// BranchPrefix is the fixed start shared by every branch name the namer renders, e.g. "split/me/pr-split-"
+func (b *BranchNamer) BranchPrefix() string {
+	prefix := strings.ReplaceAll(b.template, "{prefix}", b.prefix)
+	if i := strings.Index(prefix, "{"); i >= 0 {
+		prefix = prefix[:i]
+	}
+	if b.namespace != "" {
+		prefix = b.namespace + "/" + prefix
+	}
+	return prefix
+}
+
+// AssignBranchNames stores the final branch name on every partition in the plan
+func (b *BranchNamer) AssignBranchNames(partitions []types.Partition) {
+	for i := range partitions {
+		partitions[i].BranchName = b.BranchName(partitions[i])
+	}
+}
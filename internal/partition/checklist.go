@@ -0,0 +1,71 @@
+package partition
+
+import (
+	"pr-splitter-cli/internal/pathglob"
+	"pr-splitter-cli/internal/types"
+)
+
+// DefaultChecklistRules cover the review concerns most teams ask about. Configured rules replace them.
+var DefaultChecklistRules = []types.ChecklistRule{
+	{
+		Patterns: []string{"**/migrations/**", "**/migrate/**", "*.sql"},
+		Item:     "Contains a database migration — verify backward compatibility and rollback",
+	},
+	{
+		Patterns: []string{"*.d.ts", "*.proto", "*.graphql", "**/openapi*.{yaml,yml,json}", "**/swagger*.{yaml,yml,json}", "**/api/**/types.*"},
+		Item:     "Modifies public API types or schemas — check consumers",
+	},
+	{
+		Patterns: []string{"package.json", "go.mod", "requirements*.txt", "pyproject.toml", "pom.xml", "build.gradle*", "Cargo.toml", "Gemfile"},
+		Item:     "Changes dependencies — check versions, licenses and lockfile updates",
+	},
+	{
+		Patterns: []string{".github/workflows/**", ".gitlab-ci.yml", "Jenkinsfile", ".circleci/**", "azure-pipelines.yml"},
+		Item:     "Changes CI configuration — confirm pipelines still run",
+	},
+	{
+		Patterns: []string{"*.tf", "*.tfvars", "Dockerfile", "docker-compose*.{yml,yaml}", "**/k8s/**", "**/helm/**"},
+		Item:     "Changes infrastructure or deployment — review the plan or rollout impact",
+	},
+	{
+		Patterns: []string{"**/auth/**", "**/security/**", "**/crypto/**", "**/permissions/**"},
+		Item:     "Touches authentication or security code — request a security review",
+	},
+	{
+		Patterns: []string{".env*", "**/config/**", "*.config.{js,ts}", "settings.py"},
+		Item:     "Changes configuration — check defaults in every environment",
+	},
+}
+
+// BuildChecklist returns the checklist items whose patterns match at least one file, in rule order
+func BuildChecklist(files []types.FileChange, rules []types.ChecklistRule) []string {
+	var items []string
+	seen := make(map[string]bool)
+
+	for _, rule := range rules {
+		if seen[rule.Item] {
+			continue
+		}
+		for _, file := range files {
+			if pathglob.MatchAny(rule.Patterns, file.Path) {
+				items = append(items, rule.Item)
+				seen[rule.Item] = true
+				break
+			}
+		}
+	}
+
+	return items
+}
+
+// AssignChecklists fills in each partition's review checklist from the configured or default rules
+func AssignChecklists(partitions []types.Partition, cfg *types.Config) {
+	rules := cfg.ChecklistRules
+	if len(rules) == 0 {
+		rules = DefaultChecklistRules
+	}
+
+	for i := range partitions {
+		partitions[i].Checklist = BuildChecklist(partitions[i].Files, rules)
+	}
+}
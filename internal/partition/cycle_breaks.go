@@ -0,0 +1,122 @@
+package partition
+
+import (
+	"fmt"
+	"sort"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// maxSuggestedBreaks bounds the search so huge cycles still get a prompt quickly
+const maxSuggestedBreaks = 20
+
+// SuggestCycleBreaks finds a small set of dependency edges inside a circular group whose removal
+// leaves no circular group larger than maxSize. Edges are removed greedily, each time picking the
+// one that shrinks the largest remaining group most (weaker edges win ties), then any edge that
+// turns out to be unnecessary is put back. Returns nil when no suggestion fits the search bound.
+func SuggestCycleBreaks(files []string, edges []types.Dependency, maxSize int) []types.Dependency {
+	inGroup := make(map[string]bool)
+	for _, file := range files {
+		inGroup[file] = true
+	}
+
+	var internal []types.Dependency
+	seen := make(map[[2]string]bool)
+	for _, edge := range edges {
+		key := [2]string{edge.From, edge.To}
+		if inGroup[edge.From] && inGroup[edge.To] && edge.From != edge.To && !seen[key] {
+			seen[key] = true
+			internal = append(internal, edge)
+		}
+	}
+	sort.Slice(internal, func(i, j int) bool {
+		if internal[i].From != internal[j].From {
+			return internal[i].From < internal[j].From
+		}
+		return internal[i].To < internal[j].To
+	})
+
+	removed := make(map[int]bool)
+	for len(removed) < maxSuggestedBreaks {
+		largest := largestCycleGroup(files, internal, removed)
+		if largest <= maxSize {
+			break
+		}
+
+		best, bestSize := -1, largest+1
+		for i := range internal {
+			if removed[i] {
+				continue
+			}
+			removed[i] = true
+			size := largestCycleGroup(files, internal, removed)
+			delete(removed, i)
+
+			if size < bestSize || (size == bestSize && best >= 0 && internal[i].Strength.Rank() < internal[best].Strength.Rank()) {
+				best, bestSize = i, size
+			}
+		}
+		if best < 0 {
+			return nil
+		}
+		removed[best] = true
+	}
+
+	if largestCycleGroup(files, internal, removed) > maxSize {
+		return nil
+	}
+
+	// Reverse-delete: keep only the edges that are actually needed
+	indexes := make([]int, 0, len(removed))
+	for i := range removed {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	for _, i := range indexes {
+		delete(removed, i)
+		if largestCycleGroup(files, internal, removed) > maxSize {
+			removed[i] = true
+		}
+	}
+
+	var breaks []types.Dependency
+	for _, i := range indexes {
+		if removed[i] {
+			breaks = append(breaks, internal[i])
+		}
+	}
+	return breaks
+}
+
+// DescribeEdge formats a dependency for display, e.g. "a.ts → b.ts (line 3, CRITICAL import)"
+func DescribeEdge(edge types.Dependency) string {
+	detail := string(edge.Strength)
+	if edge.Type != "" {
+		detail += " " + edge.Type
+	}
+	if edge.Line > 0 {
+		detail = fmt.Sprintf("line %d, %s", edge.Line, detail)
+	}
+	return fmt.Sprintf("%s → %s (%s)", edge.From, edge.To, detail)
+}
+
+// largestCycleGroup returns the size of the largest circular group left after removing edges
+func largestCycleGroup(files []string, edges []types.Dependency, removed map[int]bool) int {
+	graph := &types.DependencyGraph{
+		Nodes:     files,
+		Adjacency: make(map[string][]string),
+	}
+	for i, edge := range edges {
+		if !removed[i] {
+			graph.Adjacency[edge.From] = append(graph.Adjacency[edge.From], edge.To)
+		}
+	}
+
+	largest := 0
+	for _, scc := range NewTarjanSCC(graph).FindSCCs() {
+		if scc.Size > 1 && scc.Size > largest {
+			largest = scc.Size
+		}
+	}
+	return largest
+}
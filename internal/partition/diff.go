@@ -0,0 +1,236 @@
+package partition
+
+import (
+	"fmt"
+	"sort"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// PlanDiff describes how a regenerated plan differs from a previous one
+type PlanDiff struct {
+	Matched           []PartitionMatch
+	Added             []types.Partition // Partitions with no counterpart in the old plan
+	Removed           []types.Partition // Old partitions with no counterpart in the new plan
+	MovedFiles        []FileMove
+	AddedFiles        []string // Files only in the new plan
+	RemovedFiles      []string // Files only in the old plan
+	DependencyChanges []DependencyChange
+}
+
+// PartitionMatch pairs an old partition with the new partition holding most of its files
+type PartitionMatch struct {
+	Old types.Partition
+	New types.Partition
+}
+
+// FileMove records a file that landed in a different partition
+type FileMove struct {
+	Path string
+	From string // Old partition label
+	To   string // New partition label
+}
+
+// DependencyChange lists partition dependencies gained or lost by a matched partition
+type DependencyChange struct {
+	Partition string
+	Added     []string
+	Removed   []string
+}
+
+// IsEmpty reports whether the two plans are equivalent
+func (d *PlanDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.MovedFiles) == 0 &&
+		len(d.AddedFiles) == 0 && len(d.RemovedFiles) == 0 && len(d.DependencyChanges) == 0
+}
+
+// PartitionLabel formats a partition for diff output
+func PartitionLabel(partition types.Partition) string {
+	if partition.Slug != "" {
+		return fmt.Sprintf("#%d %s", partition.ID, partition.Slug)
+	}
+	return fmt.Sprintf("#%d %s", partition.ID, partition.Name)
+}
+
+// DiffPlans compares two plans. Partition IDs are not stable across runs, so partitions are
+// matched by slug, then by file overlap, then by identical names for partitions that share no files.
+func DiffPlans(oldPlan, newPlan *types.PartitionPlan) *PlanDiff {
+	diff := &PlanDiff{}
+
+	oldOwner := fileOwners(oldPlan)
+	newOwner := fileOwners(newPlan)
+	oldByID := partitionsByID(oldPlan)
+	newByID := partitionsByID(newPlan)
+
+	oldToNew := matchPartitions(oldPlan, newPlan, oldOwner, newOwner)
+	newToOld := make(map[int]int)
+	for oldID, newID := range oldToNew {
+		newToOld[newID] = oldID
+	}
+
+	for _, partition := range oldPlan.Partitions {
+		if newID, ok := oldToNew[partition.ID]; ok {
+			diff.Matched = append(diff.Matched, PartitionMatch{Old: partition, New: newByID[newID]})
+		} else {
+			diff.Removed = append(diff.Removed, partition)
+		}
+	}
+	for _, partition := range newPlan.Partitions {
+		if _, ok := newToOld[partition.ID]; !ok {
+			diff.Added = append(diff.Added, partition)
+		}
+	}
+
+	for _, path := range sortedKeys(newOwner) {
+		oldID, existed := oldOwner[path]
+		newID := newOwner[path]
+		if !existed {
+			diff.AddedFiles = append(diff.AddedFiles, path)
+			continue
+		}
+		if matched, ok := oldToNew[oldID]; !ok || matched != newID {
+			diff.MovedFiles = append(diff.MovedFiles, FileMove{
+				Path: path,
+				From: PartitionLabel(oldByID[oldID]),
+				To:   PartitionLabel(newByID[newID]),
+			})
+		}
+	}
+	for _, path := range sortedKeys(oldOwner) {
+		if _, ok := newOwner[path]; !ok {
+			diff.RemovedFiles = append(diff.RemovedFiles, path)
+		}
+	}
+
+	for _, match := range diff.Matched {
+		change := diffDependencies(match, oldToNew, oldByID, newByID)
+		if len(change.Added) > 0 || len(change.Removed) > 0 {
+			diff.DependencyChanges = append(diff.DependencyChanges, change)
+		}
+	}
+
+	return diff
+}
+
+// diffDependencies compares a matched partition's dependencies, translating old IDs into the new plan
+func diffDependencies(match PartitionMatch, oldToNew map[int]int, oldByID, newByID map[int]types.Partition) DependencyChange {
+	change := DependencyChange{Partition: PartitionLabel(match.New)}
+
+	translated := make(map[int]bool)
+	for _, oldDep := range match.Old.Dependencies {
+		newDep, ok := oldToNew[oldDep]
+		if !ok {
+			change.Removed = append(change.Removed, PartitionLabel(oldByID[oldDep])+" (removed)")
+			continue
+		}
+		translated[newDep] = true
+	}
+
+	current := make(map[int]bool)
+	for _, newDep := range match.New.Dependencies {
+		current[newDep] = true
+		if !translated[newDep] {
+			change.Added = append(change.Added, PartitionLabel(newByID[newDep]))
+		}
+	}
+	for _, oldDep := range match.Old.Dependencies {
+		if newDep, ok := oldToNew[oldDep]; ok && !current[newDep] {
+			change.Removed = append(change.Removed, PartitionLabel(newByID[newDep]))
+		}
+	}
+
+	return change
+}
+
+// matchPartitions pairs partitions with the same slug, then greedily by the number of files they share
+func matchPartitions(oldPlan, newPlan *types.PartitionPlan, oldOwner, newOwner map[string]int) map[int]int {
+	type pair struct{ oldID, newID, shared int }
+
+	shared := make(map[[2]int]int)
+	for path, oldID := range oldOwner {
+		if newID, ok := newOwner[path]; ok {
+			shared[[2]int{oldID, newID}]++
+		}
+	}
+
+	var pairs []pair
+	for key, count := range shared {
+		pairs = append(pairs, pair{key[0], key[1], count})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].shared != pairs[j].shared {
+			return pairs[i].shared > pairs[j].shared
+		}
+		if pairs[i].oldID != pairs[j].oldID {
+			return pairs[i].oldID < pairs[j].oldID
+		}
+		return pairs[i].newID < pairs[j].newID
+	})
+
+	oldToNew := make(map[int]int)
+	usedNew := make(map[int]bool)
+
+	// Slugs are stable identifiers, so an unchanged slug is the same partition
+	for _, oldPartition := range oldPlan.Partitions {
+		for _, newPartition := range newPlan.Partitions {
+			if oldPartition.Slug != "" && oldPartition.Slug == newPartition.Slug && !usedNew[newPartition.ID] {
+				oldToNew[oldPartition.ID] = newPartition.ID
+				usedNew[newPartition.ID] = true
+				break
+			}
+		}
+	}
+
+	for _, p := range pairs {
+		if _, ok := oldToNew[p.oldID]; ok || usedNew[p.newID] {
+			continue
+		}
+		oldToNew[p.oldID] = p.newID
+		usedNew[p.newID] = true
+	}
+
+	// Partitions whose files were all replaced can still be recognised by name
+	for _, oldPartition := range oldPlan.Partitions {
+		if _, ok := oldToNew[oldPartition.ID]; ok {
+			continue
+		}
+		for _, newPartition := range newPlan.Partitions {
+			if !usedNew[newPartition.ID] && newPartition.Name == oldPartition.Name {
+				oldToNew[oldPartition.ID] = newPartition.ID
+				usedNew[newPartition.ID] = true
+				break
+			}
+		}
+	}
+
+	return oldToNew
+}
+
+// fileOwners maps each file path to the ID of the partition containing it
+func fileOwners(plan *types.PartitionPlan) map[string]int {
+	owners := make(map[string]int)
+	for _, partition := range plan.Partitions {
+		for _, file := range partition.Files {
+			owners[file.Path] = partition.ID
+		}
+	}
+	return owners
+}
+
+// partitionsByID indexes a plan's partitions by ID
+func partitionsByID(plan *types.PartitionPlan) map[int]types.Partition {
+	byID := make(map[int]types.Partition)
+	for _, partition := range plan.Partitions {
+		byID[partition.ID] = partition
+	}
+	return byID
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,7 @@
+package partition
+
+import "errors"
+
+// ErrUserAborted is returned when the user, or the decision policy of a library caller,
+// declined a plan or one of the questions asked while planning
+var ErrUserAborted = errors.New("aborted by user")
@@ -143,25 +143,5 @@ func (g *FileGrouper) groupByDirectory(path string) string {
 
 // containsTestPattern checks if the path contains test-related patterns
 func (g *FileGrouper) containsTestPattern(path string) bool {
-	lowerPath := strings.ToLower(path)
-
-	testPatterns := []string{
-		".test.",
-		".spec.",
-		"_test.",
-		"_spec.",
-		"/test/",
-		"/tests/",
-		"/spec/",
-		"/specs/",
-		"/__tests__/",
-	}
-
-	for _, pattern := range testPatterns {
-		if strings.Contains(lowerPath, pattern) {
-			return true
-		}
-	}
-
-	return false
+	return IsTestFile(path)
 }
@@ -0,0 +1,119 @@
+package partition
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/pathglob"
+	"pr-splitter-cli/internal/types"
+)
+
+// SetLineDiffs provides the source diff hunks used for hunk-level splitting
+func (p *Partitioner) SetLineDiffs(diffs map[string]types.LineDiff) {
+	p.lineDiffs = diffs
+}
+
+// AssignHunks splits shared files (e.g. index.ts barrels) matching the given globs across
+// partitions. Each hunk moves to the partition owning the changed file it refers to, such as the
+// module an added export points at; hunks that refer to nothing stay with the file's partition.
+func AssignHunks(partitions []types.Partition, diffs map[string]types.LineDiff, patterns []string) int {
+	if len(patterns) == 0 {
+		return 0
+	}
+
+	owner := make(map[string]int)
+	for i, partition := range partitions {
+		for _, file := range partition.Files {
+			owner[file.Path] = i
+		}
+	}
+
+	split := 0
+	for home := range partitions {
+		for _, file := range partitions[home].Files {
+			hunks := diffs[file.Path].Hunks
+			if len(hunks) < 2 || !pathglob.MatchAny(patterns, file.Path) {
+				continue
+			}
+			if file.ChangeType != types.ChangeTypeModify && file.ChangeType != types.ChangeTypeAdd {
+				continue
+			}
+
+			assigned := make(map[int][]int)
+			for i, hunk := range hunks {
+				target := referencedPartition(hunk, file.Path, owner)
+				if target < 0 {
+					target = home
+				}
+				assigned[target] = append(assigned[target], i)
+			}
+			if len(assigned) < 2 {
+				continue
+			}
+
+			for target, indexes := range assigned {
+				partitions[target].SplitFiles = append(partitions[target].SplitFiles, types.HunkSelection{
+					Path:  file.Path,
+					Hunks: indexes,
+					Total: len(hunks),
+				})
+			}
+			split++
+		}
+	}
+
+	for i := range partitions {
+		sort.Slice(partitions[i].SplitFiles, func(a, b int) bool {
+			return partitions[i].SplitFiles[a].Path < partitions[i].SplitFiles[b].Path
+		})
+	}
+	return split
+}
+
+// referencedPartition finds the partition whose files a hunk mentions most, or -1. A file is
+// mentioned by its path or base name without extension appearing as a whole word.
+func referencedPartition(hunk types.DiffHunk, self string, owner map[string]int) int {
+	text := strings.Join(append(append([]string{}, hunk.Added...), hunk.Removed...), "\n")
+
+	votes := make(map[int]int)
+	for filePath, index := range owner {
+		if filePath == self {
+			continue
+		}
+		stem := strings.TrimSuffix(filePath, path.Ext(filePath))
+		base := path.Base(stem)
+		if base == "index" {
+			base = path.Base(path.Dir(stem))
+		}
+		if len(base) >= 3 && containsWord(text, base) || containsWord(text, stem) {
+			votes[index]++
+		}
+	}
+
+	best, bestVotes := -1, 0
+	for index, count := range votes {
+		if count > bestVotes || (count == bestVotes && index < best) {
+			best, bestVotes = index, count
+		}
+	}
+	return best
+}
+
+// containsWord reports whether word appears in text without identifier characters on either side
+func containsWord(text, word string) bool {
+	isIdent := func(b byte) bool {
+		return b == '_' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(word)
+		if (start == 0 || !isIdent(text[start-1])) && (end == len(text) || !isIdent(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
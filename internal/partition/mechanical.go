@@ -0,0 +1,204 @@
+package partition
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// mixedChangeShare is the minority share above which a partition counts as heavily mixed
+const mixedChangeShare = 0.25
+
+// maxIdentifierRenames bounds how many distinct identifier substitutions still count as a mass rename
+const maxIdentifierRenames = 3
+
+// tokenPattern splits a line into identifiers, string literals, numbers and single punctuation characters
+var tokenPattern = regexp.MustCompile("[A-Za-z_$][A-Za-z0-9_$]*|\"(?:\\\\.|[^\"\\\\])*\"|'(?:\\\\.|[^'\\\\])*'|`[^`]*`|[0-9][A-Za-z0-9_.]*|\\S")
+
+// identifierPattern matches a whole identifier token
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// importLinePattern recognises lines where a changed string literal is a module path
+var importLinePattern = regexp.MustCompile(`\b(import|require|from|include|package|use)\b`)
+
+// ClassifyChanges marks each changed file as mechanical or behavioral from its line diff
+func ClassifyChanges(changes []types.FileChange, diffs map[string]types.LineDiff) {
+	for i := range changes {
+		if !changes[i].IsChanged {
+			continue
+		}
+		diff, ok := diffs[changes[i].Path]
+		changes[i].Kind = classifyChange(changes[i], diff, ok)
+	}
+}
+
+// classifyChange applies the heuristics: pure renames/moves, whitespace-only edits, and
+// consistent identifier or import path substitutions are mechanical; everything else is behavioral
+func classifyChange(change types.FileChange, diff types.LineDiff, hasDiff bool) types.ChangeKind {
+	switch change.ChangeType {
+	case types.ChangeTypeAdd, types.ChangeTypeDelete:
+		return types.ChangeKindBehavioral
+	case types.ChangeTypeRename:
+		if !hasDiff || len(diff.Hunks) == 0 {
+			return types.ChangeKindMechanical
+		}
+	default:
+		if !hasDiff || len(diff.Hunks) == 0 {
+			return types.ChangeKindBehavioral // binary or unreadable diff
+		}
+	}
+
+	if isWhitespaceOnly(diff) || isConsistentRename(diff, change.Content) {
+		return types.ChangeKindMechanical
+	}
+	return types.ChangeKindBehavioral
+}
+
+// isWhitespaceOnly reports whether removing all whitespace makes the old and new text identical
+func isWhitespaceOnly(diff types.LineDiff) bool {
+	var removed, added strings.Builder
+	for _, hunk := range diff.Hunks {
+		for _, line := range hunk.Removed {
+			removed.WriteString(strings.Join(strings.Fields(line), ""))
+		}
+		for _, line := range hunk.Added {
+			added.WriteString(strings.Join(strings.Fields(line), ""))
+		}
+	}
+	return removed.String() == added.String()
+}
+
+// isConsistentRename reports whether every changed line differs only by a small, consistent set of
+// identifier substitutions (or module path strings on import lines), and no old name survives in the file
+func isConsistentRename(diff types.LineDiff, newContent string) bool {
+	renames := make(map[string]string)
+
+	for _, hunk := range diff.Hunks {
+		if len(hunk.Removed) != len(hunk.Added) {
+			return false
+		}
+
+		for i := range hunk.Removed {
+			oldTokens := tokenPattern.FindAllString(hunk.Removed[i], -1)
+			newTokens := tokenPattern.FindAllString(hunk.Added[i], -1)
+			if len(oldTokens) != len(newTokens) {
+				return false
+			}
+
+			for j := range oldTokens {
+				oldToken, newToken := oldTokens[j], newTokens[j]
+				switch {
+				case oldToken == newToken:
+				case identifierPattern.MatchString(oldToken) && identifierPattern.MatchString(newToken):
+					if previous, seen := renames[oldToken]; seen && previous != newToken {
+						return false
+					}
+					renames[oldToken] = newToken
+				case isStringLiteral(oldToken) && isStringLiteral(newToken) && importLinePattern.MatchString(hunk.Added[i]):
+				default:
+					return false
+				}
+			}
+		}
+	}
+
+	if len(renames) > maxIdentifierRenames {
+		return false
+	}
+
+	// A rename that leaves the old name in use elsewhere is a logic change
+	newTokens := make(map[string]bool)
+	for _, token := range tokenPattern.FindAllString(newContent, -1) {
+		newTokens[token] = true
+	}
+	for oldName := range renames {
+		if newTokens[oldName] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isStringLiteral(token string) bool {
+	return len(token) >= 2 && strings.ContainsRune("\"'`", rune(token[0]))
+}
+
+// ChangeMix counts the mechanical and behavioral files in a partition
+func ChangeMix(partition types.Partition) (mechanical, behavioral int) {
+	for _, file := range partition.Files {
+		switch file.Kind {
+		case types.ChangeKindMechanical:
+			mechanical++
+		case types.ChangeKindBehavioral:
+			behavioral++
+		}
+	}
+	return mechanical, behavioral
+}
+
+// IsHeavilyMixed reports whether a partition has a substantial share of both kinds of change
+func IsHeavilyMixed(partition types.Partition) bool {
+	mechanical, behavioral := ChangeMix(partition)
+	if mechanical == 0 || behavioral == 0 {
+		return false
+	}
+
+	minority := mechanical
+	if behavioral < minority {
+		minority = behavioral
+	}
+	return float64(minority)/float64(mechanical+behavioral) >= mixedChangeShare
+}
+
+// separableMechanicalFiles returns mechanical files that depend only on other mechanical files,
+// so they can land in partitions ahead of the behavioral changes without breaking the build
+func (p *Partitioner) separableMechanicalFiles(files []types.FileChange, graph *types.DependencyGraph) map[string]bool {
+	separable := make(map[string]bool)
+	for _, file := range files {
+		if file.Kind == types.ChangeKindMechanical {
+			separable[file.Path] = true
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for path := range separable {
+			for _, dep := range graph.Adjacency[path] {
+				if !separable[dep] {
+					delete(separable, path)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	return separable
+}
+
+// createMechanicalPartitions puts separable mechanical files into their own leading partitions
+func (p *Partitioner) createMechanicalPartitions(files []types.FileChange, graph *types.DependencyGraph, cfg *types.Config, allocated map[string]bool) []types.Partition {
+	separable := p.separableMechanicalFiles(files, graph)
+	if len(separable) == 0 {
+		return nil
+	}
+
+	var mechanicalFiles []types.FileChange
+	for _, file := range files {
+		if separable[file.Path] {
+			mechanicalFiles = append(mechanicalFiles, file)
+			allocated[file.Path] = true
+		}
+	}
+
+	fmt.Fprintf(p.out, "🧹 Separating %d mechanical changes into their own partitions\n", len(mechanicalFiles))
+
+	partitions := p.createSimplePartitions(mechanicalFiles, 0, cfg, "mechanical")
+	for i := range partitions {
+		partitions[i].Description = fmt.Sprintf("Mechanical changes: renames, moves, formatting (%d files)", len(partitions[i].Files))
+	}
+	return partitions
+}
@@ -0,0 +1,56 @@
+package partition
+
+import (
+	"fmt"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// RenderMermaid draws the partition stack as a Mermaid flowchart, with arrows pointing from each
+// partition to the partitions built on top of it. With files set, each partition becomes a
+// subgraph listing its files.
+func RenderMermaid(plan *types.PartitionPlan, files bool) string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	for _, p := range plan.Partitions {
+		label := fmt.Sprintf("%d · %s (%d files)", p.ID, partitionTitle(p), len(p.Files))
+		if !files {
+			fmt.Fprintf(&b, "    p%d[\"%s\"]\n", p.ID, mermaidEscape(label))
+			continue
+		}
+
+		fmt.Fprintf(&b, "    subgraph p%d [\"%s\"]\n", p.ID, mermaidEscape(label))
+		for i, file := range p.Files {
+			fmt.Fprintf(&b, "        p%df%d[\"%s\"]\n", p.ID, i+1, mermaidEscape(file.Path))
+		}
+		b.WriteString("    end\n")
+	}
+
+	for _, p := range plan.Partitions {
+		for _, dep := range p.Dependencies {
+			fmt.Fprintf(&b, "    p%d --> p%d\n", dep, p.ID)
+		}
+	}
+
+	return b.String()
+}
+
+// MermaidBlock wraps a diagram in a fenced block that GitHub renders in PR descriptions
+func MermaidBlock(diagram string) string {
+	return "```mermaid\n" + diagram + "```\n"
+}
+
+// partitionTitle prefers the stable slug and falls back to the generated name
+func partitionTitle(p types.Partition) string {
+	if p.Slug != "" {
+		return p.Slug
+	}
+	return p.Name
+}
+
+// mermaidEscape replaces characters that would end a quoted Mermaid label
+func mermaidEscape(label string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(label)
+}
@@ -0,0 +1,275 @@
+package partition
+
+import (
+	"sort"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// maxRefinementPasses bounds the Kernighan–Lin style refinement
+const maxRefinementPasses = 10
+
+// communityGraph tracks a size-capped assignment of files to communities
+type communityGraph struct {
+	nodes     []string
+	weights   map[string]map[string]float64 // Undirected edge weights between files
+	adjacency map[string][]string           // Directed edges, from dependent to dependency
+	community map[string]int
+	maxSize   int
+}
+
+// createMinCutPartitions groups files so that as few dependency edges as possible cross partition
+// boundaries. Communities are grown by greedily contracting the heaviest edges, refined by moving
+// single files to the community they are most connected to, and finally packed in dependency order.
+// Communities never form a cycle, so the partitions can still be stacked.
+func (p *Partitioner) createMinCutPartitions(files []types.FileChange, graph *types.DependencyGraph, existingPartitions []types.Partition, cfg *types.Config) []types.Partition {
+	g := newCommunityGraph(p.getFilePaths(files), graph, cfg.MaxFilesPerPartition)
+	g.contract()
+	g.refine()
+
+	var partitions []types.Partition
+	for _, members := range g.packInOrder() {
+		partitionFiles := p.getFilesByPaths(files, members)
+		partitions = append(partitions, types.Partition{
+			ID:           len(existingPartitions) + len(partitions) + 1,
+			Name:         p.generateName(partitionFiles),
+			Description:  p.generateDescription(partitionFiles),
+			Files:        partitionFiles,
+			Dependencies: p.calculateDependencies(members, append(existingPartitions, partitions...)),
+		})
+	}
+	return partitions
+}
+
+// CountCutEdges counts dependency edges whose endpoints are in different partitions
+func CountCutEdges(partitions []types.Partition, dependencies []types.Dependency) int {
+	owner := make(map[string]int)
+	for i, partition := range partitions {
+		for _, file := range partition.Files {
+			owner[file.Path] = i
+		}
+	}
+
+	cut := 0
+	for _, dep := range dependencies {
+		from, fromOK := owner[dep.From]
+		to, toOK := owner[dep.To]
+		if fromOK && toOK && from != to {
+			cut++
+		}
+	}
+	return cut
+}
+
+// newCommunityGraph puts every file in its own community
+func newCommunityGraph(paths []string, graph *types.DependencyGraph, maxSize int) *communityGraph {
+	sort.Strings(paths)
+	g := &communityGraph{
+		nodes:     paths,
+		weights:   make(map[string]map[string]float64),
+		adjacency: make(map[string][]string),
+		community: make(map[string]int),
+		maxSize:   maxSize,
+	}
+
+	for i, path := range paths {
+		g.community[path] = i
+		g.weights[path] = make(map[string]float64)
+	}
+
+	for _, dep := range graph.Edges {
+		if _, ok := g.community[dep.From]; !ok || dep.From == dep.To {
+			continue
+		}
+		if _, ok := g.community[dep.To]; !ok {
+			continue
+		}
+		weight := float64(dep.Strength.Rank())
+		if weight == 0 {
+			weight = 1
+		}
+		g.weights[dep.From][dep.To] += weight
+		g.weights[dep.To][dep.From] += weight
+		g.adjacency[dep.From] = append(g.adjacency[dep.From], dep.To)
+	}
+
+	return g
+}
+
+// members groups files by community
+func (g *communityGraph) members() map[int][]string {
+	members := make(map[int][]string)
+	for _, path := range g.nodes {
+		members[g.community[path]] = append(members[g.community[path]], path)
+	}
+	return members
+}
+
+// contract merges the pair of communities joined by the heaviest total edge weight until no pair
+// fits within the size limit without creating a cycle between communities
+func (g *communityGraph) contract() {
+	for {
+		members := g.members()
+		between := make(map[[2]int]float64)
+		for from, neighbours := range g.weights {
+			for to, weight := range neighbours {
+				a, b := g.community[from], g.community[to]
+				if a < b {
+					between[[2]int{a, b}] += weight
+				}
+			}
+		}
+
+		pairs := make([][2]int, 0, len(between))
+		for pair := range between {
+			pairs = append(pairs, pair)
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			if between[pairs[i]] != between[pairs[j]] {
+				return between[pairs[i]] > between[pairs[j]]
+			}
+			if pairs[i][0] != pairs[j][0] {
+				return pairs[i][0] < pairs[j][0]
+			}
+			return pairs[i][1] < pairs[j][1]
+		})
+
+		merged := false
+		for _, pair := range pairs {
+			if len(members[pair[0]])+len(members[pair[1]]) > g.maxSize {
+				continue
+			}
+			for _, path := range members[pair[1]] {
+				g.community[path] = pair[0]
+			}
+			if g.isAcyclic() {
+				merged = true
+				break
+			}
+			for _, path := range members[pair[1]] {
+				g.community[path] = pair[1]
+			}
+		}
+		if !merged {
+			return
+		}
+	}
+}
+
+// refine moves single files to a neighbouring community when that removes more cut weight than it
+// adds, in the spirit of Kernighan–Lin
+func (g *communityGraph) refine() {
+	for pass := 0; pass < maxRefinementPasses; pass++ {
+		moved := false
+		for _, path := range g.nodes {
+			sizes := make(map[int]int)
+			for _, other := range g.nodes {
+				sizes[g.community[other]]++
+			}
+
+			home := g.community[path]
+			pull := make(map[int]float64)
+			for neighbour, weight := range g.weights[path] {
+				pull[g.community[neighbour]] += weight
+			}
+
+			candidates := make([]int, 0, len(pull))
+			for community := range pull {
+				candidates = append(candidates, community)
+			}
+			sort.Ints(candidates)
+
+			for _, target := range candidates {
+				if target == home || pull[target] <= pull[home] || sizes[target]+1 > g.maxSize {
+					continue
+				}
+				g.community[path] = target
+				if g.isAcyclic() {
+					moved = true
+					break
+				}
+				g.community[path] = home
+			}
+		}
+		if !moved {
+			return
+		}
+	}
+}
+
+// communityOrder returns communities in dependency order, dependencies first, or nil on a cycle
+func (g *communityGraph) communityOrder() []int {
+	dependents := make(map[int]map[int]bool)
+	pending := make(map[int]int)
+	for _, path := range g.nodes {
+		pending[g.community[path]] += 0
+	}
+	for from, targets := range g.adjacency {
+		for _, to := range targets {
+			a, b := g.community[from], g.community[to]
+			if a == b {
+				continue
+			}
+			if dependents[b] == nil {
+				dependents[b] = make(map[int]bool)
+			}
+			if !dependents[b][a] {
+				dependents[b][a] = true
+				pending[a]++
+			}
+		}
+	}
+
+	var ready []int
+	for community, count := range pending {
+		if count == 0 {
+			ready = append(ready, community)
+		}
+	}
+
+	var order []int
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		next := ready[0]
+		ready = ready[1:]
+		order = append(order, next)
+		for dependent := range dependents[next] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(pending) {
+		return nil
+	}
+	return order
+}
+
+// isAcyclic reports whether the communities can still be ordered by dependency
+func (g *communityGraph) isAcyclic() bool {
+	return g.communityOrder() != nil
+}
+
+// packInOrder combines consecutive communities in dependency order up to the size limit. Merging
+// neighbours in a topological order cannot create a cycle, so leftover singletons do not each
+// become their own partition.
+func (g *communityGraph) packInOrder() [][]string {
+	members := g.members()
+
+	var packed [][]string
+	var current []string
+	for _, community := range g.communityOrder() {
+		files := members[community]
+		if len(current) > 0 && len(current)+len(files) > g.maxSize {
+			packed = append(packed, current)
+			current = nil
+		}
+		current = append(current, files...)
+	}
+	if len(current) > 0 {
+		packed = append(packed, current)
+	}
+	return packed
+}
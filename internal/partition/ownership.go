@@ -0,0 +1,111 @@
+package partition
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// OwnershipHistoryDepth bounds how many recent commits are read to find owners
+const OwnershipHistoryDepth = 300
+
+// unownedKey groups files with no history in their directory
+const unownedKey = "unowned"
+
+// ResolveOwners picks the predominant recent author of every changed file, mapped to a team when
+// the author belongs to one. New files inherit the predominant owner of their directory.
+func ResolveOwners(files []types.FileChange, authorCounts map[string]map[string]int, teams map[string][]string) map[string]string {
+	teamOf := make(map[string]string)
+	for team, members := range teams {
+		for _, member := range members {
+			teamOf[strings.ToLower(member)] = team
+		}
+	}
+	ownerOf := func(author string) string {
+		if team, ok := teamOf[author]; ok {
+			return team
+		}
+		return author
+	}
+
+	owners := make(map[string]string)
+	dirCounts := make(map[string]map[string]int)
+
+	for _, file := range files {
+		counts := authorCounts[file.Path]
+		if file.OldPath != "" && len(counts) == 0 {
+			counts = authorCounts[file.OldPath]
+		}
+		if len(counts) == 0 {
+			continue
+		}
+
+		byOwner := make(map[string]int)
+		for author, count := range counts {
+			byOwner[ownerOf(author)] += count
+		}
+		owners[file.Path] = predominant(byOwner)
+
+		dir := path.Dir(file.Path)
+		if dirCounts[dir] == nil {
+			dirCounts[dir] = make(map[string]int)
+		}
+		for owner, count := range byOwner {
+			dirCounts[dir][owner] += count
+		}
+	}
+
+	for _, file := range files {
+		if _, ok := owners[file.Path]; ok {
+			continue
+		}
+		if counts := dirCounts[path.Dir(file.Path)]; len(counts) > 0 {
+			owners[file.Path] = predominant(counts)
+		} else {
+			owners[file.Path] = unownedKey
+		}
+	}
+
+	return owners
+}
+
+// SetFileOwners provides file owners for the ownership strategy
+func (p *Partitioner) SetFileOwners(owners map[string]string) {
+	p.owners = owners
+}
+
+// assignOwners records the predominant owner of each partition so it can be routed to a reviewer
+func (p *Partitioner) assignOwners(partitions []types.Partition) {
+	if len(p.owners) == 0 {
+		return
+	}
+
+	for i := range partitions {
+		counts := make(map[string]int)
+		for _, file := range partitions[i].Files {
+			if owner := p.owners[file.Path]; owner != "" && owner != unownedKey {
+				counts[owner]++
+			}
+		}
+		if len(counts) > 0 {
+			partitions[i].Owner = predominant(counts)
+		}
+	}
+}
+
+// predominant returns the key with the highest count, breaking ties alphabetically
+func predominant(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys[0]
+}
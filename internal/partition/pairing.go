@@ -0,0 +1,174 @@
+package partition
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// testPathPatterns mark test files by name, e.g. foo.test.ts or foo_test.go, or by directory
+var testPathPatterns = []string{
+	".test.",
+	".spec.",
+	"_test.",
+	"_spec.",
+	"/test/",
+	"/tests/",
+	"/spec/",
+	"/specs/",
+	"/__tests__/",
+}
+
+// testDirectories hold tests next to the directory of their sources, e.g. src/__tests__
+var testDirectories = map[string]bool{
+	"test": true, "tests": true, "__tests__": true, "spec": true, "specs": true,
+}
+
+// IsTestFile reports whether a path is a test file, by its name or a test directory it is in
+func IsTestFile(filePath string) bool {
+	lowerPath := strings.ToLower(filePath)
+	for _, pattern := range testPathPatterns {
+		if strings.Contains(lowerPath, pattern) {
+			return true
+		}
+	}
+	base := path.Base(lowerPath)
+	return strings.HasPrefix(base, "test_") && strings.HasSuffix(base, ".py")
+}
+
+// fileStem returns the lower-case base name of a path without its extension and, for a test
+// file, without its test marker: "src/Cart.test.tsx", "cart_test.go" and "test_cart.py" all give "cart"
+func fileStem(filePath string) string {
+	base := strings.ToLower(path.Base(filePath))
+	name := strings.TrimSuffix(base, path.Ext(base))
+	for _, marker := range []string{".test", ".spec", "_test", "_spec"} {
+		name = strings.TrimSuffix(name, marker)
+	}
+	return strings.TrimPrefix(name, "test_")
+}
+
+// testedSource finds the changed source file a test file tests: a file with the same stem in the
+// test's directory or, for a test directory like __tests__, its parent; failing that the only
+// changed file with that stem anywhere. It returns an empty string when there is none.
+func testedSource(testPath string, sourcesByStem map[string][]string) string {
+	candidates := sourcesByStem[fileStem(testPath)]
+
+	dir := path.Dir(testPath)
+	dirs := map[string]bool{dir: true}
+	if testDirectories[strings.ToLower(path.Base(dir))] {
+		dirs[path.Dir(dir)] = true
+	}
+	for _, candidate := range candidates {
+		if dirs[path.Dir(candidate)] {
+			return candidate
+		}
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	return ""
+}
+
+// pairTestFiles moves each changed test file into the partition of the source file it tests, so
+// a partition ships with its tests. A test stays where it is when other files depend on it or it
+// depends on files the source's partition does not, as moving it could make partitions depend on
+// each other; partitions left empty are dropped.
+func (p *Partitioner) pairTestFiles(partitions []types.Partition) []types.Partition {
+	owner := make(map[string]int)
+	sourcesByStem := make(map[string][]string)
+	generated := make([]bool, len(partitions))
+	for i, partition := range partitions {
+		generated[i] = partition.Description == p.generateDescription(partition.Files)
+		for _, file := range partition.Files {
+			owner[file.Path] = i
+			if file.IsChanged && !IsTestFile(file.Path) {
+				stem := fileStem(file.Path)
+				sourcesByStem[stem] = append(sourcesByStem[stem], file.Path)
+			}
+		}
+	}
+
+	moved := 0
+	changed := make(map[int]bool)
+	for i := range partitions {
+		var kept []types.FileChange
+		for _, file := range partitions[i].Files {
+			target := -1
+			if file.IsChanged && IsTestFile(file.Path) {
+				if source := testedSource(file.Path, sourcesByStem); source != "" {
+					target = owner[source]
+				}
+			}
+			if target < 0 || target == i || !p.canJoin(file.Path, partitions[target]) {
+				kept = append(kept, file)
+				continue
+			}
+			partitions[target].Files = append(partitions[target].Files, file)
+			owner[file.Path] = target
+			changed[i], changed[target] = true, true
+			moved++
+		}
+		partitions[i].Files = kept
+	}
+
+	if moved == 0 {
+		return partitions
+	}
+	fmt.Fprintf(p.out, "🧪 Paired %d test files with the sources they test\n", moved)
+
+	var paired []types.Partition
+	for i, partition := range partitions {
+		if len(partition.Files) == 0 {
+			continue
+		}
+		if changed[i] {
+			if generated[i] {
+				partition.Description = p.generateDescription(partition.Files)
+			} else {
+				partition.Description = recountDescription(partition.Description, len(partition.Files))
+			}
+		}
+		paired = append(paired, partition)
+	}
+	return paired
+}
+
+// canJoin reports whether a file can move into a partition without adding a dependency between
+// partitions: nothing depends on the file, and every file it depends on is in the partition or
+// already a dependency of it
+func (p *Partitioner) canJoin(filePath string, partition types.Partition) bool {
+	if p.graph == nil {
+		return true
+	}
+	if p.graph.InDegree[filePath] > 0 {
+		return false
+	}
+
+	// Files the partition already reaches through the dependency graph
+	reached := make(map[string]bool)
+	var queue []string
+	for _, file := range partition.Files {
+		reached[file.Path] = true
+		queue = append(queue, file.Path)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range p.graph.Adjacency[current] {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	for _, dependency := range p.graph.Adjacency[filePath] {
+		if !reached[dependency] {
+			return false
+		}
+	}
+	return true
+}
@@ -2,21 +2,44 @@ package partition
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
 	"sort"
 	"time"
 
 	"pr-splitter-cli/internal/config"
 	"pr-splitter-cli/internal/types"
+	"pr-splitter-cli/internal/workspace"
 )
 
 // Partitioner creates logical partitions based on dependencies
 type Partitioner struct {
 	depthCache map[string]int
+	graph      *types.DependencyGraph    // Dependency graph of the plan being created
+	owners     map[string]string         // File path to predominant owner, used by the ownership strategy
+	packages   []workspace.Package       // Monorepo packages, used by the workspace strategy
+	lineDiffs  map[string]types.LineDiff // Source diff hunks, used for hunk-level splitting
+	out        io.Writer                 // Progress output
+
+	// approveCycle decides whether a circular group larger than the partition limit may stay together
+	approveCycle func(files []string, size, limit int, suggestions []string) (bool, error)
 }
 
 // NewPartitioner creates a new partitioner instance
 func NewPartitioner() *Partitioner {
-	return &Partitioner{}
+	return &Partitioner{out: os.Stdout, approveCycle: config.PromptForSCCDecision}
+}
+
+// SetOutput sends progress output to w instead of stdout
+func (p *Partitioner) SetOutput(w io.Writer) {
+	p.out = w
+}
+
+// SetCycleApproval replaces the prompt asking whether an oversized circular group may stay together
+func (p *Partitioner) SetCycleApproval(approve func(files []string, size, limit int, suggestions []string) (bool, error)) {
+	p.approveCycle = approve
 }
 
 // CreatePlan creates a partition plan based on file changes and dependencies
@@ -28,19 +51,44 @@ func (p *Partitioner) CreatePlan(changes []types.FileChange, dependencies []type
 		return nil, fmt.Errorf("no changed files to partition")
 	}
 
-	fmt.Printf("📊 Partitioning %d changed files with %d dependencies\n", len(changedFiles), len(dependencies))
+	if err := config.ValidateStrategy(cfg.Strategy); err != nil {
+		return nil, err
+	}
+	if err := config.ValidateTopology(cfg.Topology); err != nil {
+		return nil, err
+	}
+	if err := config.ValidateBinaryFiles(cfg.BinaryFiles); err != nil {
+		return nil, err
+	}
+	if cfg.MinDependencyStrength != "" {
+		if cfg.MinDependencyStrength.Rank() == 0 {
+			return nil, fmt.Errorf("invalid minimum dependency strength '%s' (expected WEAK, MODERATE, STRONG or CRITICAL)", cfg.MinDependencyStrength)
+		}
+		kept := FilterByStrength(dependencies, cfg.MinDependencyStrength)
+		if dropped := len(dependencies) - len(kept); dropped > 0 {
+			fmt.Fprintf(p.out, "🪶 Ignoring %d dependencies weaker than %s\n", dropped, cfg.MinDependencyStrength)
+		}
+		dependencies = kept
+	}
+
+	if cfg.Strategy == types.StrategyWorkspace {
+		dependencies = append(dependencies, p.workspaceDependencies(changedFiles)...)
+	}
+
+	fmt.Fprintf(p.out, "📊 Partitioning %d changed files with %d dependencies\n", len(changedFiles), len(dependencies))
 
 	graph, err := p.buildDependencyGraph(changedFiles, dependencies)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
 	}
+	p.graph = graph
 
 	sccs, err := p.findCircularDependencies(graph)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find circular dependencies: %w", err)
 	}
 
-	approvedSCCs, err := p.handleOversizedCircularGroups(sccs, cfg.MaxFilesPerPartition)
+	approvedSCCs, err := p.handleOversizedCircularGroups(sccs, graph, cfg.MaxFilesPerPartition)
 	if err != nil {
 		return nil, fmt.Errorf("failed to handle oversized circular groups: %w", err)
 	}
@@ -50,10 +98,34 @@ func (p *Partitioner) CreatePlan(changes []types.FileChange, dependencies []type
 		return nil, fmt.Errorf("failed to create partitions: %w", err)
 	}
 
+	// A workspace package is never split, not by lines nor by moving its tests
+	if cfg.Strategy != types.StrategyWorkspace || len(p.packages) == 0 {
+		partitions = p.balancePartitions(partitions, graph, approvedSCCs, cfg.MaxLinesPerPartition)
+		partitions = p.pairTestFiles(partitions)
+	}
+	partitions = p.orderByDependencies(partitions)
+
+	topology := cfg.Topology
+	if topology == "" {
+		topology = types.TopologyLinear
+	}
+	if err := checkTopology(partitions, topology); err != nil {
+		return nil, err
+	}
+
 	if err := p.validateExhaustiveness(changedFiles, partitions); err != nil {
 		return nil, fmt.Errorf("exhaustiveness validation failed: %w", err)
 	}
 
+	if count := AssignHunks(partitions, p.lineDiffs, cfg.SplitHunks); count > 0 {
+		fmt.Fprintf(p.out, "✂️  Split %d shared files across partitions by hunk\n", count)
+	}
+
+	AssignSlugs(partitions)
+	p.assignOwners(partitions)
+	NewBranchNamer(cfg).AssignBranchNames(partitions)
+	AssignChecklists(partitions, cfg)
+
 	return &types.PartitionPlan{
 		Partitions: partitions,
 		Metadata: types.PlanMetadata{
@@ -61,7 +133,10 @@ func (p *Partitioner) CreatePlan(changes []types.FileChange, dependencies []type
 			TotalPartitions:      len(partitions),
 			MaxFilesPerPartition: cfg.MaxFilesPerPartition,
 			Strategy:             cfg.Strategy,
+			Topology:             topology,
 			CreatedAt:            time.Now(),
+			DependencyEdges:      len(graph.Edges),
+			CutEdges:             CountCutEdges(partitions, graph.Edges),
 		},
 	}, nil
 }
@@ -77,6 +152,18 @@ func (p *Partitioner) filterChangedFiles(changes []types.FileChange) []types.Fil
 	return changedFiles
 }
 
+// FilterByStrength drops dependencies weaker than the given minimum so that loose "similar pattern"
+// edges cannot glue unrelated files into one circular group
+func FilterByStrength(dependencies []types.Dependency, minimum types.DependencyStrength) []types.Dependency {
+	var kept []types.Dependency
+	for _, dep := range dependencies {
+		if dep.Strength.Rank() >= minimum.Rank() {
+			kept = append(kept, dep)
+		}
+	}
+	return kept
+}
+
 // buildDependencyGraph creates a dependency graph from files and dependencies
 func (p *Partitioner) buildDependencyGraph(files []types.FileChange, dependencies []types.Dependency) (*types.DependencyGraph, error) {
 	nodeSet := make(map[string]bool)
@@ -131,9 +218,9 @@ func (p *Partitioner) findCircularDependencies(graph *types.DependencyGraph) ([]
 	})
 
 	if len(circularSCCs) > 0 {
-		fmt.Printf("🔄 Found %d circular dependency groups\n", len(circularSCCs))
+		fmt.Fprintf(p.out, "🔄 Found %d circular dependency groups\n", len(circularSCCs))
 		for i, scc := range circularSCCs {
-			fmt.Printf("   Group %d: %d files\n", i+1, scc.Size)
+			fmt.Fprintf(p.out, "   Group %d: %d files\n", i+1, scc.Size)
 		}
 	}
 
@@ -141,17 +228,21 @@ func (p *Partitioner) findCircularDependencies(graph *types.DependencyGraph) ([]
 }
 
 // handleOversizedCircularGroups prompts user for approval of large circular groups
-func (p *Partitioner) handleOversizedCircularGroups(sccs []types.StronglyConnectedComponent, maxSize int) ([]types.StronglyConnectedComponent, error) {
+func (p *Partitioner) handleOversizedCircularGroups(sccs []types.StronglyConnectedComponent, graph *types.DependencyGraph, maxSize int) ([]types.StronglyConnectedComponent, error) {
 	var approvedSCCs []types.StronglyConnectedComponent
 
 	for _, scc := range sccs {
 		if scc.Size > maxSize {
-			approved, err := config.PromptForSCCDecision(scc.Files, scc.Size, maxSize)
+			var suggestions []string
+			for _, edge := range SuggestCycleBreaks(scc.Files, graph.Edges, maxSize) {
+				suggestions = append(suggestions, DescribeEdge(edge))
+			}
+			approved, err := p.approveCycle(scc.Files, scc.Size, maxSize, suggestions)
 			if err != nil {
 				return nil, fmt.Errorf("SCC approval failed: %w", err)
 			}
 			if !approved {
-				return nil, fmt.Errorf("user rejected oversized SCC with %d files", scc.Size)
+				return nil, fmt.Errorf("%w: rejected circular group of %d files", ErrUserAborted, scc.Size)
 			}
 		}
 		approvedSCCs = append(approvedSCCs, scc)
@@ -160,17 +251,51 @@ func (p *Partitioner) handleOversizedCircularGroups(sccs []types.StronglyConnect
 	return approvedSCCs, nil
 }
 
-// createAllPartitions creates all partitions using the configured strategy
+// createAllPartitions creates all partitions using the configured strategy, then places binary files
 func (p *Partitioner) createAllPartitions(files []types.FileChange, graph *types.DependencyGraph, sccs []types.StronglyConnectedComponent, cfg *types.Config) ([]types.Partition, error) {
+	if cfg.Strategy == types.StrategyWorkspace && len(p.packages) > 0 {
+		return p.createWorkspacePartitions(files, sccs), nil
+	}
+
+	files, submodules := splitSubmodules(files)
+	files, binaries := splitBinaryFiles(files)
+	partitions, err := p.createCodePartitions(files, graph, sccs, cfg)
+	if err != nil {
+		return nil, err
+	}
+	partitions = p.placeBinaryFiles(partitions, binaries, cfg)
+	return p.placeSubmodules(partitions, submodules, cfg), nil
+}
+
+// createCodePartitions partitions the files with dependencies using the configured strategy
+func (p *Partitioner) createCodePartitions(files []types.FileChange, graph *types.DependencyGraph, sccs []types.StronglyConnectedComponent, cfg *types.Config) ([]types.Partition, error) {
 	var partitions []types.Partition
 	allocated := make(map[string]bool)
 
+	if cfg.Strategy == types.StrategyDirectory {
+		return p.createDirectoryFirstPartitions(files, cfg), nil
+	}
+
+	// Optionally land mechanical changes ahead of behavioral ones
+	if cfg.SeparateMechanical {
+		partitions = p.createMechanicalPartitions(files, graph, cfg, allocated)
+		sccs = p.unallocatedSCCs(sccs, allocated)
+	}
+
 	// First: Create partitions for circular dependency groups
-	partitions = p.createCircularDependencyPartitions(sccs, files, partitions, cfg, allocated)
+	partitions = append(partitions, p.createCircularDependencyPartitions(sccs, files, partitions, cfg, allocated)...)
 
 	// Second: Create dependency-based partitions for remaining files
 	remainingFiles := p.getRemainingFiles(files, allocated)
-	if len(remainingFiles) > 0 {
+	if len(remainingFiles) > 0 && cfg.Strategy == types.StrategyMinCut {
+		minCutPartitions := p.createMinCutPartitions(remainingFiles, graph, partitions, cfg)
+		partitions = append(partitions, minCutPartitions...)
+		for _, partition := range minCutPartitions {
+			for _, file := range partition.Files {
+				allocated[file.Path] = true
+			}
+		}
+	} else if len(remainingFiles) > 0 {
 		depPartitions, err := p.createDependencyPartitions(remainingFiles, graph, partitions, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create dependency partitions: %w", err)
@@ -188,7 +313,7 @@ func (p *Partitioner) createAllPartitions(files []types.FileChange, graph *types
 	// Third: Handle any remaining unallocated files
 	unallocatedFiles := p.getRemainingFiles(files, allocated)
 	if len(unallocatedFiles) > 0 {
-		fmt.Printf("📋 Creating partitions for %d unallocated files...\n", len(unallocatedFiles))
+		fmt.Fprintf(p.out, "📋 Creating partitions for %d unallocated files...\n", len(unallocatedFiles))
 		remainingPartitions := p.createRemainingFilePartitions(unallocatedFiles, partitions, cfg)
 		partitions = append(partitions, remainingPartitions...)
 	}
@@ -196,6 +321,28 @@ func (p *Partitioner) createAllPartitions(files []types.FileChange, graph *types
 	return partitions, nil
 }
 
+// createDirectoryFirstPartitions groups files by top-level directory or file type using the
+// FileGrouper. Dependencies are not used for grouping, only to order the partitions afterwards.
+// Groups over the size limit are split by package directory so siblings stay together.
+func (p *Partitioner) createDirectoryFirstPartitions(files []types.FileChange, cfg *types.Config) []types.Partition {
+	groups := NewFileGrouper().GroupFiles(files)
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var partitions []types.Partition
+	allocated := make(map[string]bool)
+	dirOf := func(file types.FileChange) string { return path.Dir(file.Path) }
+	for _, name := range names {
+		groupFiles := groups[name]
+		partitions = append(partitions, p.createGroupedPartitionsForDepth(p.getFilePaths(groupFiles), groupFiles, allocated, nil, partitions, cfg, dirOf, true)...)
+	}
+	return partitions
+}
+
 // createCircularDependencyPartitions creates partitions for circular dependency groups
 func (p *Partitioner) createCircularDependencyPartitions(sccs []types.StronglyConnectedComponent, files []types.FileChange, existingPartitions []types.Partition, cfg *types.Config, allocated map[string]bool) []types.Partition {
 	var partitions []types.Partition
@@ -211,7 +358,6 @@ func (p *Partitioner) createCircularDependencyPartitions(sccs []types.StronglyCo
 			Dependencies: p.calculateDependencies(scc.Files, append(existingPartitions, partitions...)),
 		}
 
-		partition.BranchName = fmt.Sprintf("%s-%d-%s", cfg.BranchPrefix, partition.ID, partition.Name)
 		partitions = append(partitions, partition)
 
 		// Mark files as allocated
@@ -223,6 +369,18 @@ func (p *Partitioner) createCircularDependencyPartitions(sccs []types.StronglyCo
 	return partitions
 }
 
+// unallocatedSCCs drops circular groups whose files were already placed. Mechanical separation
+// keeps dependency-closed sets, so a group is either entirely placed or not at all.
+func (p *Partitioner) unallocatedSCCs(sccs []types.StronglyConnectedComponent, allocated map[string]bool) []types.StronglyConnectedComponent {
+	var remaining []types.StronglyConnectedComponent
+	for _, scc := range sccs {
+		if len(scc.Files) > 0 && !allocated[scc.Files[0]] {
+			remaining = append(remaining, scc)
+		}
+	}
+	return remaining
+}
+
 // createDependencyPartitions creates partitions based on dependency depth
 func (p *Partitioner) createDependencyPartitions(files []types.FileChange, graph *types.DependencyGraph, existingPartitions []types.Partition, cfg *types.Config) ([]types.Partition, error) {
 	var partitions []types.Partition
@@ -236,7 +394,7 @@ func (p *Partitioner) createDependencyPartitions(files []types.FileChange, graph
 	willExceedCapacity := totalFiles > maxCapacity
 
 	if willExceedCapacity {
-		fmt.Printf("⚠️  Warning: %d files may exceed capacity (%d max)\n", totalFiles, maxCapacity)
+		fmt.Fprintf(p.out, "⚠️  Warning: %d files may exceed capacity (%d max)\n", totalFiles, maxCapacity)
 	}
 
 	// Process files by dependency depth
@@ -258,6 +416,19 @@ func (p *Partitioner) createDependencyPartitions(files []types.FileChange, graph
 
 // createPartitionForDepth creates a partition for files at a specific dependency depth
 func (p *Partitioner) createPartitionForDepth(depthFiles []string, allFiles []types.FileChange, allocated map[string]bool, existingPartitions, currentPartitions []types.Partition, cfg *types.Config) []types.Partition {
+	if cfg.Strategy == types.StrategyOwnership && len(p.owners) > 0 {
+		ownerOf := func(file types.FileChange) string { return p.owners[file.Path] }
+		return p.createGroupedPartitionsForDepth(depthFiles, allFiles, allocated, existingPartitions, currentPartitions, cfg, ownerOf, false)
+	}
+	if cfg.Strategy == types.StrategySemantic {
+		clusterOf := p.semanticClusters(depthFiles, allFiles, allocated, cfg)
+		return p.createGroupedPartitionsForDepth(depthFiles, allFiles, allocated, existingPartitions, currentPartitions, cfg, clusterOf, false)
+	}
+	if cfg.GroupByDirectory {
+		dirOf := func(file types.FileChange) string { return path.Dir(file.Path) }
+		return p.createGroupedPartitionsForDepth(depthFiles, allFiles, allocated, existingPartitions, currentPartitions, cfg, dirOf, true)
+	}
+
 	var partitionFiles []types.FileChange
 
 	for _, filePath := range depthFiles {
@@ -287,10 +458,70 @@ func (p *Partitioner) createPartitionForDepth(depthFiles []string, allFiles []ty
 		Dependencies: p.calculateDependencies(p.getFilePaths(partitionFiles), append(existingPartitions, currentPartitions...)),
 	}
 
-	partition.BranchName = fmt.Sprintf("%s-%d-%s", cfg.BranchPrefix, partition.ID, partition.Name)
 	return []types.Partition{partition}
 }
 
+// createGroupedPartitionsForDepth splits a depth level by a secondary key (directory or owner)
+// instead of truncating it. With pack set, groups are packed in key order so siblings share a
+// partition; otherwise each group gets its own partitions. A group larger than the size limit is
+// split on its own.
+func (p *Partitioner) createGroupedPartitionsForDepth(depthFiles []string, allFiles []types.FileChange, allocated map[string]bool, existingPartitions, currentPartitions []types.Partition, cfg *types.Config, keyOf func(types.FileChange) string, pack bool) []types.Partition {
+	byKey := make(map[string][]types.FileChange)
+	for _, filePath := range depthFiles {
+		if allocated[filePath] {
+			continue
+		}
+		if file := p.getFileByPath(allFiles, filePath); file != nil {
+			key := keyOf(*file)
+			byKey[key] = append(byKey[key], *file)
+		}
+	}
+
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var batches [][]types.FileChange
+	var batch []types.FileChange
+	for _, key := range keys {
+		files := byKey[key]
+		sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+		if len(batch) > 0 && (!pack || len(batch)+len(files) > cfg.MaxFilesPerPartition) {
+			batches = append(batches, batch)
+			batch = nil
+		}
+		for len(files) > cfg.MaxFilesPerPartition {
+			batches = append(batches, files[:cfg.MaxFilesPerPartition])
+			files = files[cfg.MaxFilesPerPartition:]
+		}
+		batch = append(batch, files...)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	var partitions []types.Partition
+	for _, files := range batches {
+		for _, file := range files {
+			allocated[file.Path] = true
+		}
+
+		previous := append(append([]types.Partition{}, existingPartitions...), currentPartitions...)
+		partitions = append(partitions, types.Partition{
+			ID:           len(existingPartitions) + len(currentPartitions) + len(partitions) + 1,
+			Name:         p.generateName(files),
+			Description:  p.generateDescription(files),
+			Files:        files,
+			Dependencies: p.calculateDependencies(p.getFilePaths(files), append(previous, partitions...)),
+		})
+	}
+
+	return partitions
+}
+
 // createRemainingFilePartitions creates simple partitions for unallocated files
 func (p *Partitioner) createRemainingFilePartitions(files []types.FileChange, existingPartitions []types.Partition, cfg *types.Config) []types.Partition {
 	fileGrouper := NewFileGrouper()
@@ -330,7 +561,6 @@ func (p *Partitioner) createSimplePartitions(files []types.FileChange, startID i
 			Description:  fmt.Sprintf("%s files (%d files)", baseName, len(partitionFiles)),
 			Files:        partitionFiles,
 			Dependencies: []int{},
-			BranchName:   fmt.Sprintf("%s-%d-%s", cfg.BranchPrefix, startID+len(partitions)+1, name),
 		}
 
 		partitions = append(partitions, partition)
@@ -448,9 +678,31 @@ func (p *Partitioner) calculateDependencyDepth(node string, graph *types.Depende
 	return maxDepth
 }
 
+// calculateDependencies returns the IDs of partitions holding files that the given files depend on
 func (p *Partitioner) calculateDependencies(filePaths []string, existingPartitions []types.Partition) []int {
-	// Simplified dependency calculation - can be enhanced
-	return []int{}
+	dependencies := []int{}
+	if p.graph == nil {
+		return dependencies
+	}
+
+	owner := make(map[string]int)
+	for _, partition := range existingPartitions {
+		for _, file := range partition.Files {
+			owner[file.Path] = partition.ID
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, filePath := range filePaths {
+		for _, target := range p.graph.Adjacency[filePath] {
+			if id, ok := owner[target]; ok && !seen[id] {
+				seen[id] = true
+				dependencies = append(dependencies, id)
+			}
+		}
+	}
+	sort.Ints(dependencies)
+	return dependencies
 }
 
 func (p *Partitioner) generateName(files []types.FileChange) string {
@@ -462,3 +714,12 @@ func (p *Partitioner) generateDescription(files []types.FileChange) string {
 	namer := NewPartitionNamer()
 	return namer.GenerateDescription(files)
 }
+
+// fileCountSuffix is the "(N files)" count that ends most partition descriptions
+var fileCountSuffix = regexp.MustCompile(` \(\d+ files\)$`)
+
+// recountDescription brings the file count at the end of a description up to date after files
+// moved in or out of its partition; descriptions without a count are returned as they are
+func recountDescription(description string, files int) string {
+	return fileCountSuffix.ReplaceAllString(description, fmt.Sprintf(" (%d files)", files))
+}
@@ -0,0 +1,51 @@
+package partition
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// SavePlan writes a partition plan as JSON, leaving out file contents to keep the file reviewable
+func SavePlan(plan *types.PartitionPlan, filePath string) error {
+	data, err := json.MarshalIndent(WithoutContents(plan), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+	if err := os.WriteFile(filePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write plan file: %w", err)
+	}
+	return nil
+}
+
+// WithoutContents copies a plan, dropping the file contents that LoadPlan does not need
+func WithoutContents(plan *types.PartitionPlan) *types.PartitionPlan {
+	stripped := *plan
+	stripped.Partitions = make([]types.Partition, len(plan.Partitions))
+	for i, partition := range plan.Partitions {
+		files := make([]types.FileChange, len(partition.Files))
+		for j, file := range partition.Files {
+			file.Content = ""
+			files[j] = file
+		}
+		partition.Files = files
+		stripped.Partitions[i] = partition
+	}
+	return &stripped
+}
+
+// LoadPlan reads a partition plan written by SavePlan
+func LoadPlan(filePath string) (*types.PartitionPlan, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+
+	var plan types.PartitionPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan file %s: %w", filePath, err)
+	}
+	return &plan, nil
+}
@@ -0,0 +1,200 @@
+package partition
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// semanticThreshold is the minimum cosine similarity for two clusters to be merged
+const semanticThreshold = 0.1
+
+// maxSemanticTokens bounds how much of a large file contributes to its vector
+const maxSemanticTokens = 5000
+
+// semanticStopWords are keywords common to most languages that carry no topic signal
+var semanticStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "func": true, "function": true, "return": true,
+	"const": true, "var": true, "let": true, "import": true, "export": true, "from": true,
+	"package": true, "class": true, "public": true, "private": true, "static": true,
+	"string": true, "int": true, "bool": true, "true": true, "false": true, "nil": true,
+	"null": true, "this": true, "new": true, "err": true, "error": true, "type": true,
+	"struct": true, "interface": true, "void": true, "else": true, "default": true,
+}
+
+// ClusterBySimilarity groups files whose paths and contents share vocabulary, using TF-IDF
+// vectors and average-linkage agglomerative clustering capped at maxSize files per cluster.
+// Clusters are returned sorted by their first path so results are deterministic.
+func ClusterBySimilarity(files []types.FileChange, maxSize int) [][]types.FileChange {
+	sorted := append([]types.FileChange(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	vectors := tfidfVectors(sorted)
+	similarity := make([][]float64, len(sorted))
+	for i := range sorted {
+		similarity[i] = make([]float64, len(sorted))
+		for j := 0; j < i; j++ {
+			similarity[i][j] = cosine(vectors[i], vectors[j])
+			similarity[j][i] = similarity[i][j]
+		}
+	}
+
+	clusters := make([][]int, len(sorted))
+	for i := range sorted {
+		clusters[i] = []int{i}
+	}
+
+	for {
+		bestA, bestB, best := -1, -1, semanticThreshold
+		for a := range clusters {
+			for b := a + 1; b < len(clusters); b++ {
+				if len(clusters[a])+len(clusters[b]) > maxSize {
+					continue
+				}
+				if score := averageLinkage(clusters[a], clusters[b], similarity); score >= best {
+					bestA, bestB, best = a, b, score
+				}
+			}
+		}
+		if bestA < 0 {
+			break
+		}
+		clusters[bestA] = append(clusters[bestA], clusters[bestB]...)
+		clusters = append(clusters[:bestB], clusters[bestB+1:]...)
+	}
+
+	result := make([][]types.FileChange, len(clusters))
+	for i, members := range clusters {
+		sort.Ints(members)
+		for _, member := range members {
+			result[i] = append(result[i], sorted[member])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i][0].Path < result[j][0].Path })
+	return result
+}
+
+// semanticClusters clusters the unallocated files of a depth level and returns a key function
+// mapping each file to its cluster. The key is the cluster's first path so keys sort stably.
+func (p *Partitioner) semanticClusters(depthFiles []string, allFiles []types.FileChange, allocated map[string]bool, cfg *types.Config) func(types.FileChange) string {
+	var files []types.FileChange
+	for _, filePath := range depthFiles {
+		if allocated[filePath] {
+			continue
+		}
+		if file := p.getFileByPath(allFiles, filePath); file != nil {
+			files = append(files, *file)
+		}
+	}
+
+	clusterKey := make(map[string]string)
+	for _, cluster := range ClusterBySimilarity(files, cfg.MaxFilesPerPartition) {
+		for _, file := range cluster {
+			clusterKey[file.Path] = cluster[0].Path
+		}
+	}
+	return func(file types.FileChange) string { return clusterKey[file.Path] }
+}
+
+// averageLinkage is the mean pairwise similarity between two clusters
+func averageLinkage(a, b []int, similarity [][]float64) float64 {
+	total := 0.0
+	for _, i := range a {
+		for _, j := range b {
+			total += similarity[i][j]
+		}
+	}
+	return total / float64(len(a)*len(b))
+}
+
+// tfidfVectors builds a normalized TF-IDF vector per file. Path tokens are weighted double so
+// files in the same feature area cluster even when their contents differ.
+func tfidfVectors(files []types.FileChange) []map[string]float64 {
+	termCounts := make([]map[string]float64, len(files))
+	documentFrequency := make(map[string]int)
+
+	for i, file := range files {
+		counts := make(map[string]float64)
+		for _, token := range semanticTokens(file.Path) {
+			counts[token] += 2
+		}
+		contentTokens := semanticTokens(file.Content)
+		if len(contentTokens) > maxSemanticTokens {
+			contentTokens = contentTokens[:maxSemanticTokens]
+		}
+		for _, token := range contentTokens {
+			counts[token]++
+		}
+		for token := range counts {
+			documentFrequency[token]++
+		}
+		termCounts[i] = counts
+	}
+
+	vectors := make([]map[string]float64, len(files))
+	for i, counts := range termCounts {
+		vector := make(map[string]float64)
+		norm := 0.0
+		for token, count := range counts {
+			weight := (1 + math.Log(count)) * math.Log(float64(len(files)+1)/float64(documentFrequency[token]))
+			if weight > 0 {
+				vector[token] = weight
+				norm += weight * weight
+			}
+		}
+		norm = math.Sqrt(norm)
+		for token := range vector {
+			vector[token] /= norm
+		}
+		vectors[i] = vector
+	}
+	return vectors
+}
+
+// cosine returns the dot product of two normalized vectors
+func cosine(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	dot := 0.0
+	for token, weight := range a {
+		dot += weight * b[token]
+	}
+	return dot
+}
+
+// semanticTokens splits text into lowercase words, breaking camelCase and snake_case identifiers
+func semanticTokens(text string) []string {
+	var tokens []string
+	var current []rune
+
+	flush := func() {
+		if len(current) >= 3 {
+			token := strings.ToLower(string(current))
+			if !semanticStopWords[token] {
+				tokens = append(tokens, token)
+			}
+		}
+		current = current[:0]
+	}
+
+	var prev rune
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r):
+			if unicode.IsUpper(r) && unicode.IsLower(prev) {
+				flush()
+			}
+			current = append(current, r)
+		default:
+			flush()
+		}
+		prev = r
+	}
+	flush()
+
+	return tokens
+}
@@ -0,0 +1,51 @@
+package partition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// AssignSlugs gives every partition a stable identifier derived from its name, e.g. "auth" or
+// "api-2". Unlike numeric IDs, slugs do not shift when a partition is inserted or removed elsewhere.
+func AssignSlugs(partitions []types.Partition) {
+	namer := NewPartitionNamer()
+	used := make(map[string]bool)
+
+	for i := range partitions {
+		base := namer.sanitizeName(partitions[i].Name)
+		slug := base
+		for n := 2; used[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[slug] = true
+		partitions[i].Slug = slug
+	}
+}
+
+// FindPartition looks up a partition by slug, falling back to its numeric ID
+func FindPartition(partitions []types.Partition, ref string) (*types.Partition, error) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "#")
+
+	for i := range partitions {
+		if partitions[i].Slug == ref {
+			return &partitions[i], nil
+		}
+	}
+
+	if id, err := strconv.Atoi(ref); err == nil {
+		for i := range partitions {
+			if partitions[i].ID == id {
+				return &partitions[i], nil
+			}
+		}
+	}
+
+	slugs := make([]string, len(partitions))
+	for i, partition := range partitions {
+		slugs[i] = partition.Slug
+	}
+	return nil, fmt.Errorf("no partition '%s' (available: %s)", ref, strings.Join(slugs, ", "))
+}
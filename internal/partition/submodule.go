@@ -0,0 +1,60 @@
+package partition
+
+import (
+	"fmt"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// gitmodulesFile registers the path and URL of each submodule
+const gitmodulesFile = ".gitmodules"
+
+// splitSubmodules separates submodule pointer changes, which have no content to analyze, from
+// the files partitioned by their dependencies
+func splitSubmodules(files []types.FileChange) (rest, submodules []types.FileChange) {
+	for _, file := range files {
+		if file.IsSubmodule {
+			submodules = append(submodules, file)
+		} else {
+			rest = append(rest, file)
+		}
+	}
+	return rest, submodules
+}
+
+// placeSubmodules adds submodule pointer changes to the partitions. While .gitmodules changes
+// they join its partition, so a submodule is added or removed together with its registration;
+// otherwise each joins the partition holding most changed files of its directory, and those that
+// fit nowhere get partitions of their own.
+func (p *Partitioner) placeSubmodules(partitions []types.Partition, submodules []types.FileChange, cfg *types.Config) []types.Partition {
+	if len(submodules) == 0 {
+		return partitions
+	}
+	fmt.Fprintf(p.out, "🔗 Placing %d submodule changes\n", len(submodules))
+
+	registry := -1
+	for i, partition := range partitions {
+		for _, file := range partition.Files {
+			if file.Path == gitmodulesFile && file.IsChanged {
+				registry = i
+			}
+		}
+	}
+
+	var homeless []types.FileChange
+	for _, file := range submodules {
+		if registry >= 0 {
+			p.addFile(&partitions[registry], file)
+			continue
+		}
+		if i := directoryPartition(partitions, file.Path, cfg.MaxFilesPerPartition); i >= 0 {
+			p.addFile(&partitions[i], file)
+			continue
+		}
+		homeless = append(homeless, file)
+	}
+	if len(homeless) > 0 {
+		partitions = prependPartitions(p.createGroupPartitions(homeless, cfg.MaxFilesPerPartition, "Submodule updates"), partitions)
+	}
+	return partitions
+}
@@ -0,0 +1,98 @@
+package partition
+
+import (
+	"fmt"
+	"sort"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// orderByDependencies recomputes every partition's dependencies against the final partition set,
+// then reorders partitions so dependencies come first and renumbers them. Partitions created early
+// (circular groups, mechanical changes) may depend on files that were placed later.
+func (p *Partitioner) orderByDependencies(partitions []types.Partition) []types.Partition {
+	dependsOn := make([][]int, len(partitions))
+	for i, partition := range partitions {
+		others := make([]types.Partition, 0, len(partitions)-1)
+		for j, other := range partitions {
+			if j != i {
+				other.ID = j
+				others = append(others, other)
+			}
+		}
+		dependsOn[i] = p.calculateDependencies(p.getFilePaths(partition.Files), others)
+	}
+
+	// Kahn's algorithm, taking the lowest original position first to keep the plan stable
+	pending := make([]int, len(partitions))
+	dependents := make([][]int, len(partitions))
+	for i, deps := range dependsOn {
+		pending[i] = len(deps)
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], i)
+		}
+	}
+
+	var ready, order []int
+	for i := range partitions {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		next := ready[0]
+		ready = ready[1:]
+		order = append(order, next)
+		for _, dependent := range dependents[next] {
+			if pending[dependent]--; pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	// Partition-level cycles keep their original order; validation reports them
+	placed := make(map[int]bool)
+	for _, i := range order {
+		placed[i] = true
+	}
+	if len(order) < len(partitions) {
+		fmt.Fprintf(p.out, "⚠️  Warning: %d partitions depend on each other in a cycle and cannot be ordered\n", len(partitions)-len(order))
+	}
+	for i := range partitions {
+		if !placed[i] {
+			order = append(order, i)
+		}
+	}
+
+	newID := make(map[int]int)
+	for position, i := range order {
+		newID[i] = position + 1
+	}
+
+	ordered := make([]types.Partition, len(partitions))
+	for position, i := range order {
+		partition := partitions[i]
+		partition.ID = position + 1
+		partition.Dependencies = []int{}
+		for _, dep := range dependsOn[i] {
+			partition.Dependencies = append(partition.Dependencies, newID[dep])
+		}
+		sort.Ints(partition.Dependencies)
+		ordered[position] = partition
+	}
+	return ordered
+}
+
+// checkTopology verifies that the partitions can be stacked with the chosen topology
+func checkTopology(partitions []types.Partition, topology string) error {
+	if topology != types.TopologyIndependent {
+		return nil
+	}
+	for _, partition := range partitions {
+		if len(partition.Dependencies) > 0 {
+			return fmt.Errorf("independent topology requires partitions without cross-dependencies, but partition %d depends on %v (use --topology linear or dag)", partition.ID, partition.Dependencies)
+		}
+	}
+	return nil
+}
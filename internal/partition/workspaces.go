@@ -0,0 +1,142 @@
+package partition
+
+import (
+	"fmt"
+	"sort"
+
+	"pr-splitter-cli/internal/types"
+	"pr-splitter-cli/internal/workspace"
+)
+
+// workspaceRoot groups changed files outside every workspace package
+const workspaceRoot = ""
+
+// SetWorkspacePackages provides the monorepo packages for the workspace strategy
+func (p *Partitioner) SetWorkspacePackages(packages []workspace.Package) {
+	p.packages = packages
+}
+
+// packageOf returns the name of the workspace package holding filePath, or workspaceRoot
+func (p *Partitioner) packageOf(filePath string) string {
+	if owner := workspace.Owner(p.packages, filePath); owner != nil {
+		return owner.Name
+	}
+	return workspaceRoot
+}
+
+// workspaceDependencies links the changed files of packages whose manifests depend on each other,
+// so partitions follow the package graph even where imports between packages are not resolved
+func (p *Partitioner) workspaceDependencies(files []types.FileChange) []types.Dependency {
+	// First changed file of each package, in path order, stands for the package
+	sorted := p.getFilePaths(files)
+	sort.Strings(sorted)
+	representative := make(map[string]string)
+	for _, filePath := range sorted {
+		if name := p.packageOf(filePath); representative[name] == "" {
+			representative[name] = filePath
+		}
+	}
+
+	var dependencies []types.Dependency
+	for _, pkg := range p.packages {
+		from := representative[pkg.Name]
+		if from == "" {
+			continue
+		}
+		for _, required := range pkg.Requires {
+			if to := representative[required]; to != "" {
+				dependencies = append(dependencies, types.Dependency{
+					From:     from,
+					To:       to,
+					Type:     "workspace",
+					Strength: types.StrengthStrong,
+					Context:  fmt.Sprintf("%s depends on %s", pkg.Name, required),
+				})
+			}
+		}
+	}
+	return dependencies
+}
+
+// createWorkspacePartitions puts the changed files of each workspace package in one partition,
+// however large, and the files outside every package in another. Packages whose files depend on
+// each other in a cycle share a partition; ordering by dependencies then follows the package graph.
+func (p *Partitioner) createWorkspacePartitions(files []types.FileChange, sccs []types.StronglyConnectedComponent) []types.Partition {
+	// Merge packages joined by a circular dependency
+	group := make(map[string]string)
+	var find func(name string) string
+	find = func(name string) string {
+		if parent, ok := group[name]; ok && parent != name {
+			root := find(parent)
+			group[name] = root
+			return root
+		}
+		return name
+	}
+	for _, scc := range sccs {
+		if len(scc.Files) == 0 {
+			continue
+		}
+		first := find(p.packageOf(scc.Files[0]))
+		for _, filePath := range scc.Files[1:] {
+			if other := find(p.packageOf(filePath)); other != first {
+				group[other] = first
+			}
+		}
+	}
+
+	byGroup := make(map[string][]types.FileChange)
+	members := make(map[string]map[string]bool)
+	for _, file := range files {
+		name := p.packageOf(file.Path)
+		key := find(name)
+		byGroup[key] = append(byGroup[key], file)
+		if members[key] == nil {
+			members[key] = make(map[string]bool)
+		}
+		members[key][name] = true
+	}
+
+	keys := make([]string, 0, len(byGroup))
+	for key := range byGroup {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var partitions []types.Partition
+	for _, key := range keys {
+		groupFiles := byGroup[key]
+		var names []string
+		for name := range members[key] {
+			if name != workspaceRoot {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		description := fmt.Sprintf("Workspace root (%d files)", len(groupFiles))
+		if len(names) > 0 {
+			description = fmt.Sprintf("Package %s (%d files)", joinNames(names), len(groupFiles))
+		}
+		partitions = append(partitions, types.Partition{
+			ID:          len(partitions) + 1,
+			Name:        p.generateName(groupFiles),
+			Description: description,
+			Files:       groupFiles,
+		})
+	}
+
+	fmt.Fprintf(p.out, "🗂️  Grouped changed files into %d workspace partitions\n", len(partitions))
+	return partitions
+}
+
+// joinNames lists package names, e.g. "@acme/ui and @acme/api"
+func joinNames(names []string) string {
+	switch len(names) {
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " and " + names[1]
+	}
+	return fmt.Sprintf("%s and %d more", names[0], len(names)-1)
+}
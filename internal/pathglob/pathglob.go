@@ -0,0 +1,83 @@
+package pathglob
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Compile converts a slash-separated path glob into an anchored regexp. * and ? never cross a
+// directory separator, ** matches any number of directories, and {a,b} matches either alternative.
+// The first ** is captured as group 1 (empty when the glob has none).
+func Compile(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	captured := false
+	inAlternation := false
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			if captured {
+				b.WriteString("(?:.*/)?")
+			} else {
+				b.WriteString("(.*/)?")
+				captured = true
+			}
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			if captured {
+				b.WriteString(".*")
+			} else {
+				b.WriteString("(.*)")
+				captured = true
+			}
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		case glob[i] == '{' && !inAlternation:
+			b.WriteString("(?:")
+			inAlternation = true
+		case glob[i] == '}' && inAlternation:
+			b.WriteString(")")
+			inAlternation = false
+		case glob[i] == ',' && inAlternation:
+			b.WriteString("|")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+
+	if !captured {
+		b.WriteString("()")
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		// Unbalanced alternation; fall back to a literal match
+		return regexp.MustCompile("^" + regexp.QuoteMeta(glob) + "()$")
+	}
+	return re
+}
+
+// Match reports whether a repository path matches a glob. Globs without a slash match the file
+// name in any directory, like .gitignore entries.
+func Match(glob, filePath string) bool {
+	if !strings.Contains(glob, "/") {
+		return Compile(glob).MatchString(path.Base(filePath))
+	}
+	return Compile(strings.TrimPrefix(glob, "/")).MatchString(filePath)
+}
+
+// MatchAny reports whether a path matches at least one of the globs
+func MatchAny(globs []string, filePath string) bool {
+	for _, glob := range globs {
+		if Match(glob, filePath) {
+			return true
+		}
+	}
+	return false
+}
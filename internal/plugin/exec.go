@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+)
+
+// How a plugin executable is run, from its manifest runtime or its extension
+const (
+	runtimeBinary     = "binary"     // Run directly
+	runtimeNode       = "node"       // .js, .mjs and .cjs scripts
+	runtimePython     = "python"     // .py scripts
+	runtimePython3    = "python3"    // .py scripts, the default Python
+	runtimeBatch      = "batch"      // .cmd and .bat scripts, run by cmd.exe on Windows
+	runtimePowerShell = "powershell" // .ps1 scripts
+	runtimeShell      = "sh"         // .sh scripts, run by a shell on Windows
+)
+
+// interpreters lists the commands that can run each runtime, in order of preference. Windows
+// installs Python as 'python' or the 'py' launcher, and PowerShell as 'pwsh' or 'powershell'.
+var interpreters = map[string][][]string{
+	runtimeNode:       {{"node"}},
+	runtimePython:     {{"python"}, {"python3"}, {"py", "-3"}},
+	runtimePython3:    {{"python3"}, {"python"}, {"py", "-3"}},
+	runtimePowerShell: {{"pwsh", "-NoProfile", "-ExecutionPolicy", "Bypass", "-File"}, {"powershell", "-NoProfile", "-ExecutionPolicy", "Bypass", "-File"}},
+	runtimeShell:      {{"sh"}, {"bash"}},
+}
+
+// runtimeNames are the names of runtimes in messages
+var runtimeNames = map[string]string{
+	runtimeNode:       "Node.js",
+	runtimePowerShell: "PowerShell",
+	runtimeShell:      "a POSIX shell",
+}
+
+// ResolvedRuntime returns how the plugin is run: the runtime of its manifest, otherwise one
+// derived from the extension of its executable
+func (p *Plugin) ResolvedRuntime() string {
+	if p.Runtime != "" {
+		return p.Runtime
+	}
+	switch strings.ToLower(filepath.Ext(p.Executable)) {
+	case ".js", ".mjs", ".cjs":
+		return runtimeNode
+	case ".py":
+		return runtimePython3
+	case ".cmd", ".bat":
+		return runtimeBatch
+	case ".ps1":
+		return runtimePowerShell
+	case ".sh":
+		// Elsewhere the shebang line picks the shell
+		if goruntime.GOOS == "windows" {
+			return runtimeShell
+		}
+	}
+	return runtimeBinary
+}
+
+// lookInterpreter finds the command that runs a runtime, with the arguments preceding the script
+func lookInterpreter(kind string) ([]string, error) {
+	candidates, known := interpreters[kind]
+	if !known {
+		candidates = [][]string{{kind}}
+	}
+	for _, candidate := range candidates {
+		if path, err := exec.LookPath(candidate[0]); err == nil {
+			return append([]string{path}, candidate[1:]...), nil
+		}
+	}
+
+	name := runtimeNames[kind]
+	if name == "" {
+		name = kind
+	}
+	return nil, fmt.Errorf("requires %s but it's not installed", name)
+}
+
+// pluginCommand prepares the command that runs a plugin, cancelled with ctx
+func pluginCommand(ctx context.Context, plugin *Plugin) (*exec.Cmd, error) {
+	executable := shortPath(plugin.Executable)
+	switch kind := plugin.ResolvedRuntime(); kind {
+	case runtimeBinary:
+		return exec.CommandContext(ctx, executable), nil
+	case runtimeBatch:
+		return batchCommand(ctx, executable)
+	default:
+		interpreter, err := lookInterpreter(kind)
+		if err != nil {
+			return nil, err
+		}
+		return exec.CommandContext(ctx, interpreter[0], append(interpreter[1:], executable)...), nil
+	}
+}
+
+// resolveExecutable returns the file a manifest's executable refers to. On Windows a name
+// without an extension, as written for Unix, is completed with the PATHEXT extensions, e.g.
+// "bin/analyzer" runs "bin\analyzer.exe" or "bin\analyzer.cmd".
+func resolveExecutable(path string) string {
+	if goruntime.GOOS != "windows" || filepath.Ext(path) != "" {
+		return path
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".COM;.EXE;.BAT;.CMD"
+	}
+	for _, ext := range filepath.SplitList(pathExt) {
+		if ext == "" {
+			continue
+		}
+		if _, err := os.Stat(path + strings.ToLower(ext)); err == nil {
+			return path + strings.ToLower(ext)
+		}
+	}
+	return path
+}
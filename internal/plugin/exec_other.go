@@ -0,0 +1,19 @@
+//go:build !windows
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// batchCommand reports that .cmd and .bat scripts only run on Windows
+func batchCommand(ctx context.Context, script string) (*exec.Cmd, error) {
+	return nil, fmt.Errorf("is a Windows batch script and cannot run on this system: %s", script)
+}
+
+// shortPath returns the path unchanged; only Windows limits the length of executable paths
+func shortPath(path string) string {
+	return path
+}
@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// maxPath is the longest path Windows starts a process from without the long path prefix
+const maxPath = 260
+
+// batchCommand runs a .cmd or .bat script with cmd.exe. The command line is written out
+// because cmd.exe does not follow the quoting rules Go uses for other programs.
+func batchCommand(ctx context.Context, script string) (*exec.Cmd, error) {
+	shell := os.Getenv("ComSpec")
+	if shell == "" {
+		shell = "cmd.exe"
+	}
+	cmd := exec.CommandContext(ctx, shell)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine: `"` + shell + `" /d /s /c ""` + script + `""`,
+	}
+	return cmd, nil
+}
+
+// shortPath returns the 8.3 short form of a path too long to start a process from, the path
+// itself otherwise or when the volume keeps no short names
+func shortPath(path string) string {
+	if len(path) < maxPath {
+		return path
+	}
+	long, err := syscall.UTF16PtrFromString(`\\?\` + path)
+	if err != nil {
+		return path
+	}
+	buf := make([]uint16, len(path)+5)
+	n, err := syscall.GetShortPathName(long, &buf[0], uint32(len(buf)))
+	if err != nil || n == 0 || int(n) > len(buf) {
+		return path
+	}
+	short := syscall.UTF16ToString(buf[:n])
+	if len(short) > 4 && short[:4] == `\\?\` {
+		short = short[4:]
+	}
+	return short
+}
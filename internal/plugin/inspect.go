@@ -0,0 +1,218 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// PluginStatus describes a plugin directory and whether its plugin is usable
+type PluginStatus struct {
+	DirName  string          `json:"dirName"`
+	Path     string          `json:"path"`
+	Manifest *PluginManifest `json:"manifest,omitempty"`
+	Plugin   *Plugin         `json:"plugin,omitempty"`
+	Problems []string        `json:"problems,omitempty"`
+}
+
+// Valid reports whether the plugin would be registered during discovery
+func (s PluginStatus) Valid() bool {
+	return s.Plugin != nil && len(s.Problems) == 0
+}
+
+// Inspector reports on plugins without registering them or printing discovery output
+type Inspector struct {
+	manager *Manager
+}
+
+// NewInspector creates an inspector for the default plugins directory
+func NewInspector() *Inspector {
+	return &Inspector{
+		manager: &Manager{
+			pluginDir: locatePluginDir(),
+			plugins:   make(map[string]*Plugin),
+		},
+	}
+}
+
+// Builtin is a built-in analyzer and the extensions it handles
+type Builtin struct {
+	Name       string
+	Extensions []string
+}
+
+// Builtins returns the built-in analyzers, sorted by name
+func (i *Inspector) Builtins() []Builtin {
+	var builtins []Builtin
+	for name, analyzer := range builtinAnalyzers() {
+		builtins = append(builtins, Builtin{Name: name, Extensions: analyzer.Extensions()})
+	}
+	sort.Slice(builtins, func(a, b int) bool { return builtins[a].Name < builtins[b].Name })
+	return builtins
+}
+
+// PluginDir returns the directory being inspected
+func (i *Inspector) PluginDir() string {
+	return i.manager.pluginDir
+}
+
+// InspectAll returns the status of every plugin directory, sorted by directory name
+func (i *Inspector) InspectAll() ([]PluginStatus, error) {
+	entries, err := os.ReadDir(i.manager.pluginDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins directory %s: %w", i.manager.pluginDir, err)
+	}
+
+	var statuses []PluginStatus
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		statuses = append(statuses, i.inspectDir(entry.Name()))
+	}
+
+	sort.Slice(statuses, func(a, b int) bool {
+		return statuses[a].DirName < statuses[b].DirName
+	})
+
+	return statuses, nil
+}
+
+// Inspect returns the status of a single plugin, matched by directory or manifest name
+func (i *Inspector) Inspect(name string) (*PluginStatus, error) {
+	statuses, err := i.InspectAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, status := range statuses {
+		if status.DirName == name || (status.Manifest != nil && status.Manifest.Name == name) {
+			return &status, nil
+		}
+	}
+
+	return nil, fmt.Errorf("plugin '%s' not found in %s", name, i.manager.pluginDir)
+}
+
+// inspectDir loads and checks one plugin directory
+func (i *Inspector) inspectDir(dirName string) PluginStatus {
+	pluginPath := filepath.Join(i.manager.pluginDir, dirName)
+	status := PluginStatus{DirName: dirName, Path: pluginPath}
+
+	manifest, err := i.manager.readManifest(pluginPath)
+	if err != nil {
+		status.Problems = append(status.Problems, err.Error())
+		return status
+	}
+	status.Manifest = manifest
+
+	plugin, err := i.manager.loadPluginFromManifest(dirName, pluginPath)
+	if err != nil {
+		status.Problems = append(status.Problems, err.Error())
+		return status
+	}
+	status.Plugin = plugin
+	status.Problems = append(status.Problems, i.manager.checkPluginExecutable(plugin)...)
+
+	return status
+}
+
+// TestReport holds the outcome of running a plugin against a sample input
+type TestReport struct {
+	Plugin   *Plugin             `json:"plugin"`
+	Input    types.PluginInput   `json:"-"`
+	Output   *types.PluginOutput `json:"output,omitempty"`
+	Duration time.Duration       `json:"duration"`
+	Err      error               `json:"-"`
+	Warnings []string            `json:"warnings,omitempty"`
+}
+
+// Passed reports whether the plugin ran and produced schema-valid output
+func (r *TestReport) Passed() bool {
+	return r.Err == nil && r.Output != nil
+}
+
+// TestPlugin runs a single plugin against the given input and validates its output
+func (i *Inspector) TestPlugin(ctx context.Context, name string, input types.PluginInput) (*TestReport, error) {
+	status, err := i.Inspect(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.Valid() {
+		return nil, fmt.Errorf("plugin '%s' is not usable: %s", name, strings.Join(status.Problems, "; "))
+	}
+
+	if input.ProjectRoot == "" {
+		input.ProjectRoot = i.manager.getProjectRoot(ctx)
+	}
+
+	report := &TestReport{Plugin: status.Plugin, Input: input}
+
+	startTime := time.Now()
+	report.Output, report.Err = i.manager.runPlugin(ctx, status.Plugin, input)
+	report.Duration = time.Since(startTime)
+
+	if report.Output != nil {
+		report.Warnings = checkDependencyTargets(report.Output.Dependencies, input)
+	}
+
+	return report, nil
+}
+
+// FilterForPlugin keeps only the files a plugin would be given during a split
+func (i *Inspector) FilterForPlugin(name string, files []types.FileChange) ([]types.FileChange, error) {
+	status, err := i.Inspect(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Plugin == nil {
+		return nil, fmt.Errorf("plugin '%s' has no valid manifest", name)
+	}
+
+	var filtered []types.FileChange
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Path))
+		for _, supportedExt := range status.Plugin.Extensions {
+			if ext == supportedExt {
+				filtered = append(filtered, file)
+				break
+			}
+		}
+	}
+
+	return filtered, nil
+}
+
+// checkDependencyTargets flags dependencies that reference files absent from the input
+func checkDependencyTargets(dependencies []types.Dependency, input types.PluginInput) []string {
+	known := make(map[string]bool)
+	for _, file := range input.ChangedFiles {
+		known[file.Path] = true
+	}
+	for _, file := range input.ProjectFiles {
+		known[file.Path] = true
+	}
+
+	var warnings []string
+	for idx, dep := range dependencies {
+		if !known[dep.From] {
+			warnings = append(warnings, fmt.Sprintf("dependency %d 'from' references unknown file: %s", idx, dep.From))
+		}
+		if !known[dep.To] {
+			warnings = append(warnings, fmt.Sprintf("dependency %d 'to' references unknown file: %s", idx, dep.To))
+		}
+		if dep.From == dep.To {
+			warnings = append(warnings, fmt.Sprintf("dependency %d is a self-reference: %s", idx, dep.From))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,505 @@
+package plugin
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RegistryEnvVar names the environment variable holding the default plugin registry URL
+const RegistryEnvVar = "PR_SPLIT_PLUGIN_REGISTRY"
+
+// maxDownloadSize caps registry indexes and plugin archives fetched over HTTP
+const maxDownloadSize = 100 << 20
+
+// InstallOptions controls how a plugin is installed
+type InstallOptions struct {
+	RegistryURL string // Index used to resolve plugin names
+	SHA256      string // Expected archive checksum (overrides the registry value)
+	Force       bool   // Replace an existing plugin directory
+}
+
+// RegistryIndex is the JSON document served by a plugin registry
+type RegistryIndex struct {
+	Plugins map[string]RegistryEntry `json:"plugins"`
+}
+
+// RegistryEntry describes a downloadable plugin archive
+type RegistryEntry struct {
+	URL         string `json:"url"`
+	SHA256      string `json:"sha256"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Installer downloads plugin archives into the plugins directory
+type Installer struct {
+	manager *Manager
+	client  *http.Client
+}
+
+// NewInstaller creates an installer for the default plugins directory
+func NewInstaller() *Installer {
+	return &Installer{
+		manager: &Manager{
+			pluginDir: locatePluginDir(),
+			plugins:   make(map[string]*Plugin),
+		},
+		client: &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// PluginDir returns the directory plugins are installed into
+func (i *Installer) PluginDir() string {
+	return i.manager.pluginDir
+}
+
+// Install installs a plugin from an archive URL, a local archive path, or a registry name
+func (i *Installer) Install(source string, opts InstallOptions) (*PluginStatus, error) {
+	archiveURL, expectedSum := source, opts.SHA256
+
+	if !isArchiveSource(source) {
+		entry, err := i.resolveFromRegistry(source, opts.RegistryURL)
+		if err != nil {
+			return nil, err
+		}
+		archiveURL = entry.URL
+		if expectedSum == "" {
+			expectedSum = entry.SHA256
+		}
+	}
+
+	workDir, err := os.MkdirTemp("", "pr-split-plugin-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	fmt.Printf("⬇️  Fetching %s\n", archiveURL)
+	archivePath := filepath.Join(workDir, "archive"+archiveExtension(archiveURL))
+	if err := i.fetch(archiveURL, archivePath); err != nil {
+		return nil, err
+	}
+
+	if expectedSum != "" {
+		if err := verifyChecksum(archivePath, expectedSum); err != nil {
+			return nil, fmt.Errorf("archive checksum verification failed: %w", err)
+		}
+		fmt.Println("🔐 Archive checksum verified")
+	}
+
+	extractDir := filepath.Join(workDir, "extracted")
+	if err := extractArchive(archivePath, extractDir); err != nil {
+		return nil, fmt.Errorf("failed to extract archive: %w", err)
+	}
+
+	pluginRoot, err := findPluginRoot(extractDir)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := i.manager.readManifest(pluginRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin archive: %w", err)
+	}
+
+	executable, err := pluginExecutable(pluginRoot, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin archive: %w", err)
+	}
+
+	// The manifest checksum ships in the archive it describes, so it only catches corruption
+	if manifest.Checksum != "" {
+		if err := verifyChecksum(executable, manifest.Checksum); err != nil {
+			return nil, fmt.Errorf("executable does not match the manifest checksum: %w", err)
+		}
+		fmt.Println("ℹ️  Executable matches the checksum in its own manifest (detects corruption, not tampering)")
+	}
+	if expectedSum == "" {
+		fmt.Println("⚠️  Warning: No --sha256 or registry checksum, plugin contents were not verified")
+	}
+
+	return i.installDir(pluginRoot, manifest, opts.Force)
+}
+
+// installDir moves an extracted plugin into the plugins directory and inspects it. The plugin
+// is staged next to its destination first, so a plugin that fails to install never replaces
+// the one already installed.
+func (i *Installer) installDir(pluginRoot string, manifest *PluginManifest, force bool) (*PluginStatus, error) {
+	dirName := pluginDirName(manifest.Name)
+	dest := filepath.Join(i.manager.pluginDir, dirName)
+
+	_, err := os.Stat(dest)
+	replacing := err == nil
+	if replacing && !force {
+		return nil, fmt.Errorf("plugin directory %s already exists (use --force to replace)", dest)
+	}
+
+	if err := os.MkdirAll(i.manager.pluginDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create plugins directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(i.manager.pluginDir, "."+dirName+"-install-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := copyDir(pluginRoot, staging); err != nil {
+		return nil, fmt.Errorf("failed to install plugin: %w", err)
+	}
+	executable, err := pluginExecutable(staging, manifest)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(executable)
+	if err != nil {
+		return nil, fmt.Errorf("plugin executable %s not found in the archive", manifest.Executable)
+	}
+	os.Chmod(executable, info.Mode()|0111)
+
+	// Keep the installed plugin aside until the new one is in place
+	if replacing {
+		previous := staging + "-previous"
+		if err := os.Rename(dest, previous); err != nil {
+			return nil, fmt.Errorf("failed to replace existing plugin: %w", err)
+		}
+		if err := os.Rename(staging, dest); err != nil {
+			os.Rename(previous, dest)
+			return nil, fmt.Errorf("failed to install plugin: %w", err)
+		}
+		os.RemoveAll(previous)
+	} else if err := os.Rename(staging, dest); err != nil {
+		return nil, fmt.Errorf("failed to install plugin: %w", err)
+	}
+
+	status := (&Inspector{manager: i.manager}).inspectDir(dirName)
+	return &status, nil
+}
+
+// resolveFromRegistry looks up a plugin name in the registry index
+func (i *Installer) resolveFromRegistry(name, registryURL string) (*RegistryEntry, error) {
+	if registryURL == "" {
+		registryURL = os.Getenv(RegistryEnvVar)
+	}
+	if registryURL == "" {
+		return nil, fmt.Errorf("'%s' is not a URL or archive path and no registry is configured (use --registry or %s)", name, RegistryEnvVar)
+	}
+
+	data, err := i.read(registryURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch registry index: %w", err)
+	}
+
+	var index RegistryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse registry index: %w", err)
+	}
+
+	entry, exists := index.Plugins[name]
+	if !exists {
+		return nil, fmt.Errorf("plugin '%s' not found in registry %s", name, registryURL)
+	}
+	if entry.URL == "" {
+		return nil, fmt.Errorf("registry entry for '%s' has no URL", name)
+	}
+
+	return &entry, nil
+}
+
+// fetch downloads a URL or copies a local file to dest
+func (i *Installer) fetch(source, dest string) error {
+	data, err := i.read(source)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", source, err)
+	}
+	return os.WriteFile(dest, data, 0644)
+}
+
+// read returns the contents of a URL or local file
+func (i *Installer) read(source string) ([]byte, error) {
+	if !isRemoteURL(source) {
+		return os.ReadFile(source)
+	}
+
+	resp, err := i.client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("%s is larger than %d MB", source, maxDownloadSize>>20)
+	}
+	return data, nil
+}
+
+// isRemoteURL checks if a source is an http(s) URL
+func isRemoteURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// isArchiveSource checks if a source refers to an archive rather than a registry name: a URL,
+// a path with an archive extension, or an explicitly relative or absolute path. A bare name is
+// looked up in the registry even when a file of that name exists.
+func isArchiveSource(source string) bool {
+	if isRemoteURL(source) || filepath.IsAbs(source) {
+		return true
+	}
+	for _, prefix := range []string{"./", "../", "." + string(filepath.Separator), ".." + string(filepath.Separator)} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	lower := strings.ToLower(source)
+	for _, ext := range []string{".zip", ".tgz", ".tar.gz"} {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveExtension returns the archive extension of a source path
+func archiveExtension(source string) string {
+	lower := strings.ToLower(source)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return ".zip"
+	case strings.HasSuffix(lower, ".tgz"):
+		return ".tgz"
+	default:
+		return ".tar.gz"
+	}
+}
+
+// verifyChecksum compares a file's SHA-256 with an expected "sha256:<hex>" or bare hex value
+func verifyChecksum(path, expected string) error {
+	expected = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(expected), "sha256:"))
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return err
+	}
+
+	actual := hex.EncodeToString(hasher.Sum(nil))
+	if actual != expected {
+		return fmt.Errorf("expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// extractArchive unpacks a zip or tar.gz archive into dest
+func extractArchive(archivePath, dest string) error {
+	if strings.HasSuffix(archivePath, ".zip") {
+		return extractZip(archivePath, dest)
+	}
+	return extractTarGz(archivePath, dest)
+}
+
+func extractZip(archivePath, dest string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		target, err := safeJoin(dest, file.Name)
+		if err != nil {
+			return err
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(target, src, file.Mode())
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractTarGz(archivePath, dest string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := safeJoin(dest, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, reader, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// safeJoin joins an archive entry name to dest, rejecting paths that escape it
+func safeJoin(dest, name string) (string, error) {
+	target := filepath.Join(dest, name)
+	if target != filepath.Clean(dest) && !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive entry escapes destination: %s", name)
+	}
+	return target, nil
+}
+
+// pluginExecutable returns the path of the manifest's executable under a plugin directory,
+// rejecting absolute paths and paths that leave the directory
+func pluginExecutable(pluginDir string, manifest *PluginManifest) (string, error) {
+	executable := filepath.FromSlash(manifest.Executable)
+	if filepath.IsAbs(executable) || filepath.VolumeName(executable) != "" {
+		return "", fmt.Errorf("executable %s must be a path inside the plugin directory", manifest.Executable)
+	}
+	target, err := safeJoin(pluginDir, executable)
+	if err != nil || target == filepath.Clean(pluginDir) {
+		return "", fmt.Errorf("executable %s must be a path inside the plugin directory", manifest.Executable)
+	}
+	return target, nil
+}
+
+// writeFile writes a reader to path, creating parent directories
+func writeFile(path string, src io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, src)
+	return err
+}
+
+// findPluginRoot locates plugin.json at the archive root or in a single top-level directory
+func findPluginRoot(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "plugin.json")); err == nil {
+		return dir, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if len(entries) == 1 && entries[0].IsDir() {
+		nested := filepath.Join(dir, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(nested, "plugin.json")); err == nil {
+			return nested, nil
+		}
+	}
+
+	return "", fmt.Errorf("plugin.json manifest not found in archive")
+}
+
+// copyDir recursively copies a directory tree
+func copyDir(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		return writeFile(target, in, info.Mode())
+	})
+}
+
+// pluginDirName derives a directory name from a plugin's manifest name
+func pluginDirName(name string) string {
+	name = strings.ToLower(name)
+	var b strings.Builder
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+
+	dirName := strings.Trim(b.String(), "-")
+	for strings.Contains(dirName, "--") {
+		dirName = strings.ReplaceAll(dirName, "--", "-")
+	}
+	if dirName == "" {
+		dirName = "plugin"
+	}
+	return dirName
+}
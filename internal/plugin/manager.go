@@ -4,19 +4,59 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"pr-splitter-cli/internal/analyzer/cpp"
+	"pr-splitter-cli/internal/analyzer/golang"
+	"pr-splitter-cli/internal/analyzer/jvm"
+	"pr-splitter-cli/internal/analyzer/lockfile"
+	"pr-splitter-cli/internal/analyzer/proto"
+	"pr-splitter-cli/internal/analyzer/terraform"
+	"pr-splitter-cli/internal/analyzer/typescript"
+	"pr-splitter-cli/internal/git"
 	"pr-splitter-cli/internal/types"
 )
 
+// builtinPrefix distinguishes built-in analyzers from plugin directory names
+const builtinPrefix = "builtin:"
+
 // Manager handles plugin discovery, execution, and communication
 type Manager struct {
 	pluginDir string
 	plugins   map[string]*Plugin
+	builtins  map[string]BuiltinAnalyzer
+
+	includePaths  []string                  // Passed to analyzers for C/C++ header and proto import resolution
+	generatedCode []types.GeneratedCodeRule // Pairs schema files with their generated outputs
+	pairingRules  []types.PairingRule       // Pairs files with companions in their directory, e.g. lockfiles with manifests
+	priority      map[string]int            // Analyzer priority for extension ownership and edge merging
+
+	out        io.Writer // Progress output
+	workingDir string    // Checkout being analyzed, the process working directory when empty
+}
+
+// BuiltinAnalyzer is a dependency analyzer compiled into the binary
+type BuiltinAnalyzer interface {
+	Extensions() []string
+	Analyze(input types.PluginInput) (*types.PluginOutput, error)
+}
+
+// builtinAnalyzers returns the analyzers that need no plugin setup, keyed by name
+func builtinAnalyzers() map[string]BuiltinAnalyzer {
+	return map[string]BuiltinAnalyzer{
+		golang.Name:     golang.New(),
+		typescript.Name: typescript.New(),
+		jvm.Name:        jvm.New(),
+		cpp.Name:        cpp.New(),
+		proto.Name:      proto.New(),
+		terraform.Name:  terraform.New(),
+	}
 }
 
 // Plugin represents a language-specific analysis plugin
@@ -27,6 +67,8 @@ type Plugin struct {
 	Description string   `json:"description"`
 	Version     string   `json:"version"`
 	Runtime     string   `json:"runtime,omitempty"` // e.g., "node", "python", "binary"
+
+	ProjectContext bool `json:"projectContext,omitempty"` // Sent the unchanged project files along with the changed ones
 }
 
 // PluginManifest represents the plugin.json manifest file
@@ -39,10 +81,35 @@ type PluginManifest struct {
 	Runtime     string   `json:"runtime,omitempty"`
 	Author      string   `json:"author,omitempty"`
 	Homepage    string   `json:"homepage,omitempty"`
+	Checksum    string   `json:"checksum,omitempty"` // sha256 of the executable, verified on install
+
+	// ProjectContext asks for the unchanged project files as projectFiles, e.g. to resolve
+	// imports; without it plugins get the changed files only and the project is not scanned
+	ProjectContext bool `json:"projectContext,omitempty"`
 }
 
 // NewManager creates a new plugin manager
 func NewManager() *Manager {
+	return NewManagerWithOutput(os.Stdout)
+}
+
+// NewManagerWithOutput creates a plugin manager that writes its progress output to out
+func NewManagerWithOutput(out io.Writer) *Manager {
+	manager := &Manager{
+		out:       out,
+		pluginDir: locatePluginDir(),
+		plugins:   make(map[string]*Plugin),
+		builtins:  builtinAnalyzers(),
+	}
+
+	// Discover available plugins
+	manager.discoverPlugins()
+
+	return manager
+}
+
+// locatePluginDir finds the plugins directory next to the executable or in the working directory
+func locatePluginDir() string {
 	// Try to find plugins directory relative to executable
 	execPath, err := os.Executable()
 	if err != nil {
@@ -59,35 +126,28 @@ func NewManager() *Manager {
 		pluginDir = filepath.Join(wd, "plugins")
 	}
 
-	manager := &Manager{
-		pluginDir: pluginDir,
-		plugins:   make(map[string]*Plugin),
-	}
-
-	// Discover available plugins
-	manager.discoverPlugins()
-
-	return manager
+	return pluginDir
 }
 
 // discoverPlugins dynamically finds and registers available plugins
 func (m *Manager) discoverPlugins() {
 	// Check if plugins directory exists
 	if _, err := os.Stat(m.pluginDir); os.IsNotExist(err) {
-		fmt.Printf("⚠️  Plugins directory not found: %s\n", m.pluginDir)
+		fmt.Fprintf(m.out, "⚠️  Plugins directory not found: %s\n", m.pluginDir)
 		return
 	}
 
 	// Read plugin directories
 	entries, err := os.ReadDir(m.pluginDir)
 	if err != nil {
-		fmt.Printf("⚠️  Failed to read plugins directory: %v\n", err)
+		fmt.Fprintf(m.out, "⚠️  Failed to read plugins directory: %v\n", err)
 		return
 	}
 
 	pluginCount := 0
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		// Hidden directories are installs in progress
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
 
@@ -97,13 +157,12 @@ func (m *Manager) discoverPlugins() {
 		// Try to load plugin from manifest
 		plugin, err := m.loadPluginFromManifest(pluginName, pluginPath)
 		if err != nil {
-			fmt.Printf("⚠️  Failed to load plugin '%s': %v\n", pluginName, err)
+			fmt.Fprintf(m.out, "⚠️  Failed to load plugin '%s': %v\n", pluginName, err)
 			continue
 		}
 
 		// Validate plugin executable exists
 		if !m.validatePluginExecutable(plugin) {
-			fmt.Printf("⚠️  Plugin '%s' executable not found: %s\n", plugin.Name, plugin.Executable)
 			continue
 		}
 
@@ -111,20 +170,48 @@ func (m *Manager) discoverPlugins() {
 		m.plugins[pluginName] = plugin
 		pluginCount++
 
-		fmt.Printf("📦 Discovered plugin: %s v%s (%s)\n",
+		fmt.Fprintf(m.out, "📦 Discovered plugin: %s v%s (%s)\n",
 			plugin.Name, plugin.Version, plugin.Description)
 	}
 
 	if pluginCount == 0 {
-		fmt.Printf("⚠️  No valid plugins found in %s\n", m.pluginDir)
-		fmt.Printf("💡 Create plugins with a plugin.json manifest file\n")
+		fmt.Fprintf(m.out, "⚠️  No valid plugins found in %s\n", m.pluginDir)
+		fmt.Fprintf(m.out, "💡 Create plugins with a plugin.json manifest file\n")
 	} else {
-		fmt.Printf("✅ Loaded %d plugin(s)\n", pluginCount)
+		fmt.Fprintf(m.out, "✅ Loaded %d plugin(s)\n", pluginCount)
 	}
 }
 
 // loadPluginFromManifest loads a plugin from its manifest file
 func (m *Manager) loadPluginFromManifest(pluginName, pluginPath string) (*Plugin, error) {
+	manifest, err := m.readManifest(pluginPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create plugin with absolute executable path; manifests are written with forward slashes
+	executablePath := filepath.FromSlash(manifest.Executable)
+	if !filepath.IsAbs(executablePath) {
+		executablePath = filepath.Join(pluginPath, executablePath)
+	}
+	executablePath = resolveExecutable(executablePath)
+
+	plugin := &Plugin{
+		Name:        manifest.Name,
+		Executable:  executablePath,
+		Extensions:  manifest.Extensions,
+		Description: manifest.Description,
+		Version:     manifest.Version,
+		Runtime:     manifest.Runtime,
+
+		ProjectContext: manifest.ProjectContext,
+	}
+
+	return plugin, nil
+}
+
+// readManifest reads and validates the plugin.json manifest in a plugin directory
+func (m *Manager) readManifest(pluginPath string) (*PluginManifest, error) {
 	manifestPath := filepath.Join(pluginPath, "plugin.json")
 
 	// Check if manifest exists
@@ -155,92 +242,167 @@ func (m *Manager) loadPluginFromManifest(pluginName, pluginPath string) (*Plugin
 		return nil, fmt.Errorf("plugin must specify supported extensions")
 	}
 
-	// Create plugin with absolute executable path
-	executablePath := manifest.Executable
-	if !filepath.IsAbs(executablePath) {
-		executablePath = filepath.Join(pluginPath, executablePath)
-	}
-
-	plugin := &Plugin{
-		Name:        manifest.Name,
-		Executable:  executablePath,
-		Extensions:  manifest.Extensions,
-		Description: manifest.Description,
-		Version:     manifest.Version,
-		Runtime:     manifest.Runtime,
-	}
-
-	return plugin, nil
+	return &manifest, nil
 }
 
 // validatePluginExecutable checks if the plugin executable exists and is accessible
 func (m *Manager) validatePluginExecutable(plugin *Plugin) bool {
+	problems := m.checkPluginExecutable(plugin)
+	for _, problem := range problems {
+		fmt.Fprintf(m.out, "⚠️  Plugin '%s' %s\n", plugin.Name, problem)
+	}
+	return len(problems) == 0
+}
+
+// checkPluginExecutable returns the problems that prevent a plugin from running
+func (m *Manager) checkPluginExecutable(plugin *Plugin) []string {
 	// Check if file exists
 	if _, err := os.Stat(plugin.Executable); os.IsNotExist(err) {
-		return false
+		return []string{fmt.Sprintf("executable not found: %s", plugin.Executable)}
 	}
 
-	// For runtime-based plugins, also check if the runtime is available
-	if plugin.Runtime != "" {
-		switch plugin.Runtime {
-		case "node":
-			if _, err := exec.LookPath("node"); err != nil {
-				fmt.Printf("⚠️  Plugin '%s' requires Node.js but it's not installed\n", plugin.Name)
-				return false
-			}
-		case "python", "python3":
-			if _, err := exec.LookPath(plugin.Runtime); err != nil {
-				fmt.Printf("⚠️  Plugin '%s' requires %s but it's not installed\n", plugin.Name, plugin.Runtime)
-				return false
-			}
+	// For scripts, also check that something can run them here
+	switch kind := plugin.ResolvedRuntime(); kind {
+	case runtimeBinary:
+	case runtimeBatch:
+		if _, err := batchCommand(context.Background(), plugin.Executable); err != nil {
+			return []string{err.Error()}
+		}
+	default:
+		if _, err := lookInterpreter(kind); err != nil {
+			return []string{err.Error()}
 		}
 	}
 
-	return true
+	return nil
+}
+
+// SetIncludePaths configures the header search directories passed to analyzers
+func (m *Manager) SetIncludePaths(paths []string) {
+	m.includePaths = paths
+}
+
+// SetGeneratedCodeRules configures which generated files are kept with their schema sources
+func (m *Manager) SetGeneratedCodeRules(rules []types.GeneratedCodeRule) {
+	m.generatedCode = rules
+}
+
+// SetPairingRules configures which files are kept with companion files in their directory, on top
+// of the built-in lockfile rules
+func (m *Manager) SetPairingRules(rules []types.PairingRule) {
+	m.pairingRules = rules
+}
+
+// SetPluginPriority configures which analyzer wins when several handle an extension or report the same edge
+func (m *Manager) SetPluginPriority(priority map[string]int) {
+	m.priority = priority
 }
 
 // AnalyzeDependencies runs appropriate plugins to analyze file dependencies
-func (m *Manager) AnalyzeDependencies(changes []types.FileChange) ([]types.Dependency, error) {
+func (m *Manager) AnalyzeDependencies(ctx context.Context, changes []types.FileChange) ([]types.Dependency, error) {
 	var allDependencies []types.Dependency
 
 	// Group files by plugin type
 	fileGroups := m.groupFilesByPlugin(changes)
 
-	// Run each plugin for its file group
+	// Run each plugin for its file group, counting the groups so long analyses show how far
+	// they got
+	var pluginNames []string
 	for pluginName, files := range fileGroups {
-		if len(files) == 0 {
+		if hasChangedFile(files) {
+			pluginNames = append(pluginNames, pluginName)
+		}
+	}
+	sort.Strings(pluginNames)
+
+	for i, pluginName := range pluginNames {
+		files := fileGroups[pluginName]
+		step := fmt.Sprintf("[%d/%d]", i+1, len(pluginNames))
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("dependency analysis cancelled: %w", err)
+		}
+
+		if strings.HasPrefix(pluginName, builtinPrefix) {
+			fmt.Fprintf(m.out, "🔍 %s Running %s on %d files...\n", step, pluginName, len(files))
+			builtin := m.builtins[strings.TrimPrefix(pluginName, builtinPrefix)]
+			dependencies, err := m.executeBuiltin(ctx, pluginName, builtin, files)
+			if err != nil {
+				fmt.Fprintf(m.out, "⚠️  Built-in analyzer '%s' failed: %v\n", pluginName, err)
+				continue
+			}
+			allDependencies = append(allDependencies, attribute(dependencies, strings.TrimPrefix(pluginName, builtinPrefix))...)
 			continue
 		}
 
 		plugin, exists := m.plugins[pluginName]
 		if !exists {
-			fmt.Printf("⚠️  Plugin '%s' not available, using fallback analysis\n", pluginName)
+			fmt.Fprintf(m.out, "⚠️  Plugin '%s' not available, using fallback analysis\n", pluginName)
 			// Use generic fallback analysis
 			fallbackDeps := m.fallbackAnalysis(files)
-			allDependencies = append(allDependencies, fallbackDeps...)
+			allDependencies = append(allDependencies, attribute(fallbackDeps, fallbackAnalyzerName)...)
 			continue
 		}
 
-		fmt.Printf("🔍 Running %s plugin on %d files...\n", plugin.Name, len(files))
+		fmt.Fprintf(m.out, "🔍 %s Running %s plugin on %d files...\n", step, plugin.Name, len(files))
 
-		dependencies, err := m.executePlugin(plugin, files)
+		dependencies, err := m.executePlugin(ctx, plugin, files)
 		if err != nil {
-			fmt.Printf("⚠️  Plugin '%s' failed: %v\n", plugin.Name, err)
-			fmt.Printf("🔄 Falling back to generic analysis for %s files\n", plugin.Name)
+			fmt.Fprintf(m.out, "⚠️  Plugin '%s' failed: %v\n", plugin.Name, err)
+			fmt.Fprintf(m.out, "🔄 Falling back to generic analysis for %s files\n", plugin.Name)
 
 			// Use fallback analysis
 			fallbackDeps := m.fallbackAnalysis(files)
-			allDependencies = append(allDependencies, fallbackDeps...)
+			allDependencies = append(allDependencies, attribute(fallbackDeps, fallbackAnalyzerName)...)
 			continue
 		}
 
-		fmt.Printf("✅ %s plugin found %d dependencies\n", plugin.Name, len(dependencies))
-		allDependencies = append(allDependencies, dependencies...)
+		fmt.Fprintf(m.out, "✅ %s plugin found %d dependencies\n", plugin.Name, len(dependencies))
+		allDependencies = append(allDependencies, attribute(dependencies, plugin.Name)...)
+	}
+
+	if len(m.generatedCode) > 0 {
+		pairs := proto.PairGenerated(changes, m.generatedCode)
+		fmt.Fprintf(m.out, "🧬 Paired %d generated files with their sources\n", len(pairs)/2)
+		allDependencies = append(allDependencies, attribute(pairs, generatedAnalyzerName)...)
+	}
+
+	if pairs := lockfile.Pair(changes, append(append([]types.PairingRule{}, m.pairingRules...), lockfile.DefaultRules...)); len(pairs) > 0 {
+		fmt.Fprintf(m.out, "🔒 Paired %d lockfiles with their manifests\n", len(pairs)/2)
+		allDependencies = append(allDependencies, attribute(pairs, lockfile.Name)...)
+	}
+
+	// Analyzers can report overlapping edges, e.g. a plugin and the generated-code pairing
+	merged := MergeDependencies(allDependencies, m.priority)
+	if duplicates := len(allDependencies) - len(merged); duplicates > 0 {
+		fmt.Fprintf(m.out, "🔗 Merged %d duplicate dependencies\n", duplicates)
 	}
+	allDependencies = merged
 
 	return allDependencies, nil
 }
 
+// WantsProjectContext reports whether a plugin that analyzes one of the changed files asks for
+// the unchanged project files. Built-in analyzers and the fallback analysis need changed files only.
+func (m *Manager) WantsProjectContext(changes []types.FileChange) bool {
+	for pluginName, files := range m.groupFilesByPlugin(changes) {
+		if plugin, ok := m.plugins[pluginName]; ok && plugin.ProjectContext && hasChangedFile(files) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasChangedFile reports whether a file group holds a changed file; groups of project context
+// alone are not analyzed
+func hasChangedFile(files []types.FileChange) bool {
+	for _, file := range files {
+		if file.IsChanged {
+			return true
+		}
+	}
+	return false
+}
+
 // groupFilesByPlugin groups files by their appropriate plugin
 func (m *Manager) groupFilesByPlugin(files []types.FileChange) map[string][]types.FileChange {
 	groups := make(map[string][]types.FileChange)
@@ -255,24 +417,87 @@ func (m *Manager) groupFilesByPlugin(files []types.FileChange) map[string][]type
 	return groups
 }
 
-// getPluginForFile determines which plugin should handle a file
+// getPluginForFile determines which plugin should handle a file. External plugins win over
+// built-in analyzers unless a higher priority is configured; ties are broken by name.
 func (m *Manager) getPluginForFile(filePath string) string {
 	ext := strings.ToLower(filepath.Ext(filePath))
 
+	best := ""
+	bestPriority := 0
+	bestExternal := false
+
+	consider := func(key, name string, external bool) {
+		priority := m.priority[name]
+		switch {
+		case best == "":
+		case priority != bestPriority:
+			if priority < bestPriority {
+				return
+			}
+		case external != bestExternal:
+			if !external {
+				return
+			}
+		case key > best:
+			return
+		}
+		best, bestPriority, bestExternal = key, priority, external
+	}
+
 	// Check each plugin's supported extensions
 	for pluginName, plugin := range m.plugins {
 		for _, supportedExt := range plugin.Extensions {
 			if ext == supportedExt {
-				return pluginName
+				consider(pluginName, plugin.Name, true)
 			}
 		}
 	}
 
-	return "" // No plugin found
+	// Built-in analyzers need no setup, so they cover extensions no plugin claims
+	for name, builtin := range m.builtins {
+		for _, supportedExt := range builtin.Extensions() {
+			if ext == supportedExt {
+				consider(builtinPrefix+name, name, false)
+			}
+		}
+	}
+
+	return best // Empty when no plugin handles the extension
+}
+
+// executeBuiltin runs a built-in analyzer on its file group
+func (m *Manager) executeBuiltin(ctx context.Context, name string, builtin BuiltinAnalyzer, files []types.FileChange) ([]types.Dependency, error) {
+	var changedFiles []types.FileChange
+	var projectFiles []types.FileChange
+
+	for _, file := range files {
+		if file.IsChanged {
+			changedFiles = append(changedFiles, file)
+		} else {
+			projectFiles = append(projectFiles, file)
+		}
+	}
+
+	output, err := builtin.Analyze(types.PluginInput{
+		ChangedFiles: changedFiles,
+		ProjectFiles: projectFiles,
+		ProjectRoot:  m.getProjectRoot(ctx),
+		IncludePaths: m.includePaths,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, errMsg := range output.Errors {
+		fmt.Fprintf(m.out, "⚠️  %s: %s\n", name, errMsg)
+	}
+
+	fmt.Fprintf(m.out, "✅ %s found %d dependencies\n", name, len(output.Dependencies))
+	return output.Dependencies, nil
 }
 
 // executePlugin runs a plugin and returns its analysis results
-func (m *Manager) executePlugin(plugin *Plugin, files []types.FileChange) ([]types.Dependency, error) {
+func (m *Manager) executePlugin(ctx context.Context, plugin *Plugin, files []types.FileChange) ([]types.Dependency, error) {
 	startTime := time.Now()
 
 	// Separate changed files from project context files
@@ -282,7 +507,7 @@ func (m *Manager) executePlugin(plugin *Plugin, files []types.FileChange) ([]typ
 	for _, file := range files {
 		if file.IsChanged {
 			changedFiles = append(changedFiles, file)
-		} else {
+		} else if plugin.ProjectContext {
 			projectFiles = append(projectFiles, file)
 		}
 	}
@@ -291,56 +516,75 @@ func (m *Manager) executePlugin(plugin *Plugin, files []types.FileChange) ([]typ
 	input := types.PluginInput{
 		ChangedFiles: changedFiles,
 		ProjectFiles: projectFiles,
-		ProjectRoot:  m.getProjectRoot(),
+		ProjectRoot:  m.getProjectRoot(ctx),
+		IncludePaths: m.includePaths,
 	}
 
-	// Convert to JSON
-	inputJSON, err := json.Marshal(input)
+	pluginOutput, err := m.runPlugin(ctx, plugin, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal plugin input: %w", err)
+		return nil, err
 	}
 
-	// Execute plugin with appropriate runtime and timeout
-	var cmd *exec.Cmd
-	if plugin.Runtime != "" {
-		// Use specified runtime
-		cmd = exec.Command(plugin.Runtime, plugin.Executable)
-	} else {
-		// Try to detect runtime from executable extension
-		ext := strings.ToLower(filepath.Ext(plugin.Executable))
-		switch ext {
-		case ".js":
-			cmd = exec.Command("node", plugin.Executable)
-		case ".py":
-			cmd = exec.Command("python3", plugin.Executable)
-		default:
-			// Assume it's a binary
-			cmd = exec.Command(plugin.Executable)
+	// Check for plugin errors
+	if len(pluginOutput.Errors) > 0 {
+		fmt.Fprintf(m.out, "⚠️  Plugin '%s' reported errors:\n", plugin.Name)
+		for _, errMsg := range pluginOutput.Errors {
+			fmt.Fprintf(m.out, "   - %s\n", errMsg)
 		}
 	}
 
-	// Set up input/output pipes
-	cmd.Stdin = strings.NewReader(string(inputJSON))
+	// Update metadata with timing
+	duration := time.Since(startTime)
+	pluginOutput.Metadata.AnalysisTime = duration.String()
+
+	fmt.Fprintf(m.out, "📊 Plugin analysis completed in %s\n", duration)
+
+	return pluginOutput.Dependencies, nil
+}
 
-	// Add timeout context (30 seconds)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// runPlugin executes a plugin with the given input and returns its validated output
+func (m *Manager) runPlugin(ctx context.Context, plugin *Plugin, input types.PluginInput) (*types.PluginOutput, error) {
+	// Plugins expect arrays, never null
+	if input.ChangedFiles == nil {
+		input.ChangedFiles = []types.FileChange{}
+	}
+	if input.ProjectFiles == nil {
+		input.ProjectFiles = []types.FileChange{}
+	}
+
+	// Convert to JSON
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin input: %w", err)
+	}
+
+	// Add timeout context (30 seconds), still cancelled with the caller's context
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Create command with context
-	cmdWithTimeout := exec.CommandContext(ctx, cmd.Args[0], cmd.Args[1:]...)
-	cmdWithTimeout.Stdin = cmd.Stdin
-	cmdWithTimeout.Dir = cmd.Dir
+	// Execute plugin with its runtime: an interpreter for scripts, cmd.exe for batch files
+	cmd, err := pluginCommand(ctx, plugin)
+	if err != nil {
+		return nil, fmt.Errorf("plugin '%s' %w", plugin.Name, err)
+	}
+	cmd.Stdin = strings.NewReader(string(inputJSON))
 
 	// Capture output with timeout
-	output, err := cmdWithTimeout.Output()
+	output, err := cmd.Output()
 	if err != nil {
 		// Check if it was a timeout
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("plugin '%s' timed out after 30 seconds", plugin.Name)
 		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("plugin '%s' cancelled: %w", plugin.Name, ctx.Err())
+		}
 
 		// Get stderr for better error reporting
 		if exitError, ok := err.(*exec.ExitError); ok {
+			if len(exitError.Stderr) == 0 && len(output) > 0 {
+				return nil, fmt.Errorf("plugin '%s' execution failed: %s\nOutput: %s", plugin.Name, err, string(output))
+			}
 			return nil, fmt.Errorf("plugin '%s' execution failed: %s\nStderr: %s", plugin.Name, err, string(exitError.Stderr))
 		}
 		return nil, fmt.Errorf("plugin '%s' execution failed: %w", plugin.Name, err)
@@ -357,33 +601,34 @@ func (m *Manager) executePlugin(plugin *Plugin, files []types.FileChange) ([]typ
 		return nil, fmt.Errorf("plugin '%s' output validation failed: %w", plugin.Name, err)
 	}
 
-	// Check for plugin errors
-	if len(pluginOutput.Errors) > 0 {
-		fmt.Printf("⚠️  Plugin '%s' reported errors:\n", plugin.Name)
-		for _, errMsg := range pluginOutput.Errors {
-			fmt.Printf("   - %s\n", errMsg)
-		}
+	// Plugins on Windows may report paths with backslashes; changes use forward slashes
+	for i := range pluginOutput.Dependencies {
+		pluginOutput.Dependencies[i].From = filepath.ToSlash(pluginOutput.Dependencies[i].From)
+		pluginOutput.Dependencies[i].To = filepath.ToSlash(pluginOutput.Dependencies[i].To)
 	}
 
-	// Update metadata with timing
-	duration := time.Since(startTime)
-	pluginOutput.Metadata.AnalysisTime = duration.String()
-
-	fmt.Printf("📊 Plugin analysis completed in %s\n", duration)
+	return &pluginOutput, nil
+}
 
-	return pluginOutput.Dependencies, nil
+// SetWorkingDir analyzes the checkout at dir instead of the process working directory
+func (m *Manager) SetWorkingDir(dir string) {
+	m.workingDir = dir
 }
 
 // getProjectRoot returns the project root directory
-func (m *Manager) getProjectRoot() string {
+func (m *Manager) getProjectRoot(ctx context.Context) string {
 	// Try to find git root
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	output, err := cmd.Output()
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel")
+	cmd.Dir = m.workingDir
+	output, err := git.Output(cmd)
 	if err == nil {
-		return strings.TrimSpace(string(output))
+		return filepath.Clean(strings.TrimSpace(string(output))) // git prints C:/... on Windows
 	}
 
-	// Fallback to current working directory
+	// Fallback to the working directory
+	if m.workingDir != "" {
+		return m.workingDir
+	}
 	wd, _ := os.Getwd()
 	return wd
 }
@@ -392,7 +637,10 @@ func (m *Manager) getProjectRoot() string {
 func (m *Manager) fallbackAnalysis(files []types.FileChange) []types.Dependency {
 	var dependencies []types.Dependency
 
-	fmt.Printf("🔍 Running fallback analysis on %d files...\n", len(files))
+	fmt.Fprintf(m.out, "🔍 Running fallback analysis on %d files...\n", len(files))
+
+	// tsconfig/jsconfig baseUrl and paths aliases, e.g. "@app/utils/foo"
+	aliases := typescript.NewPathResolver(m.getProjectRoot(context.Background()))
 
 	// Create a map of all available files for quick lookup
 	availableFiles := make(map[string]bool)
@@ -414,17 +662,17 @@ func (m *Manager) fallbackAnalysis(files []types.FileChange) []types.Dependency
 		}
 
 		// Simple regex-based import detection
-		fileDeps := m.extractImportsFromContent(file.Content, file.Path, availableFiles)
+		fileDeps := m.extractImportsFromContent(file.Content, file.Path, availableFiles, aliases)
 		dependencies = append(dependencies, fileDeps...)
 	}
 
-	fmt.Printf("📊 Fallback analysis found %d dependencies\n", len(dependencies))
+	fmt.Fprintf(m.out, "📊 Fallback analysis found %d dependencies\n", len(dependencies))
 
 	return dependencies
 }
 
 // extractImportsFromContent uses regex to find import statements
-func (m *Manager) extractImportsFromContent(content, filePath string, availableFiles map[string]bool) []types.Dependency {
+func (m *Manager) extractImportsFromContent(content, filePath string, availableFiles map[string]bool, aliases *typescript.PathResolver) []types.Dependency {
 	var dependencies []types.Dependency
 
 	lines := strings.Split(content, "\n")
@@ -456,6 +704,9 @@ func (m *Manager) extractImportsFromContent(content, filePath string, availableF
 		if importPath != "" {
 			// Resolve relative imports
 			resolvedPath := m.resolveImportPath(importPath, baseDir, availableFiles)
+			if resolvedPath == "" {
+				resolvedPath = m.resolveAliasedImport(importPath, filePath, availableFiles, aliases)
+			}
 
 			if resolvedPath != "" {
 				dependency := types.Dependency{
@@ -499,6 +750,16 @@ func (m *Manager) resolveImportPath(importPath, baseDir string, availableFiles m
 	return ""
 }
 
+// resolveAliasedImport resolves a non-relative import through tsconfig baseUrl/paths
+func (m *Manager) resolveAliasedImport(importPath, filePath string, availableFiles map[string]bool, aliases *typescript.PathResolver) string {
+	for _, candidate := range aliases.Candidates(importPath, filePath) {
+		if resolved := typescript.ResolveFile(candidate, availableFiles); resolved != "" {
+			return resolved
+		}
+	}
+	return ""
+}
+
 // GetAvailablePlugins returns information about available plugins
 func (m *Manager) GetAvailablePlugins() map[string]*Plugin {
 	return m.plugins
@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"sort"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// fallbackAnalyzerName attributes edges found by the generic regex analysis
+const fallbackAnalyzerName = "fallback"
+
+// generatedAnalyzerName attributes edges that pair schema files with generated code
+const generatedAnalyzerName = "generated-code"
+
+// attribute records the analyzer that produced each dependency
+func attribute(dependencies []types.Dependency, analyzer string) []types.Dependency {
+	for i := range dependencies {
+		if len(dependencies[i].Analyzers) == 0 {
+			dependencies[i].Analyzers = []string{analyzer}
+		}
+	}
+	return dependencies
+}
+
+// MergeDependencies collapses edges reported more than once for the same (from, to) pair.
+// The merged edge keeps the strongest strength, lists every analyzer that reported it, and takes
+// its type, line and context from the highest-priority analyzer. Fallback analysis ranks below
+// every named analyzer unless it is given a priority.
+func MergeDependencies(dependencies []types.Dependency, priority map[string]int) []types.Dependency {
+	type edgeKey struct{ from, to string }
+
+	rank := func(dep types.Dependency) int {
+		best := -1 << 31
+		for _, analyzer := range dep.Analyzers {
+			value, ok := priority[analyzer]
+			if !ok && analyzer == fallbackAnalyzerName {
+				value = -1
+			}
+			if value > best {
+				best = value
+			}
+		}
+		return best
+	}
+
+	merged := make(map[edgeKey]*types.Dependency)
+	var order []edgeKey
+
+	for _, dep := range dependencies {
+		key := edgeKey{dep.From, dep.To}
+		existing, ok := merged[key]
+		if !ok {
+			copied := dep
+			copied.Analyzers = append([]string(nil), dep.Analyzers...)
+			merged[key] = &copied
+			order = append(order, key)
+			continue
+		}
+
+		strength := existing.Strength
+		if dep.Strength.Rank() > strength.Rank() {
+			strength = dep.Strength
+		}
+		analyzers := appendUnique(existing.Analyzers, dep.Analyzers...)
+
+		if rank(dep) > rank(*existing) {
+			*existing = dep
+		}
+		existing.Strength = strength
+		existing.Analyzers = analyzers
+	}
+
+	result := make([]types.Dependency, 0, len(order))
+	for _, key := range order {
+		dep := merged[key]
+		sort.Strings(dep.Analyzers)
+		result = append(result, *dep)
+	}
+	return result
+}
+
+func appendUnique(values []string, extra ...string) []string {
+	seen := make(map[string]bool)
+	for _, value := range values {
+		seen[value] = true
+	}
+	for _, value := range extra {
+		if !seen[value] {
+			values = append(values, value)
+			seen[value] = true
+		}
+	}
+	return values
+}
@@ -0,0 +1,119 @@
+package projectcontext
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/state"
+	"pr-splitter-cli/internal/types"
+)
+
+// DefaultCacheTTL is how long a collected project context is reused when the configuration
+// does not say
+const DefaultCacheTTL = 15 * time.Minute
+
+// cacheDir is the directory under the state directory holding cached project contexts
+const cacheDir = "cache"
+
+// Key identifies a collected project context: the source commit it was read for, the import
+// depth and the changed files it was collected from
+type Key struct {
+	SourceCommit string `json:"sourceCommit"`
+	Depth        int    `json:"depth"`
+	Changes      string `json:"changes"` // Digest of the changed paths
+}
+
+// NewKey returns the key of the context collected for changes of sourceCommit at depth
+func NewKey(sourceCommit string, depth int, changes []types.FileChange) Key {
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		paths = append(paths, string(change.ChangeType)+" "+change.Path)
+	}
+	sort.Strings(paths)
+	digest := sha256.Sum256([]byte(strings.Join(paths, "\n")))
+	return Key{SourceCommit: sourceCommit, Depth: depth, Changes: hex.EncodeToString(digest[:])}
+}
+
+// cacheEntry is a cached project context as stored on disk
+type cacheEntry struct {
+	Key       Key                `json:"key"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Files     []types.FileChange `json:"files"`
+}
+
+// Cache keeps collected project contexts in the state directory so a re-run for the same
+// source commit, such as after rejecting a plan, does not scan the project again
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewCache creates a cache for the repository whose git common dir is gitDir; entries older
+// than ttl are not reused
+func NewCache(gitDir string, ttl time.Duration) *Cache {
+	return &Cache{dir: filepath.Join(gitDir, state.Dir, cacheDir), ttl: ttl}
+}
+
+// Load returns the files cached for key, or false when there is no fresh entry for it
+func (c *Cache) Load(key Key) ([]types.FileChange, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil || entry.Key != key || time.Since(entry.CreatedAt) > c.ttl {
+		return nil, false
+	}
+	return entry.Files, true
+}
+
+// Save stores the files collected for key, written atomically, and removes expired entries
+func (c *Cache) Save(key Key, files []types.FileChange) error {
+	data, err := json.Marshal(cacheEntry{Key: key, CreatedAt: time.Now().UTC(), Files: files})
+	if err != nil {
+		return fmt.Errorf("failed to encode project context: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", c.dir, err)
+	}
+	c.prune()
+
+	target := c.path(key)
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write project context cache: %w", err)
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write project context cache: %w", err)
+	}
+	return nil
+}
+
+// path is the file of the entry for key; one entry is kept per source commit
+func (c *Cache) path(key Key) string {
+	return filepath.Join(c.dir, "context-"+key.SourceCommit+".json")
+}
+
+// prune removes the entries written longer than the TTL ago
+func (c *Cache) prune() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "context-") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && time.Since(info.ModTime()) > c.ttl {
+			os.Remove(filepath.Join(c.dir, entry.Name()))
+		}
+	}
+}
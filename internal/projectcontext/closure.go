@@ -0,0 +1,178 @@
+package projectcontext
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/analyzer/typescript"
+	"pr-splitter-cli/internal/types"
+)
+
+// DefaultDepth is how many levels of imports are followed from the changed files when the
+// configuration does not say
+const DefaultDepth = 3
+
+// ReadFile returns the content of a project file by its repository path
+type ReadFile func(filePath string) (string, error)
+
+var (
+	pythonFromImport = regexp.MustCompile(`^\s*from\s+(\.*)([\w.]*)\s+import\s+\(?([\w\s,.*]+)`)
+	pythonImport     = regexp.MustCompile(`^\s*import\s+([\w\s,.]+)`)
+)
+
+// Closure returns the project files the changed files import, directly or through up to
+// depth levels of imports, as unchanged context. paths lists the candidate files; only the
+// files reached are read. TypeScript and JavaScript imports (with tsconfig aliases, read with
+// read as well) and Python imports are followed.
+func Closure(changes []types.FileChange, paths []string, read ReadFile, depth int) []types.FileChange {
+	known := make(map[string]bool, len(paths)+len(changes))
+	for _, filePath := range paths {
+		known[filePath] = true
+	}
+
+	visited := make(map[string]bool)
+	var frontier []types.FileChange
+	for _, change := range changes {
+		visited[change.Path] = true
+		if change.ChangeType != types.ChangeTypeDelete {
+			known[change.Path] = true
+			frontier = append(frontier, change)
+		}
+	}
+
+	aliases := typescript.NewPathResolverFrom(func(configPath string) ([]byte, error) {
+		content, err := read(configPath)
+		return []byte(content), err
+	})
+	var context []types.FileChange
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		var next []types.FileChange
+		for _, file := range frontier {
+			for _, target := range imports(file, known, aliases) {
+				if visited[target] {
+					continue
+				}
+				visited[target] = true
+
+				content, err := read(target)
+				if err != nil {
+					continue
+				}
+				imported := types.FileChange{Path: target, Content: content}
+				context = append(context, imported)
+				next = append(next, imported)
+			}
+		}
+		frontier = next
+	}
+
+	sort.Slice(context, func(i, j int) bool { return context[i].Path < context[j].Path })
+	return context
+}
+
+// imports returns the known files a file imports
+func imports(file types.FileChange, known map[string]bool, aliases *typescript.PathResolver) []string {
+	var targets []string
+	switch path.Ext(file.Path) {
+	case ".ts", ".tsx", ".js", ".jsx", ".mts", ".cts", ".mjs", ".cjs":
+		for _, specifier := range typescript.Imports(file.Content) {
+			if target := typescript.Resolve(specifier, file.Path, known, aliases); target != "" {
+				targets = append(targets, target)
+			}
+		}
+	case ".py", ".pyi":
+		targets = pythonImports(file.Content, file.Path, known)
+	}
+	return targets
+}
+
+// pythonImports resolves the modules a Python file imports: relative imports against its
+// package, absolute ones against the directories above it, as sys.path roots usually are
+func pythonImports(content, fromFile string, known map[string]bool) []string {
+	var targets []string
+
+	for _, line := range strings.Split(content, "\n") {
+		if match := pythonFromImport.FindStringSubmatch(line); match != nil {
+			dots, module := len(match[1]), strings.ReplaceAll(match[2], ".", "/")
+			names := strings.Split(match[3], ",")
+
+			var bases []string
+			if dots > 0 {
+				dir := path.Dir(fromFile)
+				for i := 1; i < dots; i++ {
+					dir = path.Dir(dir)
+				}
+				bases = []string{path.Join(dir, module)}
+			} else {
+				bases = rootedModules(fromFile, module)
+			}
+
+			for _, base := range bases {
+				found := false
+				if target := resolvePythonModule(base, known); target != "" {
+					targets = append(targets, target)
+					found = true
+				}
+				// "from pkg import mod" may name submodules rather than attributes
+				for _, name := range names {
+					name = strings.TrimSpace(strings.SplitN(strings.TrimSpace(name), " ", 2)[0])
+					if name == "" || name == "*" {
+						continue
+					}
+					if target := resolvePythonModule(path.Join(base, name), known); target != "" {
+						targets = append(targets, target)
+						found = true
+					}
+				}
+				if found {
+					break
+				}
+			}
+			continue
+		}
+
+		if match := pythonImport.FindStringSubmatch(line); match != nil {
+			for _, name := range strings.Split(match[1], ",") {
+				name = strings.TrimSpace(strings.SplitN(strings.TrimSpace(name), " ", 2)[0])
+				if name == "" {
+					continue
+				}
+				for _, base := range rootedModules(fromFile, strings.ReplaceAll(name, ".", "/")) {
+					if target := resolvePythonModule(base, known); target != "" {
+						targets = append(targets, target)
+						break
+					}
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// rootedModules returns the paths an absolute module may live at: under the directory of the
+// importing file and each directory above it, up to the repository root
+func rootedModules(fromFile, module string) []string {
+	var bases []string
+	for dir := path.Dir(fromFile); ; dir = path.Dir(dir) {
+		bases = append(bases, path.Join(dir, module))
+		if dir == "." || dir == "/" {
+			return bases
+		}
+	}
+}
+
+// resolvePythonModule maps a module path to its source file or package __init__, "" when it
+// is not a known file
+func resolvePythonModule(base string, known map[string]bool) string {
+	if base == "" || base == "." || strings.HasPrefix(base, "../") {
+		return ""
+	}
+	for _, candidate := range []string{base + ".py", base + ".pyi", path.Join(base, "__init__.py")} {
+		if known[candidate] {
+			return candidate
+		}
+	}
+	return ""
+}
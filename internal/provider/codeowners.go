@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/pathglob"
+	"pr-splitter-cli/internal/types"
+)
+
+// CodeOwnersLocations are the paths GitHub reads CODEOWNERS from, in order of precedence
+var CodeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwnersRule maps a CODEOWNERS path pattern to its owners
+type CodeOwnersRule struct {
+	Pattern string
+	Owners  []string
+}
+
+// ParseCodeOwners reads the rules of a CODEOWNERS file, skipping comments and blank lines
+func ParseCodeOwners(content string) []CodeOwnersRule {
+	var rules []CodeOwnersRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, CodeOwnersRule{Pattern: fields[0], Owners: fields[1:]})
+	}
+	return rules
+}
+
+// CodeOwnersOf returns the owners of a path. As on GitHub, the last matching rule wins, and a
+// matching rule without owners leaves the path unowned.
+func CodeOwnersOf(rules []CodeOwnersRule, filePath string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if matchCodeOwnersPattern(rules[i].Pattern, filePath) {
+			return rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// matchCodeOwnersPattern applies gitignore-style matching: patterns without a slash match a file
+// or directory name at any depth, others are anchored at the root, and a matching directory
+// covers everything below it ("docs/*" only covers direct children)
+func matchCodeOwnersPattern(pattern, filePath string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	segments := strings.Split(filePath, "/")
+
+	if !strings.Contains(pattern, "/") {
+		re := pathglob.Compile(pattern)
+		for i, segment := range segments {
+			if dirOnly && i == len(segments)-1 {
+				break
+			}
+			if re.MatchString(segment) {
+				return true
+			}
+		}
+		return false
+	}
+
+	re := pathglob.Compile(strings.TrimPrefix(pattern, "/"))
+	if !dirOnly && re.MatchString(filePath) {
+		return true
+	}
+	if strings.HasSuffix(pattern, "/*") {
+		return false
+	}
+	for i := 1; i < len(segments); i++ {
+		if re.MatchString(strings.Join(segments[:i], "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// PartitionReviewers returns the owners of a partition's files. Files matching an override glob
+// take the override's owners instead of their CODEOWNERS entry.
+func PartitionReviewers(rules []CodeOwnersRule, overrides map[string][]string, files []types.FileChange) []string {
+	globs := make([]string, 0, len(overrides))
+	for glob := range overrides {
+		globs = append(globs, glob)
+	}
+	sort.Strings(globs)
+
+	seen := make(map[string]bool)
+	var reviewers []string
+	for _, file := range files {
+		var owners []string
+		overridden := false
+		for _, glob := range globs {
+			if pathglob.Match(glob, file.Path) {
+				owners = append(owners, overrides[glob]...)
+				overridden = true
+			}
+		}
+		if !overridden {
+			owners = CodeOwnersOf(rules, file.Path)
+		}
+
+		for _, owner := range owners {
+			if !seen[owner] {
+				seen[owner] = true
+				reviewers = append(reviewers, owner)
+			}
+		}
+	}
+	sort.Strings(reviewers)
+	return reviewers
+}
+
+// SplitReviewers separates "@user" and "@org/team" owners into user logins and team slugs.
+// Email owners cannot be requested through the API and are returned separately.
+func SplitReviewers(owners []string) (users, teams, skipped []string) {
+	for _, owner := range owners {
+		switch {
+		case !strings.HasPrefix(owner, "@"):
+			skipped = append(skipped, owner)
+		case strings.Contains(owner, "/"):
+			teams = append(teams, owner[strings.Index(owner, "/")+1:])
+		default:
+			users = append(users, strings.TrimPrefix(owner, "@"))
+		}
+	}
+	return users, teams, skipped
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// DescriptionMarker identifies PR bodies written by pr-split so they can be updated in place
+const DescriptionMarker = "<!-- pr-split-description -->"
+
+// DescriptionsDir holds the generated PR descriptions, relative to the repository root
+const DescriptionsDir = ".pr-split/descriptions"
+
+// PartitionTitle is the PR title of the partition at index in the split
+func PartitionTitle(result *types.SplitResult, index int) string {
+	return fmt.Sprintf("[%d/%d] %s", index+1, len(result.Partitions), result.Partitions[index].Description)
+}
+
+// PartitionLabels expands the {index} and {total} placeholders of the configured labels for the
+// partition at index, e.g. "stack:{index}/{total}" becomes "stack:3/7"
+func PartitionLabels(labels []string, index, total int) []string {
+	replacer := strings.NewReplacer("{index}", fmt.Sprint(index+1), "{total}", fmt.Sprint(total))
+	expanded := make([]string, len(labels))
+	for i, label := range labels {
+		expanded[i] = replacer.Replace(label)
+	}
+	return expanded
+}
+
+// RenderDescription builds the markdown PR body of the partition at index. prs maps partition
+// IDs to PR numbers; partitions without a PR are shown by branch name as a placeholder.
+func RenderDescription(result *types.SplitResult, index int, prs map[int]int) string {
+	partition := result.Partitions[index]
+	total := len(result.Partitions)
+
+	ref := func(id int) string {
+		for _, p := range result.Partitions {
+			if p.ID != id {
+				continue
+			}
+			if number, ok := prs[id]; ok {
+				return fmt.Sprintf("#%d", number)
+			}
+			return fmt.Sprintf("`%s` _(PR not opened yet)_", p.BranchName)
+		}
+		return fmt.Sprintf("partition %d", id)
+	}
+
+	var deps, dependents []string
+	for _, id := range partition.Dependencies {
+		deps = append(deps, ref(id))
+	}
+	for _, p := range result.Partitions {
+		for _, id := range p.Dependencies {
+			if id == partition.ID {
+				dependents = append(dependents, ref(p.ID))
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(DescriptionMarker + "\n")
+	b.WriteString(stackLine(index, total, deps, dependents) + "\n\n")
+	fmt.Fprintf(&b, "## %s\n\n", partition.Description)
+
+	added, deleted := 0, 0
+	for _, file := range partition.Files {
+		added += file.LinesAdded
+		deleted += file.LinesDeleted
+	}
+	fmt.Fprintf(&b, "Split from `%s`: %d files, +%d/−%d lines.\n\n",
+		result.SourceBranch, len(partition.Files), added, deleted)
+
+	b.WriteString("### Merge order\n\n")
+	if len(deps) == 0 {
+		fmt.Fprintf(&b, "No dependencies: this PR can be reviewed and merged into `%s` on its own.\n", result.TargetBranch)
+	} else {
+		fmt.Fprintf(&b, "Depends on %s. Merge those first.\n", strings.Join(deps, ", "))
+	}
+	if len(dependents) > 0 {
+		fmt.Fprintf(&b, "Required by %s.\n", strings.Join(dependents, ", "))
+	}
+
+	b.WriteString("\n<details><summary>Full stack</summary>\n\n")
+	b.WriteString("| # | Partition | PR |\n|---|-----------|----|\n")
+	for i, p := range result.Partitions {
+		marker := ""
+		if i == index {
+			marker = " 👈"
+		}
+		fmt.Fprintf(&b, "| %d | %s | %s%s |\n", i+1, p.Description, ref(p.ID), marker)
+	}
+	b.WriteString("\n</details>\n\n")
+
+	b.WriteString("### Files\n")
+	writeFileGroups(&b, partition.Files)
+
+	if len(partition.Checklist) > 0 {
+		b.WriteString("\n### Review checklist\n\n")
+		for _, item := range partition.Checklist {
+			fmt.Fprintf(&b, "- [ ] %s\n", item)
+		}
+	}
+
+	if result.RunID != "" {
+		fmt.Fprintf(&b, "\n<sub>Generated by pr-split run `%s`</sub>\n", result.RunID)
+	}
+	return b.String()
+}
+
+// stackLine is the one-line position of a partition in the stack, e.g. "Part 3/7, depends on #123"
+func stackLine(index, total int, deps, dependents []string) string {
+	line := fmt.Sprintf("> **Part %d/%d**", index+1, total)
+	if len(deps) > 0 {
+		line += ", depends on " + strings.Join(deps, ", ")
+	}
+	if len(dependents) > 0 {
+		line += ", required by " + strings.Join(dependents, ", ")
+	}
+	return line
+}
+
+// writeFileGroups lists files under a heading per operation
+func writeFileGroups(b *strings.Builder, files []types.FileChange) {
+	groups := []struct {
+		title      string
+		changeType types.ChangeType
+	}{
+		{"Added", types.ChangeTypeAdd},
+		{"Modified", types.ChangeTypeModify},
+		{"Renamed", types.ChangeTypeRename},
+		{"Deleted", types.ChangeTypeDelete},
+	}
+
+	for _, group := range groups {
+		var lines []string
+		for _, file := range files {
+			if file.ChangeType != group.changeType {
+				continue
+			}
+			if file.ChangeType == types.ChangeTypeRename && file.OldPath != "" {
+				lines = append(lines, fmt.Sprintf("- `%s` → `%s`", file.OldPath, file.Path))
+			} else {
+				lines = append(lines, fmt.Sprintf("- `%s`", file.Path))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		sort.Strings(lines)
+		fmt.Fprintf(b, "\n**%s (%d)**\n", group.title, len(lines))
+		b.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+}
+
+// DescriptionPath is where the description of a branch is written under the repository root
+func DescriptionPath(root, branch string) string {
+	return filepath.Join(root, DescriptionsDir, branch+".md")
+}
+
+// WriteDescriptions writes every partition's PR description under the repository root. The
+// .pr-split directory ignores itself so the files never end up in partition commits.
+func WriteDescriptions(root string, result *types.SplitResult, prs map[int]int) error {
+	stateDir := filepath.Join(root, filepath.Dir(DescriptionsDir))
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", stateDir, err)
+	}
+	if err := os.WriteFile(filepath.Join(stateDir, ".gitignore"), []byte("*\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s/.gitignore: %w", stateDir, err)
+	}
+
+	for i, partition := range result.Partitions {
+		path := DescriptionPath(root, partition.BranchName)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(RenderDescription(result, i, prs)), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,522 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"pr-splitter-cli/internal/git"
+)
+
+// DefaultAPIURL is the GitHub REST API root used when GITHUB_API_URL is unset
+const DefaultAPIURL = "https://api.github.com"
+
+// PullRequest is the subset of a hosted pull request the splitter needs
+type PullRequest struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	State    string     `json:"state"`
+	Draft    bool       `json:"draft"`
+	HTMLURL  string     `json:"html_url"`
+	MergedAt *time.Time `json:"merged_at"`
+	Head     struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// ReviewThread is an unresolved review conversation anchored to a file
+type ReviewThread struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Outdated bool   `json:"outdated"` // Anchor no longer matches the PR head
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+	URL      string `json:"url"`
+}
+
+// GitHub talks to the GitHub REST and GraphQL APIs for a single repository
+type GitHub struct {
+	Owner      string
+	Repo       string
+	HeadOwner  string // Owner of the repository PR branches are pushed to; differs from Owner for a fork
+	apiURL     string
+	graphQLURL string
+	token      string
+	httpClient *http.Client
+	limiter    *limiter
+}
+
+// remotePattern matches owner/repo in SSH (git@host:owner/repo) and URL-style (https://host/owner/repo) remotes
+var remotePattern = regexp.MustCompile(`^(?:[a-z+]+://(?:[^@/]+@)?[^/]+/|[^@/]+@[^:/]+:)([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// NewGitHubFromRemote creates a client for the repository behind the origin remote of dir
+func NewGitHubFromRemote(dir string) (*GitHub, error) {
+	return NewGitHubForRemotes(dir, "origin", "origin", "")
+}
+
+// NewGitHubForRemotes creates a client for the repository behind baseRemote whose PR branches
+// are pushed to headRemote, e.g. upstream and a fork. The token is read from tokenEnv when set,
+// then from GITHUB_TOKEN or GH_TOKEN.
+func NewGitHubForRemotes(dir, baseRemote, headRemote, tokenEnv string) (*GitHub, error) {
+	owner, repo, err := remoteRepository(dir, baseRemote)
+	if err != nil {
+		return nil, err
+	}
+	headOwner := owner
+	if headRemote != baseRemote {
+		if headOwner, _, err = remoteRepository(dir, headRemote); err != nil {
+			return nil, err
+		}
+	}
+
+	var token string
+	if tokenEnv != "" {
+		token = os.Getenv(tokenEnv)
+	}
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	if token == "" && tokenEnv != "" {
+		return nil, fmt.Errorf("no GitHub token found: set %s, GITHUB_TOKEN or GH_TOKEN", tokenEnv)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no GitHub token found: set GITHUB_TOKEN or GH_TOKEN")
+	}
+
+	apiURL := os.Getenv("GITHUB_API_URL")
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	apiURL = strings.TrimSuffix(apiURL, "/")
+
+	// GitHub Enterprise serves GraphQL at /api/graphql next to the /api/v3 REST root
+	graphQLURL := apiURL + "/graphql"
+	if strings.HasSuffix(apiURL, "/api/v3") {
+		graphQLURL = strings.TrimSuffix(apiURL, "/v3") + "/graphql"
+	}
+
+	return &GitHub{
+		Owner:      owner,
+		Repo:       repo,
+		HeadOwner:  headOwner,
+		apiURL:     apiURL,
+		graphQLURL: graphQLURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		limiter:    newLimiter(DefaultLimits),
+	}, nil
+}
+
+// remoteRepository reads the GitHub owner and repository name of a remote of dir
+func remoteRepository(dir, remote string) (string, string, error) {
+	cmd := exec.Command("git", "remote", "get-url", remote)
+	cmd.Dir = dir
+	output, err := git.Output(cmd)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s remote: %w", remote, err)
+	}
+
+	match := remotePattern.FindStringSubmatch(strings.TrimSpace(string(output)))
+	if match == nil {
+		return "", "", fmt.Errorf("%s remote %q does not look like a GitHub repository", remote, strings.TrimSpace(string(output)))
+	}
+	return match[1], match[2], nil
+}
+
+// CrossRepository reports whether PR branches live in a fork rather than the base repository
+func (g *GitHub) CrossRepository() bool {
+	return g.HeadOwner != "" && g.HeadOwner != g.Owner
+}
+
+// HeadRef returns the head a PR from branch is opened with: owner:branch for a fork
+func (g *GitHub) HeadRef(branch string) string {
+	if g.CrossRepository() {
+		return g.HeadOwner + ":" + branch
+	}
+	return branch
+}
+
+// SetLimits replaces the concurrency and rate limits for subsequent calls
+func (g *GitHub) SetLimits(limits Limits) {
+	g.limiter = newLimiter(limits)
+}
+
+// FindPullRequest returns the open pull request whose head is branch, or nil if there is none
+func (g *GitHub) FindPullRequest(branch string) (*PullRequest, error) {
+	return g.findPullRequest(branch, "open")
+}
+
+// FindLatestPullRequests looks up the latest PR of each branch concurrently, within the configured limits
+func (g *GitHub) FindLatestPullRequests(branches []string) ([]*PullRequest, []error) {
+	pulls := make([]*PullRequest, len(branches))
+	errs := make([]error, len(branches))
+
+	var wg sync.WaitGroup
+	for i, branch := range branches {
+		wg.Add(1)
+		go func(i int, branch string) {
+			defer wg.Done()
+			pulls[i], errs[i] = g.FindLatestPullRequest(branch)
+		}(i, branch)
+	}
+	wg.Wait()
+
+	return pulls, errs
+}
+
+// FindLatestPullRequest returns the most recent pull request for branch in any state, or nil
+func (g *GitHub) FindLatestPullRequest(branch string) (*PullRequest, error) {
+	return g.findPullRequest(branch, "all")
+}
+
+// headOwner returns the owner PR branches are pushed under
+func (g *GitHub) headOwner() string {
+	if g.HeadOwner != "" {
+		return g.HeadOwner
+	}
+	return g.Owner
+}
+
+// findPullRequest looks up pull requests by head branch and state
+func (g *GitHub) findPullRequest(branch, state string) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("head", g.headOwner()+":"+branch)
+	query.Set("state", state)
+
+	var pulls []PullRequest
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls?%s", g.apiURL, g.Owner, g.Repo, query.Encode())
+	if err := g.request("GET", endpoint, nil, &pulls); err != nil {
+		return nil, err
+	}
+
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+	return &pulls[0], nil
+}
+
+// NewPullRequest describes a pull request to open
+type NewPullRequest struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+	Draft bool   `json:"draft,omitempty"`
+}
+
+// CreatePullRequest opens a pull request
+func (g *GitHub) CreatePullRequest(pr NewPullRequest) (*PullRequest, error) {
+	var created PullRequest
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls", g.apiURL, g.Owner, g.Repo)
+	if err := g.request("POST", endpoint, pr, &created); err != nil {
+		return nil, fmt.Errorf("failed to open PR for %s: %w", pr.Head, err)
+	}
+	return &created, nil
+}
+
+// UpdatePullRequestBody replaces the description of a pull request
+func (g *GitHub) UpdatePullRequestBody(number int, body string) error {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", g.apiURL, g.Owner, g.Repo, number)
+	if err := g.request("PATCH", endpoint, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("failed to update description of #%d: %w", number, err)
+	}
+	return nil
+}
+
+// UpdatePullRequestBase changes the branch a pull request merges into
+func (g *GitHub) UpdatePullRequestBase(number int, base string) error {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", g.apiURL, g.Owner, g.Repo, number)
+	if err := g.request("PATCH", endpoint, map[string]string{"base": base}, nil); err != nil {
+		return fmt.Errorf("failed to retarget #%d to %s: %w", number, base, err)
+	}
+	return nil
+}
+
+// AddLabels adds labels to a pull request, creating labels that do not exist yet
+func (g *GitHub) AddLabels(number int, labels []string) error {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/%d/labels", g.apiURL, g.Owner, g.Repo, number)
+	if err := g.request("POST", endpoint, map[string][]string{"labels": labels}, nil); err != nil {
+		return fmt.Errorf("failed to label #%d: %w", number, err)
+	}
+	return nil
+}
+
+// RequestReviewers requests reviews on a pull request from users and teams (by slug)
+func (g *GitHub) RequestReviewers(number int, users, teams []string) error {
+	body := map[string][]string{"reviewers": append([]string{}, users...), "team_reviewers": append([]string{}, teams...)}
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/requested_reviewers", g.apiURL, g.Owner, g.Repo, number)
+	if err := g.request("POST", endpoint, body, nil); err != nil {
+		return fmt.Errorf("failed to request reviewers on #%d: %w", number, err)
+	}
+	return nil
+}
+
+// CurrentUser returns the login of the authenticated user
+func (g *GitHub) CurrentUser() (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := g.request("GET", g.apiURL+"/user", nil, &user); err != nil {
+		return "", fmt.Errorf("failed to look up the authenticated user: %w", err)
+	}
+	return user.Login, nil
+}
+
+// Milestone is a GitHub milestone
+type Milestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// FindMilestone returns the number of the open milestone with the given title
+func (g *GitHub) FindMilestone(title string) (int, error) {
+	var milestones []Milestone
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/milestones?state=open&per_page=100", g.apiURL, g.Owner, g.Repo)
+	if err := g.request("GET", endpoint, nil, &milestones); err != nil {
+		return 0, fmt.Errorf("failed to list milestones: %w", err)
+	}
+	for _, milestone := range milestones {
+		if milestone.Title == title {
+			return milestone.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("no open milestone named %q", title)
+}
+
+// SetMilestone attaches a pull request to a milestone
+func (g *GitHub) SetMilestone(number, milestone int) error {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/%d", g.apiURL, g.Owner, g.Repo, number)
+	if err := g.request("PATCH", endpoint, map[string]int{"milestone": milestone}, nil); err != nil {
+		return fmt.Errorf("failed to set milestone of #%d: %w", number, err)
+	}
+	return nil
+}
+
+// reviewThreadsQuery fetches review threads with their first comment
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) {
+        nodes {
+          isResolved
+          isOutdated
+          path
+          line
+          comments(first: 1) { nodes { author { login } body url } }
+        }
+      }
+    }
+  }
+}`
+
+// UnresolvedReviewThreads lists the unresolved review threads of a pull request
+func (g *GitHub) UnresolvedReviewThreads(number int) ([]ReviewThread, error) {
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						Nodes []struct {
+							IsResolved bool   `json:"isResolved"`
+							IsOutdated bool   `json:"isOutdated"`
+							Path       string `json:"path"`
+							Line       int    `json:"line"`
+							Comments   struct {
+								Nodes []struct {
+									Author struct {
+										Login string `json:"login"`
+									} `json:"author"`
+									Body string `json:"body"`
+									URL  string `json:"url"`
+								} `json:"nodes"`
+							} `json:"comments"`
+						} `json:"nodes"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	payload := map[string]interface{}{
+		"query": reviewThreadsQuery,
+		"variables": map[string]interface{}{
+			"owner":  g.Owner,
+			"repo":   g.Repo,
+			"number": number,
+		},
+	}
+
+	if err := g.request("POST", g.graphQLURL, payload, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
+	}
+
+	var threads []ReviewThread
+	for _, node := range response.Data.Repository.PullRequest.ReviewThreads.Nodes {
+		if node.IsResolved {
+			continue
+		}
+
+		thread := ReviewThread{Path: node.Path, Line: node.Line, Outdated: node.IsOutdated}
+		if len(node.Comments.Nodes) > 0 {
+			first := node.Comments.Nodes[0]
+			thread.Author = first.Author.Login
+			thread.Body = first.Body
+			thread.URL = first.URL
+		}
+		threads = append(threads, thread)
+	}
+
+	return threads, nil
+}
+
+// ThreadsOnPaths returns the threads anchored to any of the given files
+func ThreadsOnPaths(threads []ReviewThread, paths []string) []ReviewThread {
+	pathSet := make(map[string]bool)
+	for _, path := range paths {
+		pathSet[path] = true
+	}
+
+	var matching []ReviewThread
+	for _, thread := range threads {
+		if pathSet[thread.Path] {
+			matching = append(matching, thread)
+		}
+	}
+	return matching
+}
+
+// request performs an authenticated API call and decodes the JSON response into out.
+// Rate-limited responses are retried after the delay the server asks for.
+func (g *GitHub) request(method, endpoint string, body interface{}, out interface{}) error {
+	_, err := g.send(method, endpoint, body, out)
+	return err
+}
+
+// requestPage fetches one page of a list endpoint into out and returns the URL of the next
+// page from the Link header, empty on the last page
+func (g *GitHub) requestPage(endpoint string, out interface{}) (string, error) {
+	resp, err := g.send("GET", endpoint, nil, out)
+	if err != nil {
+		return "", err
+	}
+	return nextPageURL(resp.Header.Get("Link")), nil
+}
+
+// nextPageURL returns the rel="next" target of a Link header such as
+// `<https://api.github.com/...&page=2>; rel="next", <...&page=5>; rel="last"`
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(part, ";")
+		if !ok {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// send performs an API call like request and also returns the response, for callers that
+// read its headers
+func (g *GitHub) send(method, endpoint string, body interface{}, out interface{}) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		payload = data
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, data, err := g.do(method, endpoint, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if delay, limited := retryDelay(resp); limited && attempt < g.limiter.limits.MaxRetries {
+			if delay > g.limiter.limits.MaxWait {
+				return nil, fmt.Errorf("%s %s: rate limited for %s, longer than the %s maximum wait",
+					method, endpoint, delay.Round(time.Second), g.limiter.limits.MaxWait)
+			}
+			fmt.Printf("⏳ GitHub rate limit hit, retrying in %s...\n", delay.Round(time.Second))
+			g.limiter.pause(delay)
+			continue
+		}
+
+		return resp, decodeResponse(method, endpoint, resp, data, out)
+	}
+}
+
+// do sends a single request within the concurrency and rate limits
+func (g *GitHub) do(method, endpoint string, payload []byte) (*http.Response, []byte, error) {
+	g.limiter.acquire()
+	defer g.limiter.release()
+
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s %s failed: %w", method, endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return resp, data, nil
+}
+
+// decodeResponse turns an error status into an error and decodes a successful body into out
+func decodeResponse(method, endpoint string, resp *http.Response, data []byte, out interface{}) error {
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s: %s", method, endpoint, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
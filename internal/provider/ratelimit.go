@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limits controls how aggressively the provider API is called
+type Limits struct {
+	Concurrency       int     // Maximum in-flight requests
+	RequestsPerSecond float64 // Sustained request rate, 0 for unlimited
+	MaxRetries        int     // Retries after 403/429 rate limit responses
+	MaxWait           time.Duration
+}
+
+// DefaultLimits are safe for a personal token shared with other tools
+var DefaultLimits = Limits{
+	Concurrency:       4,
+	RequestsPerSecond: 10,
+	MaxRetries:        3,
+	MaxWait:           5 * time.Minute,
+}
+
+// limiter enforces Limits across goroutines
+type limiter struct {
+	limits   Limits
+	slots    chan struct{}
+	mu       sync.Mutex
+	next     time.Time // earliest time the next request may start
+	pauseEnd time.Time // set when the server asks every caller to back off
+}
+
+// newLimiter creates a limiter, filling unset fields from DefaultLimits
+func newLimiter(limits Limits) *limiter {
+	if limits.Concurrency <= 0 {
+		limits.Concurrency = DefaultLimits.Concurrency
+	}
+	if limits.MaxRetries < 0 {
+		limits.MaxRetries = 0
+	}
+	if limits.MaxWait <= 0 {
+		limits.MaxWait = DefaultLimits.MaxWait
+	}
+
+	return &limiter{
+		limits: limits,
+		slots:  make(chan struct{}, limits.Concurrency),
+	}
+}
+
+// acquire blocks until a concurrency slot is free and the rate limit allows a request
+func (l *limiter) acquire() {
+	l.slots <- struct{}{}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := now
+	if l.next.After(start) {
+		start = l.next
+	}
+	if l.pauseEnd.After(start) {
+		start = l.pauseEnd
+	}
+	if l.limits.RequestsPerSecond > 0 {
+		l.next = start.Add(time.Duration(float64(time.Second) / l.limits.RequestsPerSecond))
+	}
+	l.mu.Unlock()
+
+	if wait := start.Sub(now); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// release frees a concurrency slot
+func (l *limiter) release() {
+	<-l.slots
+}
+
+// pause makes every caller wait until d has elapsed
+func (l *limiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if end := time.Now().Add(d); end.After(l.pauseEnd) {
+		l.pauseEnd = end
+	}
+}
+
+// retryDelay reports how long to wait before retrying a rate-limited response, and whether to retry.
+// GitHub signals primary limits with 403/429 and X-RateLimit-Remaining: 0, and secondary limits with Retry-After.
+func retryDelay(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusForbidden {
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(retryAfter); err == nil {
+			return time.Until(at), true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return time.Until(time.Unix(reset, 0)) + time.Second, true
+		}
+	}
+
+	// A plain 403 is a permissions problem, not a rate limit
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return time.Minute, true
+	}
+	return 0, false
+}
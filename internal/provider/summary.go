@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SummaryMarker identifies the split summary comment so it can be updated in place
+const SummaryMarker = "<!-- pr-split-summary -->"
+
+// PartitionPR pairs a partition branch with its pull request, if one exists
+type PartitionPR struct {
+	Branch string
+	PR     *PullRequest
+}
+
+// issueComment is a pull request conversation comment
+type issueComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// Status describes the partition PR state for the summary table
+func (p PartitionPR) Status() string {
+	switch {
+	case p.PR == nil:
+		return "⏳ no PR yet"
+	case p.PR.MergedAt != nil:
+		return "✅ merged"
+	case p.PR.State == "closed":
+		return "❌ closed"
+	case p.PR.Draft:
+		return "📝 draft"
+	default:
+		return "🔍 open"
+	}
+}
+
+// RenderSplitSummary builds the markdown summary posted on the original PR
+func RenderSplitSummary(partitions []PartitionPR, runID string) string {
+	var numbers []string
+	merged := 0
+	for _, partition := range partitions {
+		if partition.PR != nil {
+			numbers = append(numbers, fmt.Sprintf("#%d", partition.PR.Number))
+			if partition.PR.MergedAt != nil {
+				merged++
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(SummaryMarker + "\n")
+
+	switch len(numbers) {
+	case 0:
+		fmt.Fprintf(&b, "### ✂️ Split into %d partitions\n\n", len(partitions))
+	case 1:
+		fmt.Fprintf(&b, "### ✂️ Split into %d PRs: %s\n\n", len(partitions), numbers[0])
+	default:
+		fmt.Fprintf(&b, "### ✂️ Split into %d PRs: %s…%s\n\n", len(partitions), numbers[0], numbers[len(numbers)-1])
+	}
+
+	b.WriteString("| # | Branch | PR | Status |\n")
+	b.WriteString("|---|--------|----|--------|\n")
+	for i, partition := range partitions {
+		pr := "—"
+		if partition.PR != nil {
+			pr = fmt.Sprintf("#%d", partition.PR.Number)
+		}
+		fmt.Fprintf(&b, "| %d | `%s` | %s | %s |\n", i+1, partition.Branch, pr, partition.Status())
+	}
+
+	fmt.Fprintf(&b, "\n**Progress:** %d/%d merged. Review and merge the partitions in order.\n", merged, len(partitions))
+	if runID != "" {
+		fmt.Fprintf(&b, "\n<sub>pr-split run `%s`</sub>\n", runID)
+	}
+	return b.String()
+}
+
+// UpsertSummaryComment creates the split summary comment on a PR or updates the existing one
+func (g *GitHub) UpsertSummaryComment(number int, body string) (bool, error) {
+	existing, err := g.findSummaryComment(number)
+	if err != nil {
+		return false, err
+	}
+
+	payload := map[string]string{"body": body}
+
+	if existing != 0 {
+		endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/comments/%d", g.apiURL, g.Owner, g.Repo, existing)
+		if err := g.request("PATCH", endpoint, payload, nil); err != nil {
+			return false, fmt.Errorf("failed to update summary comment: %w", err)
+		}
+		return false, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", g.apiURL, g.Owner, g.Repo, number)
+	if err := g.request("POST", endpoint, payload, nil); err != nil {
+		return false, fmt.Errorf("failed to post summary comment: %w", err)
+	}
+	return true, nil
+}
+
+// findSummaryComment returns the ID of the split summary comment on a PR, or 0 when there is
+// none. Busy PRs have more comments than fit on one page, so every page is searched.
+func (g *GitHub) findSummaryComment(number int) (int64, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments?per_page=100", g.apiURL, g.Owner, g.Repo, number)
+	for endpoint != "" {
+		var comments []issueComment
+		next, err := g.requestPage(endpoint, &comments)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list comments on #%d: %w", number, err)
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.Body, SummaryMarker) {
+				return comment.ID, nil
+			}
+		}
+		endpoint = next
+	}
+	return 0, nil
+}
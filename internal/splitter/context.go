@@ -0,0 +1,62 @@
+package splitter
+
+import (
+	"fmt"
+
+	"pr-splitter-cli/internal/projectcontext"
+	"pr-splitter-cli/internal/types"
+)
+
+// withProjectContext returns the changes preceded by the unchanged project files plugins read,
+// as of the source branch's commit. The files collected for a source commit are cached in the
+// state directory, so re-planning the same commit with other settings reuses them until the
+// cache TTL passes.
+func (s *Splitter) withProjectContext(changes []types.FileChange, sourceBranch string, cfg *types.Config) ([]types.FileChange, error) {
+	depth := cfg.ContextDepth
+	if depth == 0 {
+		depth = projectcontext.DefaultDepth
+	}
+
+	commit, err := s.gitClient.ResolveCommit(sourceBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", sourceBranch, err)
+	}
+
+	cache, key := s.contextCache(changes, commit, depth, cfg)
+	if cache != nil {
+		if files, ok := cache.Load(key); ok {
+			fmt.Fprintf(s.out, "📚 Reusing project context cached for %s (%d files)\n", shortSHA(key.SourceCommit), len(files))
+			return append(files, changes...), nil
+		}
+	}
+
+	fmt.Fprintln(s.out, "📚 Collecting project context for plugins that read it...")
+	analyzed, err := s.gitClient.WithProjectContext(changes, commit, depth)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		if err := cache.Save(key, analyzed[:len(analyzed)-len(changes)]); err != nil {
+			fmt.Fprintf(s.out, "⚠️  Warning: Not caching project context: %v\n", err)
+		}
+	}
+	return analyzed, nil
+}
+
+// contextCache returns the project context cache and the key of these changes of the source
+// commit, or a nil cache when caching is disabled
+func (s *Splitter) contextCache(changes []types.FileChange, commit string, depth int, cfg *types.Config) (*projectcontext.Cache, projectcontext.Key) {
+	ttl := cfg.ContextCacheTTL
+	if ttl == 0 {
+		ttl = projectcontext.DefaultCacheTTL
+	}
+	if ttl < 0 {
+		return nil, projectcontext.Key{}
+	}
+
+	gitDir, err := s.gitClient.GitCommonDir()
+	if err != nil {
+		return nil, projectcontext.Key{}
+	}
+	return projectcontext.NewCache(gitDir, ttl), projectcontext.NewKey(commit, depth, changes)
+}
@@ -0,0 +1,54 @@
+package splitter
+
+import (
+	"fmt"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/types"
+)
+
+// Decisions answers the questions a split asks before it changes any branch. The CLI prompts
+// on the terminal; embedding programs decide without user interaction.
+type Decisions interface {
+	// ApprovePlan decides whether branches are created for the plan
+	ApprovePlan(plan *types.PartitionPlan) (bool, error)
+	// ApproveOversizedCycle decides whether a circular group larger than the partition limit stays together
+	ApproveOversizedCycle(files []string, size, limit int, suggestions []string) (bool, error)
+	// ResolveTargetDrift picks how to apply files that also changed on the target since the merge-base
+	ResolveTargetDrift(drifted []string, targetBranch string) (config.DriftDecision, error)
+	// ResolveCollision picks what to do when planned branch names are taken on the remote
+	ResolveCollision(collisions []string, userSuffix string) (config.CollisionDecision, error)
+}
+
+// PromptDecisions asks the user on the terminal
+type PromptDecisions struct{}
+
+// ApprovePlan asks whether to proceed, defaulting to yes
+func (PromptDecisions) ApprovePlan(plan *types.PartitionPlan) (bool, error) {
+	fmt.Print("Proceed with this partition plan? [Y/n]: ")
+
+	var input string
+	fmt.Scanln(&input)
+
+	switch input {
+	case "n", "no", "N", "No":
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// ApproveOversizedCycle shows the circular group and asks whether to keep it together
+func (PromptDecisions) ApproveOversizedCycle(files []string, size, limit int, suggestions []string) (bool, error) {
+	return config.PromptForSCCDecision(files, size, limit, suggestions)
+}
+
+// ResolveTargetDrift shows the drifted files and asks how to apply them
+func (PromptDecisions) ResolveTargetDrift(drifted []string, targetBranch string) (config.DriftDecision, error) {
+	return config.PromptForDriftDecision(drifted, targetBranch)
+}
+
+// ResolveCollision shows the taken branches and asks whether to add a suffix
+func (PromptDecisions) ResolveCollision(collisions []string, userSuffix string) (config.CollisionDecision, error) {
+	return config.PromptForCollisionDecision(collisions, userSuffix)
+}
@@ -0,0 +1,189 @@
+package splitter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/state"
+	"pr-splitter-cli/internal/types"
+	"pr-splitter-cli/internal/validation"
+)
+
+// Resume continues a failed or interrupted run from its recorded state. Branches the run
+// already pushed are verified and kept; the remaining partitions are created as planned.
+func (s *Splitter) Resume(ctx context.Context, store *state.Store, run *state.Run) (*types.SplitResult, error) {
+	s.bind(ctx)
+	s.runID = run.ID
+	s.run = nil
+	s.gitClient.SetRunID(s.runID)
+	fmt.Fprintf(s.out, "🆔 Resuming run %s (%d of %d partitions pushed)\n", run.ID, len(run.Branches), len(run.Plan.Partitions))
+
+	result, err := s.resume(store, run)
+	s.finishRun(err)
+	if err != nil {
+		return nil, fmt.Errorf("run %s: %w", s.runID, err)
+	}
+	return result, nil
+}
+
+func (s *Splitter) resume(store *state.Store, run *state.Run) (*types.SplitResult, error) {
+	plan, cfg := run.Plan, &run.Config
+	s.gitClient.SetRemote(cfg.PushRemote)
+	s.gitClient.SetNoVerify(cfg.NoVerify)
+	s.validator.SetRemote(s.gitClient.Remote())
+	s.validator.SetLocalOnly(cfg.NoPush)
+	s.validator.SetSeverity(cfg.StrictValidation, cfg.ValidationSeverity)
+
+	source, err := s.gitClient.ResolveCommit(run.SourceBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", run.SourceBranch, err)
+	}
+	if source != run.SourceCommit {
+		return nil, fmt.Errorf("%s moved from %s to %s since the run started; undo the run and split again",
+			run.SourceBranch, shortSHA(run.SourceCommit), shortSHA(source))
+	}
+
+	if err := s.leavePartitionBranch(run); err != nil {
+		return nil, err
+	}
+
+	done, err := s.verifyPushedBranches(run)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.clearUnrecordedBranches(run); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.analyzeChanges(run.SourceBranch, run.TargetBranch, plan.Metadata.MergeBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze changes: %w", err)
+	}
+
+	run.Status = state.RunStatusRunning
+	run.Error = ""
+	run.FinishedAt = nil
+	run.Validation = nil
+	run.UpdatedAt = time.Now().UTC()
+	s.trackRun(store, run)
+
+	s.startReport(plan, cfg, run.SourceBranch)
+	fmt.Fprintln(s.out, "✅ Validating partition plan...")
+	preValidation, err := s.validator.ValidatePlan(plan, changes)
+	if err != nil {
+		return nil, fmt.Errorf("pre-validation failed: %w", err)
+	}
+	s.recordValidation("Pre-execution", preValidation)
+	if !s.validator.AllPassed(preValidation) {
+		s.displayValidationResults(preValidation)
+		return nil, &validation.ValidationFailedError{Phase: "partition plan", Results: preValidation}
+	}
+
+	s.gitClient.SkipBranches(done)
+	defer s.gitClient.SkipBranches(nil)
+	return s.createAndValidate(plan, changes, cfg, run.SourceBranch, preValidation)
+}
+
+// leavePartitionBranch moves off a partition branch that an interrupted run of an older
+// version, which built branches in the checkout itself, left checked out
+func (s *Splitter) leavePartitionBranch(run *state.Run) error {
+	current, err := s.gitClient.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+	for _, partition := range run.Plan.Partitions {
+		if partition.BranchName == current {
+			dirty, err := s.gitClient.HasLocalChanges()
+			if err != nil {
+				return err
+			}
+			if dirty {
+				return fmt.Errorf("%w (possibly from the interrupted run); stash or reset them before resuming", git.ErrDirtyWorktree)
+			}
+			fmt.Fprintf(s.out, "🔀 Leaving partition branch %s for %s\n", current, run.SourceBranch)
+			return s.gitClient.CheckoutBranch(run.SourceBranch)
+		}
+	}
+	return nil
+}
+
+// verifyPushedBranches checks that every branch the run pushed is still, locally and on
+// the push remote, the commit it pushed. It returns the verified branch names.
+func (s *Splitter) verifyPushedBranches(run *state.Run) ([]string, error) {
+	if len(run.Branches) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, len(run.Branches))
+	for i, record := range run.Branches {
+		names[i] = record.Name
+	}
+	owners, err := s.gitClient.FindRemoteOwners(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify pushed branches: %w", err)
+	}
+	remote := make(map[string]string)
+	for _, owner := range owners {
+		remote[owner.Branch] = owner.Commit
+	}
+
+	fmt.Fprintf(s.out, "🔎 Verifying %d pushed branches...\n", len(run.Branches))
+	for _, record := range run.Branches {
+		local, err := s.gitClient.ResolveCommit(record.Name)
+		if err != nil {
+			return nil, fmt.Errorf("branch %s no longer exists locally; undo the run and split again", record.Name)
+		}
+		if local != record.Commit {
+			return nil, fmt.Errorf("branch %s moved from %s to %s since it was pushed; undo the run and split again",
+				record.Name, shortSHA(record.Commit), shortSHA(local))
+		}
+		if remote[record.Name] != record.Commit {
+			return nil, fmt.Errorf("%s/%s is no longer %s; undo the run and split again", s.gitClient.Remote(), record.Name, shortSHA(record.Commit))
+		}
+		fmt.Fprintf(s.out, "   ✅ %s at %s\n", record.Name, shortSHA(record.Commit))
+	}
+	return names, nil
+}
+
+// clearUnrecordedBranches removes what an interrupted run left of the partition it was
+// working on. Updates reset those branches anyway, so only new splits need this.
+func (s *Splitter) clearUnrecordedBranches(run *state.Run) error {
+	if run.Config.UpdateExisting {
+		return nil
+	}
+
+	var pending []string
+	for _, partition := range run.Plan.Partitions {
+		if run.Branch(partition.BranchName) == nil {
+			pending = append(pending, partition.BranchName)
+		}
+	}
+
+	owners, err := s.gitClient.FindRemoteOwners(pending)
+	if err != nil {
+		return fmt.Errorf("failed to check remote branches: %w", err)
+	}
+	for _, owner := range owners {
+		if owner.RunID != run.ID {
+			return fmt.Errorf("branch %s exists on %s and was not pushed by this run", describeOwner(owner), s.gitClient.Remote())
+		}
+		fmt.Fprintf(s.out, "🗑️  Deleting unrecorded remote branch from this run: %s\n", owner.Branch)
+		if err := s.gitClient.DeleteRemoteBranch(owner.Branch); err != nil {
+			return fmt.Errorf("failed to delete remote branch %s: %w", owner.Branch, err)
+		}
+	}
+
+	// Branch names were free when the run started, so a local branch was left by the run
+	for _, branch := range pending {
+		if _, err := s.gitClient.ResolveCommit(branch); err != nil {
+			continue
+		}
+		fmt.Fprintf(s.out, "🗑️  Deleting unfinished local branch: %s\n", branch)
+		if err := s.gitClient.DeleteLocalBranch(branch); err != nil {
+			return fmt.Errorf("failed to delete local branch %s: %w", branch, err)
+		}
+	}
+	return nil
+}
@@ -1,53 +1,134 @@
 package splitter
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
 
+	"pr-splitter-cli/internal/analyzer/cochange"
+	"pr-splitter-cli/internal/analyzer/projectgraph"
 	"pr-splitter-cli/internal/config"
 	"pr-splitter-cli/internal/git"
 	"pr-splitter-cli/internal/partition"
 	"pr-splitter-cli/internal/plugin"
+	"pr-splitter-cli/internal/state"
 	"pr-splitter-cli/internal/types"
 	"pr-splitter-cli/internal/validation"
+	"pr-splitter-cli/internal/workspace"
 )
 
 // Splitter orchestrates the entire PR splitting process
 type Splitter struct {
+	ctx           context.Context // Cancels git, plugin and validation subprocesses of the current run
+	runID         string
 	gitClient     *git.Client
 	pluginManager *plugin.Manager
 	partitioner   *partition.Partitioner
 	validator     *validation.Validator
+	out           io.Writer
+	decisions     Decisions
+	store         *state.Store // Run records under the git directory, set once a plan is approved
+	run           *state.Run
+	report        *validation.Report // Validation results written to the report file, nil without one
+	reportOutput  string
+}
+
+// Options configures a Splitter created with NewWithOptions
+type Options struct {
+	Dir       string    // Checkout to split, the process working directory when empty
+	Output    io.Writer // Progress output, stdout when nil
+	Decisions Decisions // Answers approval and conflict questions, terminal prompts when nil
+
+	// ForcePushCheck is called before a branch of an earlier split is force-pushed; an error
+	// cancels the push. Nil pushes without checking.
+	ForcePushCheck git.ForcePushCheck
 }
 
 // New creates a new Splitter instance
 func New() *Splitter {
-	return &Splitter{
+	return NewWithOptions(Options{})
+}
+
+// NewInDir creates a Splitter whose git operations run in the checkout at dir
+func NewInDir(dir string) *Splitter {
+	return NewWithOptions(Options{Dir: dir})
+}
+
+// NewWithOptions creates a Splitter for the checkout, output and decision maker in opts
+func NewWithOptions(opts Options) *Splitter {
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+	if opts.Decisions == nil {
+		opts.Decisions = PromptDecisions{}
+	}
+
+	s := &Splitter{
+		ctx:           context.Background(),
 		gitClient:     git.NewClient(),
-		pluginManager: plugin.NewManager(),
+		pluginManager: plugin.NewManagerWithOutput(opts.Output),
 		partitioner:   partition.NewPartitioner(),
 		validator:     validation.NewValidator(),
+		out:           opts.Output,
+		decisions:     opts.Decisions,
+	}
+	if opts.Dir != "" {
+		s.gitClient = git.NewClientInDir(opts.Dir)
+		s.pluginManager.SetWorkingDir(opts.Dir)
+		s.validator.SetWorkingDir(opts.Dir)
 	}
+	s.gitClient.SetOutput(opts.Output)
+	s.gitClient.SetForcePushCheck(opts.ForcePushCheck)
+	s.partitioner.SetOutput(opts.Output)
+	s.partitioner.SetCycleApproval(opts.Decisions.ApproveOversizedCycle)
+	s.validator.SetOutput(opts.Output)
+	return s
+}
+
+// bind makes every subprocess started for this call stop when ctx is done
+func (s *Splitter) bind(ctx context.Context) {
+	s.ctx = ctx
+	s.gitClient = s.gitClient.WithContext(ctx)
 }
 
 // Split performs the complete PR splitting process with smart configuration
-func (s *Splitter) Split(sourceBranch string) (*types.SplitResult, error) {
+func (s *Splitter) Split(ctx context.Context, sourceBranch string) (*types.SplitResult, error) {
+	s.bind(ctx)
+
 	// Get configuration with smart recommendations
-	fmt.Println("🔍 Analyzing repository for configuration recommendations...")
+	fmt.Fprintln(s.out, "🔍 Analyzing repository for configuration recommendations...")
 	cfg, err := s.getSmartConfiguration(sourceBranch, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get configuration: %w", err)
 	}
 
-	return s.SplitWithConfig(sourceBranch, cfg)
+	return s.SplitWithConfig(ctx, sourceBranch, cfg)
 }
 
 // SplitWithConfig performs the splitting process with provided configuration
-func (s *Splitter) SplitWithConfig(sourceBranch string, cfg *types.Config) (*types.SplitResult, error) {
-	return s.executeWorkflow(sourceBranch, cfg)
+func (s *Splitter) SplitWithConfig(ctx context.Context, sourceBranch string, cfg *types.Config) (*types.SplitResult, error) {
+	s.bind(ctx)
+
+	// One id correlates console output, commit trailers, PR comments and child processes of this run
+	s.runID = git.NewRunID()
+	s.run = nil
+	s.gitClient.SetRunID(s.runID)
+	fmt.Fprintf(s.out, "🆔 Run ID: %s\n", s.runID)
+
+	result, err := s.executeWorkflow(sourceBranch, cfg)
+	s.finishRun(err)
+	if err != nil {
+		return nil, fmt.Errorf("run %s: %w", s.runID, err)
+	}
+	return result, nil
 }
 
 // GetSmartConfiguration exposes smart configuration for CLI usage
-func (s *Splitter) GetSmartConfiguration(sourceBranch, preferredTarget string) (*types.Config, error) {
+func (s *Splitter) GetSmartConfiguration(ctx context.Context, sourceBranch, preferredTarget string) (*types.Config, error) {
+	s.bind(ctx)
 	return s.getSmartConfiguration(sourceBranch, preferredTarget)
 }
 
@@ -62,7 +143,7 @@ func (s *Splitter) getSmartConfiguration(sourceBranch, preferredTarget string) (
 	// Try quick analysis for recommendations using the correct target branch
 	quickChanges, err := s.gitClient.GetChanges(sourceBranch, targetBranch)
 	if err != nil {
-		fmt.Println("⚠️  Quick analysis failed, using basic configuration...")
+		fmt.Fprintln(s.out, "⚠️  Quick analysis failed, using basic configuration...")
 		return config.GetFromUser()
 	}
 
@@ -72,22 +153,25 @@ func (s *Splitter) getSmartConfiguration(sourceBranch, preferredTarget string) (
 
 // executeWorkflow runs the main splitting workflow
 func (s *Splitter) executeWorkflow(sourceBranch string, cfg *types.Config) (*types.SplitResult, error) {
-	// Step 1: Analyze changes
-	changes, err := s.analyzeChanges(sourceBranch, cfg.TargetBranch)
+	plan, changes, err := s.buildPlan(sourceBranch, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to analyze changes: %w", err)
+		return nil, err
 	}
+	plan.Metadata.RunID = s.runID
 
-	// Step 2: Analyze dependencies
-	dependencies, err := s.analyzeDependencies(changes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to analyze dependencies: %w", err)
+	if cfg.PlanOutput != "" {
+		if err := partition.SavePlan(plan, cfg.PlanOutput); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(s.out, "💾 Saved partition plan to %s\n", cfg.PlanOutput)
 	}
 
-	// Step 3: Create partition plan
-	plan, err := s.createPartitionPlan(changes, dependencies, cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create partition plan: %w", err)
+	if cfg.MermaidOutput != "" {
+		diagram := partition.MermaidBlock(partition.RenderMermaid(plan, false))
+		if err := os.WriteFile(cfg.MermaidOutput, []byte(diagram), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write Mermaid diagram: %w", err)
+		}
+		fmt.Fprintf(s.out, "🧜 Saved partition diagram to %s\n", cfg.MermaidOutput)
 	}
 
 	// Step 4: Get user approval
@@ -95,52 +179,371 @@ func (s *Splitter) executeWorkflow(sourceBranch string, cfg *types.Config) (*typ
 		return nil, err
 	}
 
+	s.startRun(plan, cfg, sourceBranch)
+
 	// Step 5: Validate and execute
 	return s.validateAndExecute(plan, changes, cfg, sourceBranch)
 }
 
+// Plan analyzes sourceBranch and returns the partition plan a split would create, without
+// asking for approval or touching any branch
+func (s *Splitter) Plan(ctx context.Context, sourceBranch string, cfg *types.Config) (*types.PartitionPlan, error) {
+	s.bind(ctx)
+	plan, _, err := s.buildPlan(sourceBranch, cfg)
+	return plan, err
+}
+
+// buildPlan pins the base, analyzes changes and dependencies and partitions them
+func (s *Splitter) buildPlan(sourceBranch string, cfg *types.Config) (*types.PartitionPlan, []types.FileChange, error) {
+	if err := s.useRemotes(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	// Step 0: Pin the base so the split is reproducible if target advances mid-run
+	mergeBase, baseCommit, err := s.pinBase(sourceBranch, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pin base commit: %w", err)
+	}
+
+	// Step 1: Analyze changes
+	changes, err := s.analyzeChanges(sourceBranch, cfg.TargetRef(), mergeBase)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to analyze changes: %w", err)
+	}
+	if cfg.Since != "" || cfg.CommitPattern != "" {
+		changes, err = s.selectCommits(changes, sourceBranch, mergeBase, cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to select commits: %w", err)
+		}
+	}
+	if cfg.ExcludeTests {
+		changes = s.withoutTests(changes)
+	}
+
+	// Step 2: Analyze dependencies
+	dependencies, err := s.analyzeDependencies(changes, sourceBranch, cfg, mergeBase)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to analyze dependencies: %w", err)
+	}
+
+	// Step 3: Create partition plan
+	plan, err := s.createPartitionPlan(changes, dependencies, cfg, sourceBranch, mergeBase)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create partition plan: %w", err)
+	}
+	plan.Metadata.MergeBase = mergeBase
+	plan.Metadata.BaseCommit = baseCommit
+
+	return plan, changes, nil
+}
+
+// useRemotes points branch pushes and checks at cfg.PushRemote and, in a fork workflow,
+// fetches the target branch from cfg.UpstreamRemote so the split is based on it
+func (s *Splitter) useRemotes(cfg *types.Config) error {
+	s.gitClient.SetRemote(cfg.PushRemote)
+	s.validator.SetRemote(s.gitClient.Remote())
+	s.validator.SetLocalOnly(cfg.NoPush)
+	s.validator.SetSeverity(cfg.StrictValidation, cfg.ValidationSeverity)
+	if cfg.UpstreamRemote == "" {
+		return nil
+	}
+
+	fmt.Fprintf(s.out, "📥 Fetching %s from %s...\n", cfg.TargetBranch, cfg.UpstreamRemote)
+	if err := s.gitClient.FetchRemoteBranch(cfg.UpstreamRemote, cfg.TargetBranch); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "🍴 Pushing partitions to %s, based on %s\n", s.gitClient.Remote(), cfg.TargetRef())
+	return nil
+}
+
+// pinBase resolves the merge-base and the commit root partitions are created from
+func (s *Splitter) pinBase(sourceBranch string, cfg *types.Config) (string, string, error) {
+	target := cfg.TargetRef()
+	mergeBase, err := s.gitClient.GetMergeBase(target, sourceBranch)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find merge-base of %s and %s: %w", target, sourceBranch, err)
+	}
+
+	baseCommit := mergeBase
+	if cfg.RebasePlan {
+		baseCommit, err = s.gitClient.ResolveCommit(target)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to resolve %s: %w", target, err)
+		}
+		fmt.Fprintf(s.out, "📌 Basing partitions on current %s tip %s\n", target, shortSHA(baseCommit))
+	} else {
+		fmt.Fprintf(s.out, "📌 Pinned merge-base: %s\n", shortSHA(mergeBase))
+	}
+
+	return mergeBase, baseCommit, nil
+}
+
 // analyzeChanges gets git changes with validation
-func (s *Splitter) analyzeChanges(sourceBranch, targetBranch string) ([]types.FileChange, error) {
-	fmt.Printf("🔍 Analyzing git changes from %s to %s...\n", sourceBranch, targetBranch)
+func (s *Splitter) analyzeChanges(sourceBranch, targetBranch, mergeBase string) ([]types.FileChange, error) {
+	fmt.Fprintf(s.out, "🔍 Analyzing git changes from %s to %s...\n", sourceBranch, targetBranch)
 
-	changes, err := s.gitClient.GetChanges(sourceBranch, targetBranch)
+	changes, err := s.gitClient.GetChangesFromBase(sourceBranch, targetBranch, mergeBase)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(changes) == 0 {
-		return nil, fmt.Errorf("no changes found between %s and %s", sourceBranch, targetBranch)
+		return nil, fmt.Errorf("%w: no files differ between %s and %s", git.ErrNoChanges, sourceBranch, targetBranch)
+	}
+
+	fmt.Fprintf(s.out, "📊 Found %d changed files\n", s.countChangedFiles(changes))
+	if dirty, err := s.gitClient.HasLocalChanges(); err == nil && dirty {
+		fmt.Fprintln(s.out, "ℹ️  Uncommitted changes in your checkout are left alone and are not part of the split")
+	}
+
+	// Classification only drives warnings and optional separation, so a failed diff is not fatal
+	if diffs, err := s.gitClient.GetLineDiffs(mergeBase, sourceBranch); err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Could not classify mechanical changes: %v\n", err)
+	} else {
+		partition.ClassifyChanges(changes, diffs)
+		s.partitioner.SetLineDiffs(diffs)
 	}
 
-	fmt.Printf("📊 Found %d changed files\n", s.countChangedFiles(changes))
 	return changes, nil
 }
 
+// selectCommits keeps the changed files touched by the source commits picked with --since and
+// --grep, so one ticket of a long-lived branch can be split on its own. Partitions take each file
+// as it is on the source branch, so a file also touched by commits left out carries their changes
+// too; those files are listed.
+func (s *Splitter) selectCommits(changes []types.FileChange, sourceBranch, mergeBase string, cfg *types.Config) ([]types.FileChange, error) {
+	commits, err := s.gitClient.GetBranchCommits(mergeBase, sourceBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	var criteria []string
+	var inRange map[string]bool
+	if cfg.Since != "" {
+		criteria = append(criteria, "after "+cfg.Since)
+		recent, err := s.gitClient.GetBranchCommits(cfg.Since, sourceBranch)
+		if err != nil {
+			return nil, err
+		}
+		inRange = make(map[string]bool)
+		for _, commit := range recent {
+			inRange[commit.SHA] = true
+		}
+	}
+	var pattern *regexp.Regexp
+	if cfg.CommitPattern != "" {
+		criteria = append(criteria, fmt.Sprintf("matching %q", cfg.CommitPattern))
+		if pattern, err = regexp.Compile(cfg.CommitPattern); err != nil {
+			return nil, fmt.Errorf("invalid commit pattern: %w", err)
+		}
+	}
+	criterion := strings.Join(criteria, " and ")
+
+	selected := make(map[string]bool)
+	others := make(map[string]bool)
+	picked := 0
+	for _, commit := range commits {
+		files := others
+		if (inRange == nil || inRange[commit.SHA]) && (pattern == nil || pattern.MatchString(commit.Message)) {
+			files = selected
+			picked++
+		}
+		for _, file := range commit.Files {
+			files[file] = true
+		}
+	}
+	if picked == 0 {
+		return nil, fmt.Errorf("%w: no commits of %s %s", git.ErrNoChanges, sourceBranch, criterion)
+	}
+
+	var kept []types.FileChange
+	var mixed []string
+	dropped := 0
+	for _, change := range changes {
+		if !change.IsChanged {
+			kept = append(kept, change)
+			continue
+		}
+		if !selected[change.Path] && !selected[change.OldPath] {
+			dropped++
+			continue
+		}
+		if others[change.Path] || (change.OldPath != "" && others[change.OldPath]) {
+			mixed = append(mixed, change.Path)
+		}
+		kept = append(kept, change)
+	}
+	if dropped == s.countChangedFiles(changes) {
+		return nil, fmt.Errorf("%w: the %d commits of %s %s change no files that differ from %s", git.ErrNoChanges, picked, sourceBranch, criterion, cfg.TargetBranch)
+	}
+
+	fmt.Fprintf(s.out, "🎫 Splitting the files changed by %d of %d commits %s, leaving out %d other files\n", picked, len(commits), criterion, dropped)
+	if len(mixed) > 0 {
+		fmt.Fprintf(s.out, "⚠️  Warning: %d files also carry changes from commits left out: %s\n", len(mixed), strings.Join(mixed, ", "))
+	}
+	return kept, nil
+}
+
+// withoutTests drops the changed test files from a split that leaves tests out
+func (s *Splitter) withoutTests(changes []types.FileChange) []types.FileChange {
+	var kept []types.FileChange
+	dropped := 0
+	for _, change := range changes {
+		if change.IsChanged && partition.IsTestFile(change.Path) {
+			dropped++
+			continue
+		}
+		kept = append(kept, change)
+	}
+	if dropped > 0 {
+		fmt.Fprintf(s.out, "🧪 Leaving %d changed test files out of the split\n", dropped)
+	}
+	return kept
+}
+
 // analyzeDependencies runs plugin analysis on files
-func (s *Splitter) analyzeDependencies(changes []types.FileChange) ([]types.Dependency, error) {
-	fmt.Println("🧠 Analyzing dependencies with plugins...")
+func (s *Splitter) analyzeDependencies(changes []types.FileChange, sourceBranch string, cfg *types.Config, mergeBase string) ([]types.Dependency, error) {
+	fmt.Fprintln(s.out, "🧠 Analyzing dependencies with plugins...")
 
-	dependencies, err := s.pluginManager.AnalyzeDependencies(changes)
+	s.pluginManager.SetIncludePaths(cfg.IncludePaths)
+	s.pluginManager.SetGeneratedCodeRules(cfg.GeneratedCode)
+	s.pluginManager.SetPairingRules(cfg.PairingRules)
+	s.pluginManager.SetPluginPriority(cfg.PluginPriority)
+
+	// The project is only scanned when a plugin reads unchanged files
+	analyzed := changes
+	if s.pluginManager.WantsProjectContext(changes) {
+		var err error
+		if analyzed, err = s.withProjectContext(changes, sourceBranch, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	dependencies, err := s.pluginManager.AnalyzeDependencies(s.ctx, analyzed)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("🔗 Found %d dependencies\n", len(dependencies))
+	if !cfg.SkipProjectGraph {
+		dependencies = s.applyProjectGraph(changes, dependencies, cfg)
+	}
+
+	if cfg.CoChange {
+		dependencies = plugin.MergeDependencies(append(dependencies, s.analyzeCoChange(changes, cfg, mergeBase)...), cfg.PluginPriority)
+	}
+
+	fmt.Fprintf(s.out, "🔗 Found %d dependencies\n", len(dependencies))
 	return dependencies, nil
 }
 
+// applyProjectGraph makes the Nx or Turborepo project graph of the checkout authoritative for
+// dependencies between projects: files follow the projects they depend on, and edges between
+// projects the graph calls unrelated are dropped. Repositories without either tool are left alone.
+func (s *Splitter) applyProjectGraph(changes []types.FileChange, dependencies []types.Dependency, cfg *types.Config) []types.Dependency {
+	root, err := s.gitClient.RepoRoot()
+	if err != nil {
+		return dependencies
+	}
+	tool := projectgraph.Detect(root)
+	if tool == "" {
+		return dependencies
+	}
+
+	fmt.Fprintf(s.out, "🕸️  Exporting the %s project graph...\n", tool)
+	projects, err := projectgraph.Export(s.ctx, root, tool)
+	if err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Skipping the %s project graph: %v\n", tool, err)
+		return dependencies
+	}
+
+	graph := projectgraph.NewGraph(projects)
+	kept, dropped := graph.Filter(dependencies)
+	if dropped > 0 {
+		fmt.Fprintf(s.out, "✂️  Dropped %d dependencies between projects %s says are unrelated\n", dropped, tool)
+	}
+	projectDependencies := graph.Dependencies(changes)
+	fmt.Fprintf(s.out, "✅ %s found %d projects and %d dependencies between changed files\n", projectgraph.Name, len(projects), len(projectDependencies))
+
+	return plugin.MergeDependencies(append(kept, projectDependencies...), cfg.PluginPriority)
+}
+
+// analyzeCoChange mines history before the branch for files that usually change together
+func (s *Splitter) analyzeCoChange(changes []types.FileChange, cfg *types.Config, mergeBase string) []types.Dependency {
+	maxCommits := cfg.CoChangeCommits
+	if maxCommits <= 0 {
+		maxCommits = cochange.DefaultMaxCommits
+	}
+
+	fmt.Fprintf(s.out, "🕰️  Mining the last %d commits for co-changed files...\n", maxCommits)
+	commits, err := s.gitClient.GetCommitFileSets(mergeBase, maxCommits)
+	if err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Skipping co-change analysis: %v\n", err)
+		return nil
+	}
+
+	dependencies := cochange.Analyze(changes, commits)
+	fmt.Fprintf(s.out, "✅ %s found %d file pairs\n", cochange.Name, len(dependencies)/2)
+	return dependencies
+}
+
+// resolveOwners finds the predominant recent author or team of each changed file before the branch
+func (s *Splitter) resolveOwners(changes []types.FileChange, cfg *types.Config, mergeBase string) map[string]string {
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+		if change.OldPath != "" {
+			paths = append(paths, change.OldPath)
+		}
+	}
+
+	fmt.Fprintf(s.out, "👥 Finding recent owners of %d files...\n", len(paths))
+	authors, err := s.gitClient.GetFileAuthors(mergeBase, paths, partition.OwnershipHistoryDepth)
+	if err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Falling back to dependency-first grouping: %v\n", err)
+		return nil
+	}
+
+	return partition.ResolveOwners(changes, authors, cfg.Teams)
+}
+
+// detectWorkspaces finds the monorepo packages of the source branch for the workspace strategy
+func (s *Splitter) detectWorkspaces(sourceBranch string) []workspace.Package {
+	files, err := s.gitClient.ListFiles(sourceBranch)
+	if err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Could not list files of %s: %v\n", sourceBranch, err)
+		return nil
+	}
+	packages := workspace.Detect(files, func(filePath string) (string, error) {
+		return s.gitClient.ReadFileAt(sourceBranch, filePath)
+	})
+	if len(packages) == 0 {
+		fmt.Fprintln(s.out, "⚠️  Warning: No npm, yarn, pnpm or Go workspace found; using dependency-first partitioning")
+		return nil
+	}
+	fmt.Fprintf(s.out, "🗂️  Found %d workspace packages\n", len(packages))
+	return packages
+}
+
 // createPartitionPlan creates the partitioning plan
-func (s *Splitter) createPartitionPlan(changes []types.FileChange, dependencies []types.Dependency, cfg *types.Config) (*types.PartitionPlan, error) {
-	fmt.Println("📦 Creating partition plan...")
+func (s *Splitter) createPartitionPlan(changes []types.FileChange, dependencies []types.Dependency, cfg *types.Config, sourceBranch, mergeBase string) (*types.PartitionPlan, error) {
+	fmt.Fprintln(s.out, "📦 Creating partition plan...")
+
+	if cfg.Strategy == types.StrategyOwnership {
+		s.partitioner.SetFileOwners(s.resolveOwners(changes, cfg, mergeBase))
+	}
+	if cfg.Strategy == types.StrategyWorkspace {
+		s.partitioner.SetWorkspacePackages(s.detectWorkspaces(sourceBranch))
+	}
 
 	plan, err := s.partitioner.CreatePlan(changes, dependencies, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("📋 Created %d partitions\n", len(plan.Partitions))
+	fmt.Fprintf(s.out, "📋 Created %d partitions\n", len(plan.Partitions))
 	s.displayPartitionSummary(plan)
 	s.displayExhaustivenessSummary(changes, plan)
+	s.displayMixedChangeWarnings(plan, cfg)
 
 	return plan, nil
 }
@@ -149,13 +552,13 @@ func (s *Splitter) createPartitionPlan(changes []types.FileChange, dependencies
 func (s *Splitter) getApprovalForPlan(plan *types.PartitionPlan) error {
 	s.displayDetailedPlan(plan)
 
-	approved, err := s.promptForApproval()
+	approved, err := s.decisions.ApprovePlan(plan)
 	if err != nil {
 		return fmt.Errorf("failed to get user approval: %w", err)
 	}
 
 	if !approved {
-		return fmt.Errorf("user cancelled the operation")
+		return fmt.Errorf("%w: plan not approved", partition.ErrUserAborted)
 	}
 
 	return nil
@@ -163,39 +566,90 @@ func (s *Splitter) getApprovalForPlan(plan *types.PartitionPlan) error {
 
 // validateAndExecute validates the plan and creates branches
 func (s *Splitter) validateAndExecute(plan *types.PartitionPlan, changes []types.FileChange, cfg *types.Config, sourceBranch string) (*types.SplitResult, error) {
+	s.startReport(plan, cfg, sourceBranch)
+
 	// Pre-validation
-	fmt.Println("✅ Validating partition plan...")
+	fmt.Fprintln(s.out, "✅ Validating partition plan...")
 	preValidation, err := s.validator.ValidatePlan(plan, changes)
 	if err != nil {
 		return nil, fmt.Errorf("pre-validation failed: %w", err)
 	}
 
+	s.recordValidation("Pre-execution", preValidation)
 	if !s.validator.AllPassed(preValidation) {
 		s.displayValidationResults(preValidation)
-		return nil, fmt.Errorf("partition plan validation failed")
+		return nil, &validation.ValidationFailedError{Phase: "partition plan", Results: preValidation}
+	}
+
+	// Guard against overwriting target-side changes
+	if err := s.checkTargetDrift(plan, cfg, sourceBranch); err != nil {
+		return nil, err
 	}
 
-	// Create branches
-	fmt.Println("🌿 Creating branches...")
+	// Local-only splits never reach the remote, so its branches cannot collide
+	if cfg.NoPush {
+		return s.createAndValidate(plan, changes, cfg, sourceBranch, preValidation)
+	}
+
+	// Updates compare against and lease on the current remote branches
+	if cfg.UpdateExisting {
+		if err := s.gitClient.FetchRemote(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Guard against pushing over someone else's split
+	if err := s.checkRemoteCollisions(plan, cfg); err != nil {
+		return nil, err
+	}
+
+	return s.createAndValidate(plan, changes, cfg, sourceBranch, preValidation)
+}
+
+// createAndValidate creates the partition branches and validates them against the source
+func (s *Splitter) createAndValidate(plan *types.PartitionPlan, changes []types.FileChange, cfg *types.Config, sourceBranch string, preValidation []types.ValidationResult) (*types.SplitResult, error) {
+	fmt.Fprintln(s.out, "🌿 Creating branches...")
 	branches, err := s.gitClient.CreateBranches(plan, cfg, sourceBranch)
 	if err != nil {
+		// CreateBranches rolled back everything it pushed, unless told to keep progress
+		if s.run != nil && !cfg.KeepProgress {
+			s.run.Branches = nil
+		}
+		if cfg.KeepProgress {
+			return nil, fmt.Errorf("failed to create branches (continue with 'pr-split resume'): %w", err)
+		}
 		return nil, fmt.Errorf("failed to create branches: %w", err)
 	}
 
+	if cfg.UpdateExisting {
+		s.reportStaleBranches(plan, cfg)
+	}
+
 	// Post-validation
-	fmt.Println("🔍 Post-creation validation...")
-	postValidation, err := s.validator.ValidateBranches(branches, changes, sourceBranch, cfg.TargetBranch)
+	fmt.Fprintln(s.out, "🔍 Post-creation validation...")
+	var creationResults []types.ValidationResult
+	if len(cfg.PartitionChecks) > 0 {
+		creationResults = append(creationResults, partitionCheckResult(plan, s.gitClient.PartitionCheckFailures()))
+	}
+	if plan.Metadata.Topology == types.TopologyIndependent || plan.Metadata.Topology == types.TopologyDAG {
+		conflicts, err := s.gitClient.PredictedConflicts()
+		creationResults = append(creationResults, conflictPredictionResult(conflicts, err))
+	}
+	s.validator.SetCreationResults(creationResults)
+	postValidation, err := s.validator.ValidateBranches(s.ctx, branches, changes, sourceBranch, plan.Metadata.BaseCommit, plan.Metadata.Topology)
 	if err != nil {
 		return nil, fmt.Errorf("post-validation failed: %w", err)
 	}
 
+	s.recordValidation("Post-creation", postValidation)
 	if !s.validator.AllPassed(postValidation) {
 		s.displayValidationResults(postValidation)
-		return nil, fmt.Errorf("branch validation failed")
+		return nil, &validation.ValidationFailedError{Phase: "branch", Results: postValidation}
 	}
 
 	// Build result
 	result := &types.SplitResult{
+		RunID:             plan.Metadata.RunID,
 		SourceBranch:      sourceBranch,
 		TargetBranch:      cfg.TargetBranch,
 		Partitions:        plan.Partitions,
@@ -208,8 +662,206 @@ func (s *Splitter) validateAndExecute(plan *types.PartitionPlan, changes []types
 	return result, nil
 }
 
+// partitionCheckResult reports the partition checks run before each branch was pushed; under
+// the continue policy failed checks are a warning, the abort policy never gets here with one
+func partitionCheckResult(plan *types.PartitionPlan, failures []git.PartitionCheckFailure) types.ValidationResult {
+	if len(failures) == 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationPartitionCheck,
+			Status:  types.ValidationStatusPass,
+			Message: fmt.Sprintf("Partition checks passed on all %d partitions", len(plan.Partitions)),
+		}
+	}
+
+	var failed []string
+	for _, partition := range plan.Partitions {
+		if len(partition.FailedChecks) > 0 {
+			failed = append(failed, fmt.Sprintf("%d (%s)", partition.ID, strings.Join(partition.FailedChecks, ", ")))
+		}
+	}
+	return types.ValidationResult{
+		Type:    types.ValidationPartitionCheck,
+		Status:  types.ValidationStatusWarn,
+		Message: fmt.Sprintf("Partition check warning: checks failed on partitions %s", strings.Join(failed, "; ")),
+		Details: failures,
+	}
+}
+
+// conflictPredictionResult reports sibling partitions that will conflict once both are merged;
+// the branches are fine on their own, so conflicts are a warning
+func conflictPredictionResult(conflicts []git.PartitionConflict, err error) types.ValidationResult {
+	if err != nil {
+		return types.ValidationResult{
+			Type:    types.ValidationConflicts,
+			Status:  types.ValidationStatusWarn,
+			Message: fmt.Sprintf("Conflict prediction skipped: %v", err),
+		}
+	}
+	if len(conflicts) == 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationConflicts,
+			Status:  types.ValidationStatusPass,
+			Message: "Conflict prediction passed: sibling partitions merge cleanly in any order",
+		}
+	}
+
+	var pairs []string
+	for _, conflict := range conflicts {
+		pairs = append(pairs, fmt.Sprintf("%d and %d (%s)", conflict.Partitions[0], conflict.Partitions[1], strings.Join(conflict.Files, ", ")))
+	}
+	return types.ValidationResult{
+		Type:    types.ValidationConflicts,
+		Status:  types.ValidationStatusWarn,
+		Message: fmt.Sprintf("Conflict prediction warning: partitions %s will conflict when both are merged", strings.Join(pairs, "; ")),
+		Details: conflicts,
+	}
+}
+
+// checkTargetDrift warns when planned files changed on the target since the merge-base
+func (s *Splitter) checkTargetDrift(plan *types.PartitionPlan, cfg *types.Config, sourceBranch string) error {
+	if cfg.ApplyMode == types.ApplyModePatch || plan.Metadata.MergeBase == plan.Metadata.BaseCommit {
+		return nil
+	}
+
+	var paths []string
+	for _, partition := range plan.Partitions {
+		for _, file := range partition.Files {
+			if file.IsChanged && file.ChangeType != types.ChangeTypeAdd {
+				paths = append(paths, file.Path)
+			}
+		}
+	}
+
+	drifted, err := s.gitClient.GetTargetDrift(plan.Metadata.MergeBase, plan.Metadata.BaseCommit, paths)
+	if err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Could not check target drift: %v\n", err)
+		return nil
+	}
+
+	if len(drifted) == 0 {
+		return nil
+	}
+
+	s.displayDriftByPartition(plan, drifted)
+
+	decision, err := s.decisions.ResolveTargetDrift(drifted, cfg.TargetBranch)
+	if err != nil {
+		return fmt.Errorf("failed to get drift decision: %w", err)
+	}
+
+	switch decision {
+	case config.DriftUsePatchMode:
+		cfg.ApplyMode = types.ApplyModePatch
+	case config.DriftAbort:
+		return fmt.Errorf("%w: planned files changed on %s", partition.ErrUserAborted, cfg.TargetBranch)
+	}
+
+	return nil
+}
+
+// checkRemoteCollisions warns when planned branch names are already taken on the remote by another run
+func (s *Splitter) checkRemoteCollisions(plan *types.PartitionPlan, cfg *types.Config) error {
+	owners, err := s.gitClient.FindRemoteOwners(plannedBranchNames(plan))
+	if err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Could not check remote for existing branches: %v\n", err)
+		return nil
+	}
+
+	userEmail := s.gitClient.GetUserEmail()
+	var collisions []string
+	for _, owner := range owners {
+		if owner.RunID == plan.Metadata.RunID {
+			continue
+		}
+		// An update replaces your own earlier split
+		if cfg.UpdateExisting && owner.RunID != "" && strings.EqualFold(owner.CommitterEmail, userEmail) {
+			continue
+		}
+		collisions = append(collisions, describeOwner(owner))
+	}
+
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	userSlug := s.gitClient.GetUserSlug()
+	decision, err := s.decisions.ResolveCollision(collisions, userSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get collision decision: %w", err)
+	}
+
+	if decision == config.CollisionAbort {
+		return fmt.Errorf("%w: planned branches exist on the remote", partition.ErrUserAborted)
+	}
+
+	cfg.BranchSuffix = userSlug
+	partition.NewBranchNamer(cfg).AssignBranchNames(plan.Partitions)
+
+	// The suffixed names must be free as well
+	owners, err = s.gitClient.FindRemoteOwners(plannedBranchNames(plan))
+	if err == nil && len(owners) > 0 {
+		return fmt.Errorf("%w on the remote: %s; choose a different prefix or namespace", git.ErrBranchExists, owners[0].Branch)
+	}
+
+	fmt.Fprintf(s.out, "🏷️  Using per-user suffix: -%s\n", userSlug)
+	return nil
+}
+
+// reportStaleBranches lists branches of an earlier split that the new plan no longer uses
+func (s *Splitter) reportStaleBranches(plan *types.PartitionPlan, cfg *types.Config) {
+	local, err := s.gitClient.GetLocalBranches()
+	if err != nil {
+		return
+	}
+
+	planned := make(map[string]bool)
+	for _, name := range plannedBranchNames(plan) {
+		planned[name] = true
+	}
+
+	prefix := partition.NewBranchNamer(cfg).BranchPrefix()
+	var stale []string
+	for _, branch := range local {
+		if strings.HasPrefix(branch, prefix) && !planned[branch] {
+			stale = append(stale, branch)
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+
+	fmt.Fprintf(s.out, "🧹 %d branches from the previous split are no longer in the plan:\n", len(stale))
+	for _, branch := range stale {
+		fmt.Fprintf(s.out, "   - %s\n", branch)
+	}
+	fmt.Fprintf(s.out, "   Close their PRs and delete them with 'git branch -D' and 'git push %s --delete'\n", s.gitClient.Remote())
+}
+
 // Utility and display methods
 
+func plannedBranchNames(plan *types.PartitionPlan) []string {
+	names := make([]string, len(plan.Partitions))
+	for i, partition := range plan.Partitions {
+		names[i] = partition.BranchName
+	}
+	return names
+}
+
+func describeOwner(owner git.BranchOwner) string {
+	run := owner.RunID
+	if run == "" {
+		run = "no run id"
+	}
+	return fmt.Sprintf("%s (by %s <%s>, run %s)", owner.Branch, owner.CommitterName, owner.CommitterEmail, run)
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
 func (s *Splitter) countChangedFiles(changes []types.FileChange) int {
 	count := 0
 	for _, change := range changes {
@@ -221,40 +873,60 @@ func (s *Splitter) countChangedFiles(changes []types.FileChange) int {
 }
 
 func (s *Splitter) displayPartitionSummary(plan *types.PartitionPlan) {
-	fmt.Printf("📊 Partition Summary: %d partitions covering %d files\n",
+	fmt.Fprintf(s.out, "📊 Partition Summary: %d partitions covering %d files\n",
 		len(plan.Partitions), plan.Metadata.TotalFiles)
+	if plan.Metadata.DependencyEdges > 0 {
+		fmt.Fprintf(s.out, "✂️  Cut size: %d of %d dependency edges cross partition boundaries\n",
+			plan.Metadata.CutEdges, plan.Metadata.DependencyEdges)
+	}
 }
 
 func (s *Splitter) displayDetailedPlan(plan *types.PartitionPlan) {
-	fmt.Println()
-	fmt.Println("📦 Detailed Partition Plan:")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(s.out)
+	fmt.Fprintln(s.out, "📦 Detailed Partition Plan:")
+	fmt.Fprintln(s.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	for i, partition := range plan.Partitions {
-		fmt.Printf("Partition %d: %s (%d files)\n", i+1, partition.Description, len(partition.Files))
+		fmt.Fprintf(s.out, "Partition %d [%s]: %s (%d files)\n", i+1, partition.Slug, partition.Description, len(partition.Files))
 
 		// Show preview of files
 		maxShow := 3
 		for j, file := range partition.Files {
 			if j >= maxShow {
-				fmt.Printf("  ... and %d more files\n", len(partition.Files)-maxShow)
+				fmt.Fprintf(s.out, "  ... and %d more files\n", len(partition.Files)-maxShow)
 				break
 			}
-			fmt.Printf("  - %s (%s)\n", file.Path, file.ChangeType)
+			fmt.Fprintf(s.out, "  - %s (%s)\n", file.Path, file.DescribeChange())
+		}
+
+		if partition.Owner != "" {
+			fmt.Fprintf(s.out, "  Owner: %s\n", partition.Owner)
+		}
+
+		for _, selection := range partition.SplitFiles {
+			fmt.Fprintf(s.out, "  ✂ %s (%d of %d hunks)\n", selection.Path, len(selection.Hunks), selection.Total)
+		}
+
+		// Show review checklist
+		for _, item := range partition.Checklist {
+			fmt.Fprintf(s.out, "  ☐ %s\n", item)
 		}
 
 		// Show dependencies
 		if len(partition.Dependencies) > 0 {
-			fmt.Printf("  Dependencies: Partition %v\n", partition.Dependencies)
+			fmt.Fprintf(s.out, "  Dependencies: Partition %v\n", partition.Dependencies)
 		} else {
-			fmt.Printf("  Dependencies: None (base partition)\n")
+			fmt.Fprintf(s.out, "  Dependencies: None (base partition)\n")
 		}
-		fmt.Println()
+		fmt.Fprintln(s.out)
 	}
 
-	fmt.Printf("Total: %d files across %d partitions\n", plan.Metadata.TotalFiles, plan.Metadata.TotalPartitions)
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println()
+	fmt.Fprintf(s.out, "Total: %d files across %d partitions\n", plan.Metadata.TotalFiles, plan.Metadata.TotalPartitions)
+	if plan.Metadata.Topology != "" {
+		fmt.Fprintf(s.out, "Topology: %s\n", plan.Metadata.Topology)
+	}
+	fmt.Fprintln(s.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(s.out)
 }
 
 func (s *Splitter) displayExhaustivenessSummary(changes []types.FileChange, plan *types.PartitionPlan) {
@@ -265,35 +937,59 @@ func (s *Splitter) displayExhaustivenessSummary(changes []types.FileChange, plan
 		partitionFileCount += len(partition.Files)
 	}
 
-	fmt.Println("📊 Coverage Summary:")
-	fmt.Printf("   • Total changed files: %d\n", totalFiles)
-	fmt.Printf("   • Files in partitions: %d\n", partitionFileCount)
+	fmt.Fprintln(s.out, "📊 Coverage Summary:")
+	fmt.Fprintf(s.out, "   • Total changed files: %d\n", totalFiles)
+	fmt.Fprintf(s.out, "   • Files in partitions: %d\n", partitionFileCount)
 
 	if partitionFileCount == totalFiles {
-		fmt.Println("   ✅ All files included (100% coverage)")
+		fmt.Fprintln(s.out, "   ✅ All files included (100% coverage)")
 	} else {
-		fmt.Printf("   ⚠️  Coverage gap: %d files\n", totalFiles-partitionFileCount)
+		fmt.Fprintf(s.out, "   ⚠️  Coverage gap: %d files\n", totalFiles-partitionFileCount)
 	}
-	fmt.Println()
+	fmt.Fprintln(s.out)
 }
 
-func (s *Splitter) promptForApproval() (bool, error) {
-	fmt.Print("Proceed with this partition plan? [Y/n]: ")
+// displayMixedChangeWarnings flags partitions that combine mechanical and behavioral changes
+func (s *Splitter) displayMixedChangeWarnings(plan *types.PartitionPlan, cfg *types.Config) {
+	mixed := 0
+	for i, p := range plan.Partitions {
+		if !partition.IsHeavilyMixed(p) {
+			continue
+		}
+		mechanical, behavioral := partition.ChangeMix(p)
+		fmt.Fprintf(s.out, "⚠️  Partition %d mixes %d mechanical and %d behavioral changes\n", i+1, mechanical, behavioral)
+		mixed++
+	}
+
+	if mixed > 0 && !cfg.SeparateMechanical {
+		fmt.Fprintln(s.out, "   Reviewers find renames and moves easier to check on their own; use --separate-mechanical")
+		fmt.Fprintln(s.out)
+	}
+}
 
-	var input string
-	fmt.Scanln(&input)
+func (s *Splitter) displayDriftByPartition(plan *types.PartitionPlan, drifted []string) {
+	driftSet := make(map[string]bool)
+	for _, path := range drifted {
+		driftSet[path] = true
+	}
 
-	switch input {
-	case "n", "no", "N", "No":
-		return false, nil
-	default:
-		return true, nil
+	fmt.Fprintln(s.out, "🌊 Target-branch drift detected:")
+	for _, partition := range plan.Partitions {
+		var files []string
+		for _, file := range partition.Files {
+			if driftSet[file.Path] {
+				files = append(files, file.Path)
+			}
+		}
+		if len(files) > 0 {
+			fmt.Fprintf(s.out, "   Partition %d (%s): %d drifted files\n", partition.ID, partition.BranchName, len(files))
+		}
 	}
 }
 
 func (s *Splitter) displayValidationResults(results []types.ValidationResult) {
-	fmt.Println("\n❌ Validation Results:")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(s.out, "\n❌ Validation Results:")
+	fmt.Fprintln(s.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	for _, result := range results {
 		var status string
@@ -305,25 +1001,27 @@ func (s *Splitter) displayValidationResults(results []types.ValidationResult) {
 		case types.ValidationStatusFail:
 			status = "❌ FAIL"
 		}
-		fmt.Printf("%s %s: %s\n", status, result.Type, result.Message)
+		fmt.Fprintf(s.out, "%s %s: %s\n", status, result.Type, result.Message)
 	}
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(s.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 }
 
 func (s *Splitter) displaySuccessSummary(result *types.SplitResult, plan *types.PartitionPlan) {
-	fmt.Println()
-	fmt.Println("🎉 Success Summary:")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("Source Branch: %s\n", result.SourceBranch)
-	fmt.Printf("Target Branch: %s\n", result.TargetBranch)
-	fmt.Printf("Total Files: %d\n", plan.Metadata.TotalFiles)
-	fmt.Printf("Total Partitions: %d\n", plan.Metadata.TotalPartitions)
-	fmt.Printf("Created Branches: %d\n", len(result.CreatedBranches))
-	fmt.Println()
-	fmt.Println("📋 Next Steps:")
-	fmt.Println("1. Review the created branches")
-	fmt.Println("2. Create PRs for each branch in dependency order")
-	fmt.Println("3. Merge branches sequentially")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println()
+	fmt.Fprintln(s.out)
+	fmt.Fprintln(s.out, "🎉 Success Summary:")
+	fmt.Fprintln(s.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(s.out, "Run ID: %s\n", result.RunID)
+	fmt.Fprintf(s.out, "Source Branch: %s\n", result.SourceBranch)
+	fmt.Fprintf(s.out, "Target Branch: %s\n", result.TargetBranch)
+	fmt.Fprintf(s.out, "Base Commit: %s\n", shortSHA(plan.Metadata.BaseCommit))
+	fmt.Fprintf(s.out, "Total Files: %d\n", plan.Metadata.TotalFiles)
+	fmt.Fprintf(s.out, "Total Partitions: %d\n", plan.Metadata.TotalPartitions)
+	fmt.Fprintf(s.out, "Created Branches: %d\n", len(result.CreatedBranches))
+	fmt.Fprintln(s.out)
+	fmt.Fprintln(s.out, "📋 Next Steps:")
+	fmt.Fprintln(s.out, "1. Review the created branches")
+	fmt.Fprintln(s.out, "2. Create PRs for each branch in dependency order")
+	fmt.Fprintln(s.out, "3. Merge branches sequentially")
+	fmt.Fprintln(s.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(s.out)
 }
@@ -0,0 +1,105 @@
+package splitter
+
+import (
+	"fmt"
+	"time"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/partition"
+	"pr-splitter-cli/internal/state"
+	"pr-splitter-cli/internal/types"
+	"pr-splitter-cli/internal/validation"
+)
+
+// startRun records an approved plan in the state file before any branch is touched
+func (s *Splitter) startRun(plan *types.PartitionPlan, cfg *types.Config, sourceBranch string) {
+	gitDir, err := s.gitClient.GitCommonDir()
+	if err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Not recording run state: %v\n", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	run := &state.Run{
+		ID:           plan.Metadata.RunID,
+		Status:       state.RunStatusRunning,
+		StartedAt:    now,
+		UpdatedAt:    now,
+		SourceBranch: sourceBranch,
+		TargetBranch: cfg.TargetBranch,
+		Config:       *cfg,
+		Plan:         partition.WithoutContents(plan),
+	}
+	run.SourceCommit, _ = s.gitClient.ResolveCommit(sourceBranch)
+	run.TargetCommit, _ = s.gitClient.ResolveCommit(cfg.TargetRef())
+
+	s.trackRun(state.NewStore(gitDir), run)
+}
+
+// trackRun makes run the current record and keeps it up to date as branches are pushed
+func (s *Splitter) trackRun(store *state.Store, run *state.Run) {
+	s.store = store
+	s.run = run
+	s.saveRun()
+
+	s.gitClient.OnBranchPushed(func(progress git.BranchProgress) {
+		run.RecordBranch(state.BranchRecord{
+			PartitionID:    progress.PartitionID,
+			Name:           progress.Branch,
+			Commit:         progress.Commit,
+			Updated:        progress.Updated,
+			Previous:       progress.Previous,
+			PreviousRemote: progress.PreviousRemote,
+		})
+		s.saveRun()
+	})
+}
+
+// finishRun records the outcome of the run, if it got far enough to be recorded
+func (s *Splitter) finishRun(err error) {
+	if s.run == nil {
+		return
+	}
+	s.run.Finish(err)
+	s.saveRun()
+	s.gitClient.OnBranchPushed(nil)
+}
+
+// saveRun writes the current run record; state is bookkeeping, so failures only warn
+func (s *Splitter) saveRun() {
+	if err := s.store.SaveRun(s.run); err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: Could not record run state: %v\n", err)
+	}
+}
+
+// recordValidation adds the validation results of a phase to the run record and the report
+func (s *Splitter) recordValidation(phase string, results []types.ValidationResult) {
+	if s.report != nil {
+		s.report.AddPhase(phase, results)
+		s.saveReport()
+	}
+	if s.run == nil {
+		return
+	}
+	s.run.Validation = append(s.run.Validation, results...)
+	s.saveRun()
+}
+
+// startReport begins the validation report of the run when one is configured
+func (s *Splitter) startReport(plan *types.PartitionPlan, cfg *types.Config, sourceBranch string) {
+	if cfg.ReportOutput == "" {
+		return
+	}
+	s.report = validation.NewReport(plan.Metadata.RunID, sourceBranch, cfg.TargetBranch)
+	s.reportOutput = cfg.ReportOutput
+}
+
+// saveReport rewrites the report after each phase, so a failed split still leaves one; like
+// the run state it is bookkeeping, so failures only warn
+func (s *Splitter) saveReport() {
+	if err := validation.WriteReport(s.report, s.reportOutput); err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: %v\n", err)
+		return
+	}
+	fmt.Fprintf(s.out, "📝 Saved validation report to %s\n", s.reportOutput)
+}
@@ -0,0 +1,268 @@
+package splitter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/types"
+)
+
+// SyncPlan describes how new source branch commits are distributed over an existing split
+type SyncPlan struct {
+	SourceBranch string
+	Branches     []string            // Existing partition branches in partition order
+	Updated      map[string][]string // Partition branch to the files synced into it
+	NewBranch    string              // Partition created for files unrelated to existing ones
+	Restacked    []string            // Branches rebased because a branch they build on changed, set by ApplySync
+
+	updates map[int][]string
+}
+
+// PlanSync works out how to bring existing partition branches (in partition order) up to date
+// with the source branch. Changed files go to the partition that last touched them, new files to
+// the partition they depend on, and files unrelated to any partition to a new partition at the end.
+func (s *Splitter) PlanSync(ctx context.Context, sourceBranch string, branches []string, cfg *types.Config) (*SyncPlan, error) {
+	s.bind(ctx)
+	if err := s.useRemotes(cfg); err != nil {
+		return nil, err
+	}
+	s.gitClient.SetNoVerify(cfg.NoVerify)
+	if err := s.gitClient.FetchRemote(); err != nil {
+		fmt.Fprintf(s.out, "⚠️  Warning: %v; using local branches\n", err)
+	}
+	for _, branch := range branches {
+		if err := s.gitClient.SyncWithRemote(branch); err != nil {
+			return nil, err
+		}
+	}
+
+	mergeBase, err := s.gitClient.GetMergeBase(cfg.TargetRef(), sourceBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge-base of %s and %s: %w", cfg.TargetRef(), sourceBranch, err)
+	}
+	changes, err := s.analyzeChanges(sourceBranch, cfg.TargetRef(), mergeBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze changes: %w", err)
+	}
+
+	// The partition that last touched a file holds its current content in the split
+	owner := make(map[string]int)
+	for i, branch := range branches {
+		exclude := append([]string{cfg.TargetRef()}, branches[:i]...)
+		files, err := s.gitClient.BranchFiles(branch, exclude)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			owner[file] = i
+		}
+	}
+
+	updates := make(map[int][]string)
+	var unowned []string
+	for _, change := range changes {
+		if !change.IsChanged {
+			continue
+		}
+		paths := []string{change.Path}
+		if change.OldPath != "" {
+			paths = append(paths, change.OldPath)
+		}
+		for _, path := range paths {
+			i, ok := owner[path]
+			if !ok {
+				if path == change.Path && !s.gitClient.SameContent(sourceBranch, branches[len(branches)-1], path) {
+					unowned = append(unowned, path)
+				}
+				continue
+			}
+			if !s.gitClient.SameContent(sourceBranch, branches[i], path) {
+				updates[i] = append(updates[i], path)
+			}
+		}
+	}
+
+	newPartition := len(branches)
+	if len(unowned) > 0 {
+		dependencies, err := s.analyzeDependencies(changes, sourceBranch, cfg, mergeBase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze dependencies: %w", err)
+		}
+		for path, i := range placeNewFiles(unowned, owner, dependencies, newPartition) {
+			updates[i] = append(updates[i], path)
+		}
+	}
+
+	result := &SyncPlan{SourceBranch: sourceBranch, Branches: branches, Updated: make(map[string][]string), updates: updates}
+	if len(updates[newPartition]) > 0 {
+		result.NewBranch = fmt.Sprintf("%s-%d-sync", partitionPrefix(branches), newPartition+1)
+	}
+	for i, files := range updates {
+		sort.Strings(files)
+		branch := result.NewBranch
+		if i < len(branches) {
+			branch = branches[i]
+		}
+		result.Updated[branch] = files
+	}
+	return result, nil
+}
+
+// placeNewFiles assigns files that no partition touched yet. A file joins the latest partition
+// it depends on, or else the earliest partition depending on it; unrelated files go to the new
+// partition at index fallback. Placement repeats so new files can follow each other.
+func placeNewFiles(files []string, owner map[string]int, dependencies []types.Dependency, fallback int) map[string]int {
+	placed := make(map[string]int)
+	lookup := func(path string) (int, bool) {
+		if i, ok := owner[path]; ok {
+			return i, true
+		}
+		i, ok := placed[path]
+		return i, ok
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, file := range files {
+			if _, ok := placed[file]; ok {
+				continue
+			}
+			latestDependency, earliestDependent := -1, -1
+			for _, dep := range dependencies {
+				if dep.From == file {
+					if i, ok := lookup(dep.To); ok && i > latestDependency {
+						latestDependency = i
+					}
+				}
+				if dep.To == file {
+					if i, ok := lookup(dep.From); ok && (earliestDependent < 0 || i < earliestDependent) {
+						earliestDependent = i
+					}
+				}
+			}
+			switch {
+			case latestDependency >= 0:
+				placed[file] = latestDependency
+			case earliestDependent >= 0:
+				placed[file] = earliestDependent
+			default:
+				continue
+			}
+			changed = true
+		}
+	}
+
+	for _, file := range files {
+		if _, ok := placed[file]; !ok {
+			placed[file] = fallback
+		}
+	}
+	return placed
+}
+
+// ApplySync commits the planned updates, rebases branches stacked on changed branches and pushes them
+func (s *Splitter) ApplySync(ctx context.Context, result *SyncPlan) error {
+	s.bind(ctx)
+	sourceBranch, branches, updates := result.SourceBranch, result.Branches, result.updates
+
+	dirty, err := s.gitClient.HasLocalChanges()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w; commit or stash them before syncing", git.ErrDirtyWorktree)
+	}
+
+	runID := ""
+	if owner, err := s.gitClient.GetBranchOwner(branches[0]); err == nil {
+		runID = owner.RunID
+	}
+	message := func(i int, files []string) string {
+		msg := fmt.Sprintf("Partition %d: sync %d files from %s", i+1, len(files), sourceBranch)
+		if runID != "" {
+			msg += "\n\n" + git.RunTrailer(runID)
+		}
+		return msg
+	}
+
+	oldTips := make(map[string]string)
+	remoteTips := make(map[string]string)
+	for _, branch := range branches {
+		if oldTips[branch], err = s.gitClient.ResolveCommit(branch); err != nil {
+			return err
+		}
+		if remote := s.gitClient.RemoteRef(branch); remote != branch {
+			remoteTips[branch], _ = s.gitClient.ResolveCommit(remote)
+		}
+	}
+
+	originalBranch, _ := s.gitClient.GetCurrentBranch()
+	defer func() {
+		if originalBranch != "" {
+			if err := s.gitClient.WithContext(context.WithoutCancel(ctx)).CheckoutBranch(originalBranch); err != nil {
+				fmt.Fprintf(s.out, "⚠️  Warning: Could not return to %s: %v\n", originalBranch, err)
+			}
+		}
+	}()
+
+	changed := make(map[string]bool)
+	for j, branch := range branches {
+		// A branch builds on the latest earlier partition its old tip contains
+		for i := j - 1; i >= 0; i-- {
+			if !s.gitClient.IsAncestor(oldTips[branches[i]], oldTips[branch]) {
+				continue
+			}
+			if changed[branches[i]] {
+				if err := s.gitClient.RebaseOnto(branch, oldTips[branches[i]], branches[i]); err != nil {
+					return err
+				}
+				changed[branch] = true
+				result.Restacked = append(result.Restacked, branch)
+			}
+			break
+		}
+
+		if files := updates[j]; len(files) > 0 {
+			if err := s.gitClient.CommitPathsFrom(branch, sourceBranch, files, message(j, files)); err != nil {
+				return err
+			}
+			changed[branch] = true
+		}
+	}
+
+	pushOrder := append([]string(nil), branches...)
+	if result.NewBranch != "" {
+		files := updates[len(branches)]
+		if err := s.gitClient.CreateBranchAt(result.NewBranch, branches[len(branches)-1]); err != nil {
+			return err
+		}
+		if err := s.gitClient.CommitPathsFrom(result.NewBranch, sourceBranch, files, message(len(branches), files)); err != nil {
+			return err
+		}
+		changed[result.NewBranch] = true
+		pushOrder = append(pushOrder, result.NewBranch)
+	}
+
+	for _, branch := range pushOrder {
+		if !changed[branch] {
+			continue
+		}
+		if err := s.gitClient.ForcePushWithLease(branch, remoteTips[branch]); err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, "⬆️  Pushed %s\n", branch)
+	}
+	return nil
+}
+
+// partitionPrefix is the part of the first branch name before its partition number
+func partitionPrefix(branches []string) string {
+	first := branches[0]
+	if i := strings.Index(first, "-1-"); i >= 0 {
+		return first[:i]
+	}
+	return strings.TrimRight(first, "-0123456789")
+}
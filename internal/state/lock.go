@@ -0,0 +1,117 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// LockName is the lock file under Dir that keeps two runs from sharing a repository
+const LockName = "lock"
+
+// LockInfo describes the process holding the lock
+type LockInfo struct {
+	PID       int       `json:"pid"`
+	Host      string    `json:"host"`
+	Command   string    `json:"command"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// Lock is a held repository lock
+type Lock struct {
+	path  string
+	Stale *LockInfo // Holder of a stale lock that was taken over, nil if the lock was free
+}
+
+// LockedError reports that another pr-split process holds the repository lock
+type LockedError struct {
+	Path   string
+	Holder LockInfo
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("another pr-split process is working in this repository: pid %d on %s, '%s', started %s; "+
+		"wait for it to finish, or delete %s if it is no longer running",
+		e.Holder.PID, e.Holder.Host, e.Holder.Command, e.Holder.StartedAt.Local().Format("2006-01-02 15:04:05"), e.Path)
+}
+
+// AcquireLock takes the repository lock of the git common dir gitDir. A lock left by a process
+// on this host that no longer exists is stale and taken over.
+func AcquireLock(gitDir, command string) (*Lock, error) {
+	path := filepath.Join(gitDir, Dir, LockName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	host, _ := os.Hostname()
+	info := LockInfo{PID: os.Getpid(), Host: host, Command: command, StartedAt: time.Now().UTC()}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lock: %w", err)
+	}
+
+	var stale *LockInfo
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, writeErr := file.Write(data)
+			file.Close()
+			if writeErr != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock: %w", writeErr)
+			}
+			return &Lock{path: path, Stale: stale}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock: %w", err)
+		}
+
+		holder, readErr := readLock(path)
+		if readErr == nil && !holder.isStale(host) {
+			return nil, &LockedError{Path: path, Holder: holder}
+		}
+		// An unreadable lock may be one another process is still writing
+		if stat, statErr := os.Stat(path); readErr != nil && statErr == nil && time.Since(stat.ModTime()) < 10*time.Second {
+			return nil, &LockedError{Path: path, Holder: LockInfo{Command: "unknown", StartedAt: stat.ModTime()}}
+		}
+		stale = &holder
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale lock: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("failed to acquire %s", path)
+}
+
+// Release removes the lock
+func (l *Lock) Release() {
+	os.Remove(l.path)
+}
+
+// readLock reads the holder of a lock file
+func readLock(path string) (LockInfo, error) {
+	var info LockInfo
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
+
+// isStale reports whether the holder is known to be gone. Processes on other hosts (a
+// repository on a network share) cannot be checked, so their locks are never stale.
+func (info LockInfo) isStale(host string) bool {
+	if info.Host != host || info.PID <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(info.PID)
+	if err != nil {
+		return true
+	}
+	err = process.Signal(syscall.Signal(0))
+	return errors.Is(err, os.ErrProcessDone) || errors.Is(err, syscall.ESRCH)
+}
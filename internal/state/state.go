@@ -0,0 +1,211 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Dir is the directory under the git common dir that holds pr-split state
+const Dir = "pr-split"
+
+// FileName is the state file inside Dir
+const FileName = "state.json"
+
+// MaxRuns is how many run records are kept; older runs are dropped first
+const MaxRuns = 20
+
+// Version is the current state file format
+const Version = 1
+
+// RunStatus is the lifecycle state of a split run
+type RunStatus string
+
+const (
+	RunStatusRunning   RunStatus = "running"   // Plan approved, branches being created
+	RunStatusCompleted RunStatus = "completed" // Branches created and validated
+	RunStatusFailed    RunStatus = "failed"    // Stopped with an error
+	RunStatusUndone    RunStatus = "undone"    // Branches removed or restored by 'pr-split undo'
+)
+
+// State is the content of the state file
+type State struct {
+	Version int    `json:"version"`
+	Runs    []*Run `json:"runs"` // Oldest first
+}
+
+// Run records one split run
+type Run struct {
+	ID           string                   `json:"id"`
+	Status       RunStatus                `json:"status"`
+	Error        string                   `json:"error,omitempty"`
+	StartedAt    time.Time                `json:"startedAt"`
+	UpdatedAt    time.Time                `json:"updatedAt"`
+	FinishedAt   *time.Time               `json:"finishedAt,omitempty"`
+	SourceBranch string                   `json:"sourceBranch"`
+	SourceCommit string                   `json:"sourceCommit"`
+	TargetBranch string                   `json:"targetBranch"`
+	TargetCommit string                   `json:"targetCommit"`
+	Config       types.Config             `json:"config"`
+	Plan         *types.PartitionPlan     `json:"plan"` // Without file contents
+	Branches     []BranchRecord           `json:"branches"`
+	Validation   []types.ValidationResult `json:"validation,omitempty"`
+}
+
+// BranchRecord is a partition branch written and pushed by a run
+type BranchRecord struct {
+	PartitionID    int    `json:"partitionId"`
+	Name           string `json:"name"`
+	Commit         string `json:"commit"`
+	Updated        bool   `json:"updated,omitempty"`        // Reset from an earlier split rather than created
+	Previous       string `json:"previous,omitempty"`       // Local tip before an update, empty if there was no local branch
+	PreviousRemote string `json:"previousRemote,omitempty"` // Tip on origin before an update, empty if it was never pushed
+}
+
+// RecordBranch adds or replaces the record of a branch
+func (r *Run) RecordBranch(record BranchRecord) {
+	r.UpdatedAt = time.Now().UTC()
+	for i, existing := range r.Branches {
+		if existing.Name == record.Name {
+			r.Branches[i] = record
+			return
+		}
+	}
+	r.Branches = append(r.Branches, record)
+}
+
+// Finish marks the run completed, or failed with err
+func (r *Run) Finish(err error) {
+	now := time.Now().UTC()
+	r.UpdatedAt = now
+	r.FinishedAt = &now
+	if err != nil {
+		r.Status = RunStatusFailed
+		r.Error = err.Error()
+		return
+	}
+	r.Status = RunStatusCompleted
+}
+
+// Branch returns the record of a branch, or nil when the run did not push it
+func (r *Run) Branch(name string) *BranchRecord {
+	for i := range r.Branches {
+		if r.Branches[i].Name == name {
+			return &r.Branches[i]
+		}
+	}
+	return nil
+}
+
+// Latest returns the most recent run, or nil when none is recorded
+func (s *State) Latest() *Run {
+	if len(s.Runs) == 0 {
+		return nil
+	}
+	return s.Runs[len(s.Runs)-1]
+}
+
+// Find returns the run with the given id, or nil
+func (s *State) Find(id string) *Run {
+	for _, run := range s.Runs {
+		if run.ID == id {
+			return run
+		}
+	}
+	return nil
+}
+
+// LatestForBranch returns the most recent run that pushed branch, or nil
+func (s *State) LatestForBranch(branch string) *Run {
+	for i := len(s.Runs) - 1; i >= 0; i-- {
+		if s.Runs[i].Branch(branch) != nil {
+			return s.Runs[i]
+		}
+	}
+	return nil
+}
+
+// Store reads and writes the state file of a repository
+type Store struct {
+	path string
+}
+
+// NewStore creates a store for the repository whose git common dir is gitDir
+func NewStore(gitDir string) *Store {
+	return &Store{path: filepath.Join(gitDir, Dir, FileName)}
+}
+
+// Path is the location of the state file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the state file; a missing file is an empty state
+func (s *Store) Load() (*State, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return &State{Version: Version}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", s.path, err)
+	}
+	if state.Version > Version {
+		return nil, fmt.Errorf("state file %s has version %d; upgrade pr-split to read it", s.path, state.Version)
+	}
+	return &state, nil
+}
+
+// SaveRun writes run into the state file, replacing an earlier record of the same run
+func (s *Store) SaveRun(run *Run) error {
+	state, err := s.Load()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, existing := range state.Runs {
+		if existing.ID == run.ID {
+			state.Runs[i] = run
+			replaced = true
+		}
+	}
+	if !replaced {
+		state.Runs = append(state.Runs, run)
+	}
+	if len(state.Runs) > MaxRuns {
+		state.Runs = state.Runs[len(state.Runs)-MaxRuns:]
+	}
+
+	state.Version = Version
+	return s.save(state)
+}
+
+// save writes the whole state atomically so an interrupted run never leaves a truncated file
+func (s *Store) save(state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
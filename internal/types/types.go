@@ -10,7 +10,48 @@ type FileChange struct {
 	LinesAdded   int        `json:"linesAdded"`
 	LinesDeleted int        `json:"linesDeleted"`
 	IsChanged    bool       `json:"isChanged"`
-	OldPath      string     `json:"oldPath,omitempty"` // For renames
+	OldPath      string     `json:"oldPath,omitempty"`   // For renames
+	Kind         ChangeKind `json:"kind,omitempty"`      // Mechanical or behavioral, set when diffs are classified
+	Mode         string     `json:"mode,omitempty"`      // Git file mode on the source branch, e.g. 100755 or 120000 for a symlink
+	OldMode      string     `json:"oldMode,omitempty"`   // Mode before the change, set only when the change alters it
+	IsBinary     bool       `json:"binary,omitempty"`    // Binary content: not loaded, not analyzed, placed by the binary files policy
+	IsSubmodule  bool       `json:"submodule,omitempty"` // Submodule pointer (gitlink): applied as a commit ID in the index, never loaded
+}
+
+// Git file modes of changed files
+const (
+	FileModeRegular    = "100644"
+	FileModeExecutable = "100755"
+	FileModeSymlink    = "120000"
+	FileModeGitlink    = "160000" // Submodule commit
+)
+
+// IsSymlink reports whether the file is a symbolic link on the source branch; its content is the link target
+func (f FileChange) IsSymlink() bool {
+	return f.Mode == FileModeSymlink
+}
+
+// ModeChanged reports whether the change alters the file mode, e.g. sets the executable bit
+func (f FileChange) ModeChanged() bool {
+	return f.OldMode != "" && f.OldMode != f.Mode
+}
+
+// DescribeChange returns the change type with any mode change, e.g. "MODIFY, mode 100644 → 100755"
+func (f FileChange) DescribeChange() string {
+	description := string(f.ChangeType)
+	if f.IsBinary {
+		description += ", binary"
+	}
+	if f.IsSubmodule {
+		return description + ", submodule"
+	}
+	if f.ModeChanged() {
+		return description + ", mode " + f.OldMode + " → " + f.Mode
+	}
+	if f.IsSymlink() {
+		return description + ", symlink"
+	}
+	return description
 }
 
 // ChangeType represents the type of change made to a file
@@ -23,14 +64,45 @@ const (
 	ChangeTypeRename ChangeType = "RENAME"
 )
 
+// ChangeKind separates mechanical edits from logic changes
+type ChangeKind string
+
+const (
+	ChangeKindMechanical ChangeKind = "MECHANICAL" // rename, move, formatting, identifier renames
+	ChangeKindBehavioral ChangeKind = "BEHAVIORAL" // anything that may change what the code does
+)
+
+// LineDiff holds the removed and added lines of a file, grouped by hunk
+type LineDiff struct {
+	Hunks []DiffHunk
+}
+
+// DiffHunk is one contiguous block of removed and added lines
+type DiffHunk struct {
+	OldStart     int // First removed line in the base file, or the line inserted after when nothing is removed
+	OldLines     int
+	Removed      []string
+	Added        []string
+	AddedNoEOL   bool // The added lines end the file without a trailing newline
+	RemovedNoEOL bool // The removed lines ended the base file without a trailing newline
+}
+
+// HunkSelection assigns some of a file's hunks to a partition
+type HunkSelection struct {
+	Path  string `json:"path"`
+	Hunks []int  `json:"hunks"` // Indexes into the file's zero-context diff hunks
+	Total int    `json:"total"` // Number of hunks in the file
+}
+
 // Dependency represents a relationship between two files
 type Dependency struct {
-	From     string             `json:"from"`
-	To       string             `json:"to"`
-	Type     string             `json:"type"`
-	Strength DependencyStrength `json:"strength"`
-	Line     int                `json:"line,omitempty"`    // Line number where dependency occurs
-	Context  string             `json:"context,omitempty"` // Code context around dependency
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	Type      string             `json:"type"`
+	Strength  DependencyStrength `json:"strength"`
+	Line      int                `json:"line,omitempty"`      // Line number where dependency occurs
+	Context   string             `json:"context,omitempty"`   // Code context around dependency
+	Analyzers []string           `json:"analyzers,omitempty"` // Analyzers that reported this edge
 }
 
 // DependencyStrength represents how strong a dependency is
@@ -44,11 +116,28 @@ const (
 	StrengthCircular DependencyStrength = "CIRCULAR" // mutual dependencies
 )
 
+// Rank orders strengths from weakest to strongest; unknown strengths rank lowest
+func (s DependencyStrength) Rank() int {
+	switch s {
+	case StrengthCritical, StrengthCircular:
+		return 4
+	case StrengthStrong:
+		return 3
+	case StrengthModerate:
+		return 2
+	case StrengthWeak:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // PluginInput represents the input sent to plugins
 type PluginInput struct {
 	ChangedFiles []FileChange `json:"changedFiles"`
 	ProjectFiles []FileChange `json:"projectFiles"`
 	ProjectRoot  string       `json:"projectRoot"`
+	IncludePaths []string     `json:"includePaths,omitempty"` // Extra header search directories for C/C++
 }
 
 // PluginOutput represents the output from plugins
@@ -68,12 +157,17 @@ type PluginMetadata struct {
 
 // Partition represents a group of files that should go together
 type Partition struct {
-	ID           int          `json:"id"`
-	Name         string       `json:"name"`
-	Description  string       `json:"description"`
-	Files        []FileChange `json:"files"`
-	Dependencies []int        `json:"dependencies"` // IDs of partitions this depends on
-	BranchName   string       `json:"branchName"`
+	ID           int             `json:"id"`             // Position in creation order
+	Slug         string          `json:"slug,omitempty"` // Stable identifier used by commands, e.g. "auth" or "api-2"
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Files        []FileChange    `json:"files"`
+	Dependencies []int           `json:"dependencies"` // IDs of partitions this depends on
+	BranchName   string          `json:"branchName"`
+	Checklist    []string        `json:"checklist,omitempty"`    // Review checklist items triggered by the partition's files
+	Owner        string          `json:"owner,omitempty"`        // Predominant recent author or team of the partition's files
+	SplitFiles   []HunkSelection `json:"splitFiles,omitempty"`   // Files shared with other partitions at hunk level
+	FailedChecks []string        `json:"failedChecks,omitempty"` // Partition check commands that failed on the branch
 }
 
 // PartitionPlan represents the complete partitioning strategy
@@ -88,11 +182,18 @@ type PlanMetadata struct {
 	TotalPartitions      int       `json:"totalPartitions"`
 	MaxFilesPerPartition int       `json:"maxFilesPerPartition"`
 	Strategy             string    `json:"strategy"`
+	Topology             string    `json:"topology,omitempty"` // How partition branches are based on each other
 	CreatedAt            time.Time `json:"createdAt"`
+	MergeBase            string    `json:"mergeBase,omitempty"`  // Pinned merge-base SHA all diffs are computed against
+	BaseCommit           string    `json:"baseCommit,omitempty"` // SHA that root partition branches are created from
+	RunID                string    `json:"runId,omitempty"`      // Identifies this split run in commit trailers
+	DependencyEdges      int       `json:"dependencyEdges"`      // Dependency edges between changed files
+	CutEdges             int       `json:"cutEdges"`             // Edges whose files landed in different partitions
 }
 
 // SplitResult represents the final result of the splitting operation
 type SplitResult struct {
+	RunID             string             `json:"runId"`
 	SourceBranch      string             `json:"sourceBranch"`
 	TargetBranch      string             `json:"targetBranch"`
 	Partitions        []Partition        `json:"partitions"`
@@ -117,8 +218,22 @@ const (
 	ValidationDependency     ValidationType = "DEPENDENCY"
 	ValidationGitIntegrity   ValidationType = "GIT_INTEGRITY"
 	ValidationDiffComparison ValidationType = "DIFF_COMPARISON"
+	ValidationTypeCheck      ValidationType = "TYPE_CHECK"
+	ValidationPartitionCheck ValidationType = "PARTITION_CHECK"
+	ValidationConflicts      ValidationType = "CONFLICT_PREDICTION"
+	ValidationBinaryFiles    ValidationType = "BINARY_FILES"
 )
 
+// Severities overrides the status of non-passing validation results by validation type,
+// e.g. to fail on TYPE_CHECK warnings or only warn about CONFLICT_PREDICTION
+type Severities map[ValidationType]ValidationStatus
+
+// ValidationTypes lists every validation type, e.g. to check severity overrides
+var ValidationTypes = []ValidationType{
+	ValidationStructural, ValidationDependency, ValidationGitIntegrity, ValidationDiffComparison,
+	ValidationTypeCheck, ValidationPartitionCheck, ValidationConflicts, ValidationBinaryFiles,
+}
+
 // ValidationStatus represents the status of a validation check
 type ValidationStatus string
 
@@ -130,13 +245,132 @@ const (
 
 // Config represents the configuration for the splitting operation
 type Config struct {
-	MaxFilesPerPartition int    `json:"maxFilesPerPartition"`
-	MaxPartitions        int    `json:"maxPartitions"`
-	BranchPrefix         string `json:"branchPrefix"`
-	Strategy             string `json:"strategy"`
-	TargetBranch         string `json:"targetBranch"`
+	MaxFilesPerPartition  int                 `json:"maxFilesPerPartition"`
+	MaxPartitions         int                 `json:"maxPartitions"`
+	MaxLinesPerPartition  int                 `json:"maxLinesPerPartition,omitempty"` // Split partitions whose added plus deleted lines exceed this
+	BranchPrefix          string              `json:"branchPrefix"`
+	Strategy              string              `json:"strategy"`
+	TargetBranch          string              `json:"targetBranch"`
+	BranchTemplate        string              `json:"branchTemplate,omitempty"`
+	BranchNamespace       string              `json:"branchNamespace,omitempty"`       // e.g. "split/{user}", prepended to every branch name
+	BranchSuffix          string              `json:"branchSuffix,omitempty"`          // Appended to every branch name to avoid remote collisions
+	PostSummary           bool                `json:"postSummary,omitempty"`           // Post a split summary comment on the source branch's PR
+	CreatePRs             bool                `json:"createPRs,omitempty"`             // Open a PR per partition through the provider API
+	PRDraft               bool                `json:"prDraft,omitempty"`               // Open partition PRs as drafts
+	PRLabels              []string            `json:"prLabels,omitempty"`              // Labels for partition PRs; {index} and {total} are expanded
+	PRMilestone           string              `json:"prMilestone,omitempty"`           // Title of the milestone partition PRs are added to
+	RequestReviews        bool                `json:"requestReviews,omitempty"`        // Request reviews on partition PRs from CODEOWNERS
+	Reviewers             map[string][]string `json:"reviewers,omitempty"`             // Path glob to reviewers, overriding CODEOWNERS
+	IncludePaths          []string            `json:"includePaths,omitempty"`          // Header search directories for the C/C++ analyzer
+	APIConcurrency        int                 `json:"apiConcurrency,omitempty"`        // Maximum concurrent provider API requests
+	APIRateLimit          float64             `json:"apiRateLimit,omitempty"`          // Maximum provider API requests per second
+	PlanOutput            string              `json:"planOutput,omitempty"`            // Write the partition plan as JSON for 'pr-split plan diff'
+	MermaidOutput         string              `json:"mermaidOutput,omitempty"`         // Write the partition stack as a Mermaid diagram
+	ReportOutput          string              `json:"reportOutput,omitempty"`          // Write the validation results as JSON, or Markdown for .md files
+	GeneratedCode         []GeneratedCodeRule `json:"generatedCode,omitempty"`         // Pair schema files with their generated outputs
+	SeparateMechanical    bool                `json:"separateMechanical,omitempty"`    // Move mechanical changes into their own partitions
+	ChecklistRules        []ChecklistRule     `json:"checklistRules,omitempty"`        // File patterns mapped to reviewer checklist items
+	PluginPriority        map[string]int      `json:"pluginPriority,omitempty"`        // Higher wins when analyzers report the same edge or extension
+	GroupByDirectory      bool                `json:"groupByDirectory,omitempty"`      // Split large dependency levels by directory instead of truncating
+	MinDependencyStrength DependencyStrength  `json:"minDependencyStrength,omitempty"` // Ignore weaker edges when partitioning
+	CoChange              bool                `json:"coChange,omitempty"`              // Add weak edges between files that historically change together
+	CoChangeCommits       int                 `json:"coChangeCommits,omitempty"`       // History depth for co-change analysis
+	Teams                 map[string][]string `json:"teams,omitempty"`                 // Team name to member emails, used by the ownership strategy
+	Topology              string              `json:"topology,omitempty"`              // linear, independent or dag branch bases
+	SplitHunks            []string            `json:"splitHunks,omitempty"`            // Globs of shared files that may be split across partitions by hunk
+	ApplyMode             string              `json:"applyMode,omitempty"`
+	RebasePlan            bool                `json:"rebasePlan,omitempty"`         // Base partitions on the current target tip instead of the merge-base
+	UpdateExisting        bool                `json:"updateExisting,omitempty"`     // Reset branches left by a previous split instead of failing
+	KeepProgress          bool                `json:"keepProgress,omitempty"`       // On failure keep pushed branches so the run can be resumed
+	Parallelism           int                 `json:"parallelism,omitempty"`        // Partition branches built and pushed at once, 0 for the default
+	PushRemote            string              `json:"pushRemote,omitempty"`         // Remote partition branches are pushed to, origin when empty
+	UpstreamRemote        string              `json:"upstreamRemote,omitempty"`     // Remote whose target branch partitions are based on, e.g. upstream of a fork
+	PushOptions           []string            `json:"pushOptions,omitempty"`        // Sent with every partition push as --push-option, e.g. "ci.skip"
+	NoPush                bool                `json:"noPush,omitempty"`             // Create and validate branches locally without pushing them
+	NoVerify              bool                `json:"noVerify,omitempty"`           // Skip git hooks on the tool's own commits and pushes
+	SignCommits           bool                `json:"signCommits,omitempty"`        // Sign partition commits even when commit.gpgSign is off
+	SignOff               bool                `json:"signOff,omitempty"`            // Add the operator's Signed-off-by trailer to partition commits
+	CoAuthorTrailers      bool                `json:"coAuthorTrailers,omitempty"`   // Credit the source commits' authors with Co-authored-by trailers
+	OperatorAuthor        bool                `json:"operatorAuthor,omitempty"`     // Author partition commits as the operator instead of the dominant source author
+	ProtectedBranches     []string            `json:"protectedBranches,omitempty"`  // Branch names or globs rollbacks never delete, on top of main, master, develop and release/*
+	PartitionChecks       []string            `json:"partitionChecks,omitempty"`    // Commands run in a worktree of each partition branch before it is pushed
+	CheckPolicy           string              `json:"checkPolicy,omitempty"`        // What a failed partition check does, abort or continue
+	StrictValidation      bool                `json:"strictValidation,omitempty"`   // Treat validation warnings as failures
+	ValidationSeverity    Severities          `json:"validationSeverity,omitempty"` // Status of non-passing results per validation type, overriding strict
+	BinaryFiles           string              `json:"binaryFiles,omitempty"`        // Where binary files go, with their directory or in their own partition
+	ExcludeTests          bool                `json:"excludeTests,omitempty"`       // Leave changed test files out of the split instead of pairing them with their sources
+	PairingRules          []PairingRule       `json:"pairingRules,omitempty"`       // File pairs kept in one partition on top of the built-in lockfile rules
+	SkipProjectGraph      bool                `json:"skipProjectGraph,omitempty"`   // Ignore the Nx or Turborepo project graph of the repository
+	Since                 string              `json:"since,omitempty"`              // Only split the files changed by commits after this one
+	CommitPattern         string              `json:"commitPattern,omitempty"`      // Only split the files changed by commits whose message matches this regular expression
+	GitHubTokenEnv        string              `json:"githubTokenEnv,omitempty"`     // Environment variable holding the GitHub token, read before GITHUB_TOKEN and GH_TOKEN
+	ContextDepth          int                 `json:"contextDepth,omitempty"`       // Levels of imports followed for plugin project context, 0 for the default, negative for every file
+	ContextCacheTTL       time.Duration       `json:"contextCacheTTL,omitempty"`    // How long a collected project context is reused for the same source commit, 0 for the default, negative to disable
 }
 
+// TargetRef returns the revision the target branch is read from: the upstream remote's
+// tracking branch in a fork workflow, the local branch otherwise
+func (c *Config) TargetRef() string {
+	if c.UpstreamRemote == "" {
+		return c.TargetBranch
+	}
+	return c.UpstreamRemote + "/" + c.TargetBranch
+}
+
+// GeneratedCodeRule maps schema files to the generated files that must ship with them
+type GeneratedCodeRule struct {
+	Source    string   `json:"source" yaml:"source"`       // Glob for schema files, e.g. "proto/**/*.proto"
+	Generated []string `json:"generated" yaml:"generated"` // Globs for outputs, may use {dir} and {name}
+}
+
+// PairingRule keeps a changed file in the partition of a changed companion file in the same
+// directory, e.g. a lockfile with its manifest
+type PairingRule struct {
+	File string `json:"file" yaml:"file"` // File name, e.g. "yarn.lock"
+	With string `json:"with" yaml:"with"` // Name of the companion file, e.g. "package.json"
+}
+
+// ChecklistRule adds a reviewer checklist item to partitions containing a matching file
+type ChecklistRule struct {
+	Patterns []string `json:"patterns" yaml:"patterns"` // Path globs; globs without a slash match the file name
+	Item     string   `json:"item" yaml:"item"`
+}
+
+// Check policies decide what a failed partition check does
+const (
+	CheckPolicyAbort    = "abort"    // stop and roll back the split
+	CheckPolicyContinue = "continue" // mark the partition and push it anyway
+)
+
+// Binary file policies decide which partition a changed binary file joins
+const (
+	BinaryFilesDirectory = "directory" // the partition holding most changed files of its directory
+	BinaryFilesSeparate  = "separate"  // a partition of binary files, ahead of the code
+)
+
+// Apply modes control how partition file changes are written onto a branch
+const (
+	ApplyModeCheckout = "checkout" // copy the final file state from the source branch
+	ApplyModePatch    = "patch"    // apply only the source-vs-merge-base diff
+)
+
+// Topologies control which branch each partition branch is created from
+const (
+	TopologyLinear      = "linear"      // stack every partition on the previous one
+	TopologyIndependent = "independent" // base every partition on the target
+	TopologyDAG         = "dag"         // base on the partitions it depends on, merging when there are several
+)
+
+// Strategies control how files within a dependency level are grouped
+const (
+	StrategyDependencyFirst = "dependency-first" // group by dependency depth only
+	StrategyOwnership       = "ownership"        // group by predominant recent author or team
+	StrategySemantic        = "semantic"         // cluster by similarity of file paths and contents
+	StrategyMinCut          = "min-cut"          // minimize dependency edges crossing partitions
+	StrategyDirectory       = "directory"        // group by top-level directory, dependencies only order partitions
+	StrategyWorkspace       = "workspace"        // one partition per monorepo package, ordered by the package graph
+)
+
 // StronglyConnectedComponent represents a group of files with circular dependencies
 type StronglyConnectedComponent struct {
 	Files []string `json:"files"`
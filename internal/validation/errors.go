@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"errors"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// ErrValidationFailed matches every ValidationFailedError with errors.Is
+var ErrValidationFailed = errors.New("validation failed")
+
+// ValidationFailedError is returned when the partition plan or the created branches fail
+// validation. Results holds every check of that phase, including the ones that passed.
+type ValidationFailedError struct {
+	Phase   string // "partition plan" or "branch"
+	Results []types.ValidationResult
+}
+
+func (e *ValidationFailedError) Error() string {
+	return e.Phase + " validation failed"
+}
+
+// Is makes errors.Is(err, ErrValidationFailed) report true
+func (e *ValidationFailedError) Is(target error) bool {
+	return target == ErrValidationFailed
+}
@@ -0,0 +1,128 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// Report collects the validation results of a split run for tickets and CI
+type Report struct {
+	RunID        string        `json:"runId"`
+	SourceBranch string        `json:"sourceBranch"`
+	TargetBranch string        `json:"targetBranch"`
+	GeneratedAt  time.Time     `json:"generatedAt"`
+	Passed       bool          `json:"passed"` // No check of any phase failed
+	Phases       []ReportPhase `json:"phases"`
+}
+
+// ReportPhase holds the results of one validation phase, e.g. "Pre-execution"
+type ReportPhase struct {
+	Name    string                   `json:"name"`
+	Results []types.ValidationResult `json:"results"`
+}
+
+// NewReport starts an empty report for a run
+func NewReport(runID, sourceBranch, targetBranch string) *Report {
+	return &Report{RunID: runID, SourceBranch: sourceBranch, TargetBranch: targetBranch, Passed: true}
+}
+
+// AddPhase adds the results of a validation phase
+func (r *Report) AddPhase(name string, results []types.ValidationResult) {
+	r.Phases = append(r.Phases, ReportPhase{Name: name, Results: results})
+	for _, result := range results {
+		if result.Status == types.ValidationStatusFail {
+			r.Passed = false
+		}
+	}
+}
+
+// WriteReport writes the report as Markdown when the file ends in .md, as JSON otherwise
+func WriteReport(report *Report, filePath string) error {
+	report.GeneratedAt = time.Now().UTC()
+
+	var data []byte
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".md", ".markdown":
+		data = []byte(report.Markdown())
+	default:
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode validation report: %w", err)
+		}
+		data = append(encoded, '\n')
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write validation report: %w", err)
+	}
+	return nil
+}
+
+// Markdown renders the report with a table per phase followed by the details of each check
+func (r *Report) Markdown() string {
+	var b strings.Builder
+
+	outcome := "✅ Passed"
+	if !r.Passed {
+		outcome = "❌ Failed"
+	}
+	fmt.Fprintf(&b, "# Validation report: %s → %s\n\n", r.SourceBranch, r.TargetBranch)
+	fmt.Fprintf(&b, "- Run: `%s`\n", r.RunID)
+	fmt.Fprintf(&b, "- Generated: %s\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Outcome: %s\n", outcome)
+
+	for _, phase := range r.Phases {
+		fmt.Fprintf(&b, "\n## %s\n\n", phase.Name)
+		b.WriteString("| Status | Check | Message |\n")
+		b.WriteString("|--------|-------|---------|\n")
+		for _, result := range phase.Results {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", result.Status, result.Type, markdownCell(result.Message))
+		}
+
+		for _, result := range phase.Results {
+			details := markdownDetails(result.Details)
+			if details == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "\n### %s %s: %s\n\n", result.Status, result.Type, markdownCell(result.Message))
+			b.WriteString(details)
+		}
+	}
+	return b.String()
+}
+
+// markdownDetails lists string details as bullets and shows anything else as JSON; it returns
+// an empty string when there are no details
+func markdownDetails(details interface{}) string {
+	if details == nil {
+		return ""
+	}
+	if lines, ok := details.([]string); ok {
+		var b strings.Builder
+		for _, line := range lines {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+		return b.String()
+	}
+
+	encoded, err := json.MarshalIndent(details, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v\n", details)
+	}
+	if string(encoded) == "null" {
+		return ""
+	}
+	return "```json\n" + string(encoded) + "\n```\n"
+}
+
+// markdownCell keeps a message on one table row
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.ReplaceAll(text, "\n", " ")
+}
@@ -0,0 +1,187 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"pr-splitter-cli/internal/types"
+)
+
+// TypeCheckFailure describes the first chain state that failed to compile
+type TypeCheckFailure struct {
+	Branch string `json:"branch"`
+	Index  int    `json:"index"` // 1-based position of the branch in the chain
+	Output string `json:"output"`
+}
+
+// validateTypeScriptChain type-checks each intermediate chain state (base + partitions 1..N)
+// in a temporary worktree and reports the first partition at which compilation breaks. Linear
+// chains stack, so each state adds one partition to the one before; independent and DAG
+// branches are checked as their tips are, without their siblings' changes.
+func (v *Validator) validateTypeScriptChain(ctx context.Context, branchNames []string, originalChanges []types.FileChange, baseRef, topology string) types.ValidationResult {
+	if !hasTypeScriptChanges(originalChanges) {
+		return types.ValidationResult{
+			Type:    types.ValidationTypeCheck,
+			Status:  types.ValidationStatusPass,
+			Message: "Type-check validation skipped: no TypeScript files changed",
+		}
+	}
+
+	repoRoot, err := runCommand(ctx, v.workingDir, "git", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return v.typeCheckSkipped(fmt.Sprintf("could not determine repository root: %v", err))
+	}
+
+	if _, err := os.Stat(filepath.Join(repoRoot, "tsconfig.json")); os.IsNotExist(err) {
+		return v.typeCheckSkipped("no tsconfig.json found at repository root")
+	}
+
+	if _, err := exec.LookPath("npx"); err != nil {
+		return v.typeCheckSkipped("npx is not installed")
+	}
+
+	workDir, err := os.MkdirTemp("", "pr-split-typecheck-")
+	if err != nil {
+		return v.typeCheckSkipped(fmt.Sprintf("failed to create temp directory: %v", err))
+	}
+	defer os.RemoveAll(workDir)
+
+	worktree := filepath.Join(workDir, "worktree")
+	if _, err := runCommand(ctx, repoRoot, "git", "worktree", "add", "--detach", worktree, baseRef); err != nil {
+		return v.typeCheckSkipped(fmt.Sprintf("failed to create worktree: %v", err))
+	}
+	// Cleanup runs even when the type-check was cancelled
+	defer runCommand(context.WithoutCancel(ctx), repoRoot, "git", "worktree", "remove", "--force", worktree)
+
+	// Reuse installed dependencies from the main checkout
+	nodeModules := filepath.Join(repoRoot, "node_modules")
+	if _, err := os.Stat(nodeModules); err == nil {
+		if err := os.Symlink(nodeModules, filepath.Join(worktree, "node_modules")); err != nil {
+			return v.typeCheckSkipped(fmt.Sprintf("could not link node_modules into the worktree: %v", err))
+		}
+	}
+
+	// Without tsc every state would fail, which says nothing about the base or the partitions
+	if _, err := runCommand(ctx, worktree, "npx", "--no-install", "tsc", "--version"); err != nil {
+		return v.typeCheckSkipped("tsc is not installed")
+	}
+	fmt.Fprintf(v.out, "🧪 Type-checking %d chain states...\n", len(branchNames)+1)
+
+	// A single tsbuildinfo file is shared across states so each check is incremental
+	buildInfo := filepath.Join(workDir, "tsconfig.tsbuildinfo")
+
+	if output, err := runTypeCheck(ctx, worktree, buildInfo); err != nil {
+		return types.ValidationResult{
+			Type:    types.ValidationTypeCheck,
+			Status:  types.ValidationStatusWarn,
+			Message: fmt.Sprintf("Type-check warning: base %s does not compile on its own", baseRef),
+			Details: TypeCheckFailure{Branch: baseRef, Index: 0, Output: output},
+		}
+	}
+
+	stacked := topology == "" || topology == types.TopologyLinear
+	for i, branch := range branchNames {
+		var err error
+		if stacked {
+			err = applyBranchState(ctx, worktree, baseRef, branch)
+		} else {
+			err = checkoutBranchState(ctx, worktree, branch)
+		}
+		if err != nil {
+			return v.typeCheckSkipped(fmt.Sprintf("failed to apply branch %s: %v", branch, err))
+		}
+
+		fmt.Fprintf(v.out, "   [%d/%d] %s\n", i+1, len(branchNames), branch)
+		if output, err := runTypeCheck(ctx, worktree, buildInfo); err != nil {
+			return types.ValidationResult{
+				Type:    types.ValidationTypeCheck,
+				Status:  types.ValidationStatusFail,
+				Message: fmt.Sprintf("Type-check validation failed: compilation breaks at partition %d (%s)", i+1, branch),
+				Details: TypeCheckFailure{Branch: branch, Index: i + 1, Output: output},
+			}
+		}
+	}
+
+	return types.ValidationResult{
+		Type:    types.ValidationTypeCheck,
+		Status:  types.ValidationStatusPass,
+		Message: fmt.Sprintf("Type-check validation passed: all %d chain states compile", len(branchNames)+1),
+	}
+}
+
+// typeCheckSkipped builds a warning result for when type-checking cannot run
+func (v *Validator) typeCheckSkipped(reason string) types.ValidationResult {
+	return types.ValidationResult{
+		Type:    types.ValidationTypeCheck,
+		Status:  types.ValidationStatusWarn,
+		Message: fmt.Sprintf("Type-check validation skipped: %s", reason),
+	}
+}
+
+// applyBranchState layers the files a branch changed relative to the base onto the worktree
+func applyBranchState(ctx context.Context, worktree, baseRef, branch string) error {
+	output, err := runCommand(ctx, worktree, "git", "diff", "--name-status", "--no-renames", baseRef, branch)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		status, path := parts[0], parts[1]
+		if status == "D" {
+			if _, err := runCommand(ctx, worktree, "git", "rm", "-q", "--ignore-unmatch", "--", path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			continue
+		}
+
+		if _, err := runCommand(ctx, worktree, "git", "checkout", branch, "--", path); err != nil {
+			return fmt.Errorf("failed to checkout %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// checkoutBranchState replaces the worktree with the tip of a branch, which for independent
+// and DAG plans holds the branch's own changes on top of its base
+func checkoutBranchState(ctx context.Context, worktree, branch string) error {
+	_, err := runCommand(ctx, worktree, "git", "checkout", "-q", "-f", "--detach", branch)
+	return err
+}
+
+// runTypeCheck runs tsc in no-emit incremental mode and returns its output
+func runTypeCheck(ctx context.Context, dir, buildInfo string) (string, error) {
+	return runCommand(ctx, dir, "npx", "--no-install", "tsc", "--noEmit", "--incremental",
+		"--tsBuildInfoFile", buildInfo, "-p", "tsconfig.json")
+}
+
+// hasTypeScriptChanges checks if any changed file is a TypeScript source
+func hasTypeScriptChanges(changes []types.FileChange) bool {
+	for _, change := range changes {
+		if !change.IsChanged {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(change.Path))
+		if ext == ".ts" || ext == ".tsx" {
+			return true
+		}
+	}
+	return false
+}
+
+// runCommand executes a command in dir and returns its combined output
+func runCommand(ctx context.Context, dir, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
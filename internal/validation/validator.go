@@ -1,28 +1,74 @@
 package validation
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
 
+	"pr-splitter-cli/internal/git"
 	"pr-splitter-cli/internal/types"
 )
 
 // Validator performs pre-execution and post-creation validation
 type Validator struct {
-	workingDir string
+	workingDir string    // Checkout whose branches are validated, the process working directory when empty
+	remote     string    // Remote the branches are expected on
+	localOnly  bool      // Branches are not pushed, so none are expected on the remote
+	out        io.Writer // Progress output
+
+	creationResults []types.ValidationResult // Checks made while the branches were created, e.g. partition checks
+	strict          bool                     // Warnings fail validation
+	severities      types.Severities         // Status of non-passing results by type, overriding strict
 }
 
 // NewValidator creates a new validator instance
 func NewValidator() *Validator {
-	return &Validator{}
+	return &Validator{out: os.Stdout, remote: "origin"}
+}
+
+// SetOutput sends progress output to w instead of stdout
+func (v *Validator) SetOutput(w io.Writer) {
+	v.out = w
+}
+
+// SetWorkingDir validates the checkout at dir instead of the process working directory
+func (v *Validator) SetWorkingDir(dir string) {
+	v.workingDir = dir
+}
+
+// SetRemote expects pushed branches on the named remote instead of origin
+func (v *Validator) SetRemote(name string) {
+	if name != "" {
+		v.remote = name
+	}
+}
+
+// SetLocalOnly stops expecting created branches on the remote, for splits that do not push
+func (v *Validator) SetLocalOnly(localOnly bool) {
+	v.localOnly = localOnly
+}
+
+// SetCreationResults makes ValidateBranches report checks made while the branches were created,
+// such as partition checks and predicted conflicts, with its own
+func (v *Validator) SetCreationResults(results []types.ValidationResult) {
+	v.creationResults = results
+}
+
+// SetSeverity makes warnings fail validation when strict, and overrides the status of
+// non-passing results of the given types either way
+func (v *Validator) SetSeverity(strict bool, severities types.Severities) {
+	v.strict = strict
+	v.severities = severities
 }
 
 // ValidatePlan performs pre-execution validation of the partition plan
 func (v *Validator) ValidatePlan(plan *types.PartitionPlan, originalChanges []types.FileChange) ([]types.ValidationResult, error) {
 	var results []types.ValidationResult
 
-	fmt.Println("🔍 Pre-execution validation:")
+	fmt.Fprintln(v.out, "🔍 Pre-execution validation:")
 
 	// Structural validation
 	structuralResult := v.validateStructural(plan, originalChanges)
@@ -40,43 +86,97 @@ func (v *Validator) ValidatePlan(plan *types.PartitionPlan, originalChanges []ty
 	coverageResult := v.validateCoverage(plan, originalChanges)
 	results = append(results, coverageResult)
 
+	results = v.applySeverity(results)
+
 	// Display results
 	v.displayValidationSummary(results, "Pre-execution")
 
 	return results, nil
 }
 
-// ValidateBranches performs post-creation validation of created branches
-func (v *Validator) ValidateBranches(branchNames []string, originalChanges []types.FileChange, sourceBranch, targetBranch string) ([]types.ValidationResult, error) {
+// ValidateBranches performs post-creation validation of created branches, based on each other
+// as topology says
+func (v *Validator) ValidateBranches(ctx context.Context, branchNames []string, originalChanges []types.FileChange, sourceBranch, baseRef, topology string) ([]types.ValidationResult, error) {
 	var results []types.ValidationResult
 
-	fmt.Println("🔍 Post-creation validation:")
+	fmt.Fprintln(v.out, "🔍 Post-creation validation:")
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("post-creation validation cancelled: %w", err)
+	}
 
 	// Git integrity validation
-	gitResult := v.validateGitIntegrity(branchNames)
+	gitResult := v.validateGitIntegrity(ctx, branchNames)
 	results = append(results, gitResult)
 
 	// Branch existence validation
-	branchResult := v.validateBranchExistence(branchNames)
+	branchResult := v.validateBranchExistence(ctx, branchNames)
 	results = append(results, branchResult)
 
 	// Diff comparison validation
-	diffResult, err := v.validateDiffComparison(branchNames, originalChanges, sourceBranch, targetBranch)
+	diffResult, err := v.validateDiffComparison(branchNames, originalChanges, sourceBranch, baseRef)
 	if err != nil {
 		return results, fmt.Errorf("diff comparison validation failed: %w", err)
 	}
 	results = append(results, diffResult)
 
 	// File operation validation
-	fileOpResult := v.validateFileOperations(branchNames, originalChanges)
+	fileOpResult := v.validateFileOperations(ctx, branchNames, originalChanges, sourceBranch)
 	results = append(results, fileOpResult)
 
+	// File mode validation (executable bits and symlinks)
+	results = append(results, v.validateFileModes(ctx, branchNames, originalChanges))
+
+	// Binary files must match the source byte for byte
+	results = append(results, v.validateBinaryFiles(ctx, branchNames, originalChanges, sourceBranch))
+
+	// Submodules must point at the source commits
+	results = append(results, v.validateSubmodules(ctx, branchNames, originalChanges, sourceBranch))
+
+	// Type-check validation of each intermediate chain state
+	typeCheckResult := v.validateTypeScriptChain(ctx, branchNames, originalChanges, baseRef, topology)
+	results = append(results, typeCheckResult)
+
+	results = append(results, v.creationResults...)
+	results = v.applySeverity(results)
+
 	// Display results
 	v.displayValidationSummary(results, "Post-creation")
 
 	return results, nil
 }
 
+// RevalidateBranches re-runs the git integrity, branch existence, diff comparison, file operation,
+// file mode, binary file and submodule checks on branches created by an earlier split, e.g. after
+// they were rebased by hand
+func (v *Validator) RevalidateBranches(ctx context.Context, branchNames []string, originalChanges []types.FileChange, sourceBranch, baseRef string) ([]types.ValidationResult, error) {
+	var results []types.ValidationResult
+
+	fmt.Fprintln(v.out, "🔍 Branch validation:")
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("branch validation cancelled: %w", err)
+	}
+
+	results = append(results, v.validateGitIntegrity(ctx, branchNames))
+	results = append(results, v.validateBranchExistence(ctx, branchNames))
+
+	diffResult, err := v.validateDiffComparison(branchNames, originalChanges, sourceBranch, baseRef)
+	if err != nil {
+		return results, fmt.Errorf("diff comparison validation failed: %w", err)
+	}
+	results = append(results, diffResult)
+	results = append(results, v.validateFileOperations(ctx, branchNames, originalChanges, sourceBranch))
+	results = append(results, v.validateFileModes(ctx, branchNames, originalChanges))
+	results = append(results, v.validateBinaryFiles(ctx, branchNames, originalChanges, sourceBranch))
+	results = append(results, v.validateSubmodules(ctx, branchNames, originalChanges, sourceBranch))
+
+	results = v.applySeverity(results)
+	v.displayValidationSummary(results, "Branch")
+
+	return results, nil
+}
+
 // validateStructural checks basic structural correctness of the plan
 func (v *Validator) validateStructural(plan *types.PartitionPlan, originalChanges []types.FileChange) types.ValidationResult {
 	var issues []string
@@ -316,30 +416,22 @@ func (v *Validator) validateCoverage(plan *types.PartitionPlan, originalChanges
 }
 
 // validateGitIntegrity checks basic git repository state
-func (v *Validator) validateGitIntegrity(branchNames []string) types.ValidationResult {
+func (v *Validator) validateGitIntegrity(ctx context.Context, branchNames []string) types.ValidationResult {
 	var issues []string
 
 	// Check if we're in a git repository
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
-	if err := cmd.Run(); err != nil {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--git-dir")
+	cmd.Dir = v.workingDir
+	if err := git.Run(cmd); err != nil {
 		issues = append(issues, "Not in a git repository")
 	}
 
-	// Check if working directory is clean (ignoring untracked files)
-	cmd = exec.Command("git", "diff", "--quiet")
-	if err := cmd.Run(); err != nil {
-		issues = append(issues, "Working directory has uncommitted changes")
-	}
-
-	// Check if there are staged changes
-	cmd = exec.Command("git", "diff", "--cached", "--quiet")
-	if err := cmd.Run(); err != nil {
-		issues = append(issues, "Working directory has staged changes")
-	}
+	// Local changes in the checkout are the user's own: branches are built in a temporary
+	// worktree, so they are neither caused by nor part of the split
 
 	// Determine result
 	status := types.ValidationStatusPass
-	message := "Git integrity validation passed: repository is in clean state"
+	message := "Git integrity validation passed: repository is accessible"
 
 	if len(issues) > 0 {
 		status = types.ValidationStatusWarn // Warnings rather than failures
@@ -355,21 +447,26 @@ func (v *Validator) validateGitIntegrity(branchNames []string) types.ValidationR
 }
 
 // validateBranchExistence checks that all expected branches were created
-func (v *Validator) validateBranchExistence(branchNames []string) types.ValidationResult {
+func (v *Validator) validateBranchExistence(ctx context.Context, branchNames []string) types.ValidationResult {
 	var issues []string
 
 	for _, branchName := range branchNames {
-		cmd := exec.Command("git", "rev-parse", "--verify", branchName)
-		if err := cmd.Run(); err != nil {
+		cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", branchName)
+		cmd.Dir = v.workingDir
+		if err := git.Run(cmd); err != nil {
 			issues = append(issues, fmt.Sprintf("Branch not found: %s", branchName))
 		}
 	}
 
-	// Check if branches were pushed to remote
+	// Check if branches were pushed to remote, unless the split keeps them local
 	var unpushedBranches []string
 	for _, branchName := range branchNames {
-		cmd := exec.Command("git", "rev-parse", "--verify", fmt.Sprintf("origin/%s", branchName))
-		if err := cmd.Run(); err != nil {
+		if v.localOnly {
+			break
+		}
+		cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", fmt.Sprintf("%s/%s", v.remote, branchName))
+		cmd.Dir = v.workingDir
+		if err := git.Run(cmd); err != nil {
 			unpushedBranches = append(unpushedBranches, branchName)
 		}
 	}
@@ -434,35 +531,342 @@ func (v *Validator) validateDiffComparison(branchNames []string, originalChanges
 	}, nil
 }
 
-// validateFileOperations checks that file operations were applied correctly
-func (v *Validator) validateFileOperations(branchNames []string, originalChanges []types.FileChange) types.ValidationResult {
+// validateFileOperations counts the file operations and checks that every rename was applied as
+// one: on at least one partition branch the old path is gone and the new path holds the source
+// content, including any edits made along with the rename
+func (v *Validator) validateFileOperations(ctx context.Context, branchNames []string, originalChanges []types.FileChange, sourceBranch string) types.ValidationResult {
 
 	// Count operations by type
 	opCounts := make(map[types.ChangeType]int)
+	var renames []types.FileChange
 	for _, change := range originalChanges {
 		if change.IsChanged {
 			opCounts[change.ChangeType]++
+			if change.ChangeType == types.ChangeTypeRename && change.OldPath != "" {
+				renames = append(renames, change)
+			}
 		}
 	}
 
-	// For now, just report the operation summary
-	// Future enhancement: verify each operation was applied correctly
-
-	status := types.ValidationStatusPass
-	message := fmt.Sprintf("File operations validation passed: %d ADD, %d MODIFY, %d DELETE, %d RENAME",
+	summary := fmt.Sprintf("%d ADD, %d MODIFY, %d DELETE, %d RENAME",
 		opCounts[types.ChangeTypeAdd],
 		opCounts[types.ChangeTypeModify],
 		opCounts[types.ChangeTypeDelete],
 		opCounts[types.ChangeTypeRename])
 
+	issues, err := v.unappliedRenames(ctx, branchNames, renames, sourceBranch)
+	if err != nil {
+		return types.ValidationResult{
+			Type:    types.ValidationGitIntegrity,
+			Status:  types.ValidationStatusWarn,
+			Message: fmt.Sprintf("File operations validation warning: %s; renames not checked: %v", summary, err),
+			Details: opCounts,
+		}
+	}
+	if len(issues) > 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationGitIntegrity,
+			Status:  types.ValidationStatusFail,
+			Message: fmt.Sprintf("File operations validation failed: %s", strings.Join(issues, "; ")),
+			Details: issues,
+		}
+	}
+
 	return types.ValidationResult{
 		Type:    types.ValidationGitIntegrity,
-		Status:  status,
-		Message: message,
+		Status:  types.ValidationStatusPass,
+		Message: fmt.Sprintf("File operations validation passed: %s", summary),
 		Details: opCounts,
 	}
 }
 
+// unappliedRenames describes each rename that no partition branch shows: the new path with its
+// source content and the old path gone, unless the source branch has a file there again
+func (v *Validator) unappliedRenames(ctx context.Context, branchNames []string, renames []types.FileChange, sourceBranch string) ([]string, error) {
+	if len(renames) == 0 {
+		return nil, nil
+	}
+	var paths []string
+	for _, rename := range renames {
+		paths = append(paths, rename.OldPath, rename.Path)
+	}
+
+	expected, err := v.treeEntries(ctx, sourceBranch, paths)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", sourceBranch, err)
+	}
+
+	applied := make(map[string]bool)
+	for _, branch := range branchNames {
+		entries, err := v.treeEntries(ctx, branch, paths)
+		if err != nil {
+			continue // Missing branches are reported by branch validation
+		}
+		for _, rename := range renames {
+			_, oldKept := entries[rename.OldPath]
+			_, oldExpected := expected[rename.OldPath]
+			if entries[rename.Path].object == expected[rename.Path].object && (!oldKept || oldExpected) {
+				applied[rename.Path] = true
+			}
+		}
+	}
+
+	var issues []string
+	for _, rename := range renames {
+		if !applied[rename.Path] {
+			issues = append(issues, fmt.Sprintf("rename of %s to %s is not applied on any partition branch", rename.OldPath, rename.Path))
+		}
+	}
+	return issues, nil
+}
+
+// validateFileModes checks that every changed file with a mode change, an executable bit or a
+// symlink has its source mode on at least one partition branch
+func (v *Validator) validateFileModes(ctx context.Context, branchNames []string, originalChanges []types.FileChange) types.ValidationResult {
+	expected := make(map[string]string)
+	var paths []string
+	for _, change := range originalChanges {
+		if !change.IsChanged || change.Mode == "" || change.ChangeType == types.ChangeTypeDelete {
+			continue
+		}
+		if change.ModeChanged() || change.Mode != types.FileModeRegular {
+			expected[change.Path] = change.Mode
+			paths = append(paths, change.Path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationGitIntegrity,
+			Status:  types.ValidationStatusPass,
+			Message: "File mode validation passed: no mode changes, executables or symlinks",
+		}
+	}
+
+	// Mode of each path on each branch that has it
+	found := make(map[string][]string)
+	for _, branch := range branchNames {
+		entries, err := v.treeEntries(ctx, branch, paths)
+		if err != nil {
+			continue // Missing branches are reported by branch validation
+		}
+		for path, entry := range entries {
+			found[path] = append(found[path], entry.mode)
+		}
+	}
+
+	var issues []string
+	for _, path := range paths {
+		modes := found[path]
+		if len(modes) == 0 || containsMode(modes, expected[path]) {
+			continue
+		}
+		issues = append(issues, fmt.Sprintf("%s has mode %s on the partition branches, expected %s", path, modes[len(modes)-1], expected[path]))
+	}
+
+	if len(issues) > 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationGitIntegrity,
+			Status:  types.ValidationStatusFail,
+			Message: fmt.Sprintf("File mode validation failed: %s", strings.Join(issues, "; ")),
+			Details: issues,
+		}
+	}
+	return types.ValidationResult{
+		Type:    types.ValidationGitIntegrity,
+		Status:  types.ValidationStatusPass,
+		Message: fmt.Sprintf("File mode validation passed: %d mode changes, executables and symlinks applied", len(paths)),
+	}
+}
+
+// validateBinaryFiles checks that every added or modified binary file is, on at least one
+// partition branch, the same blob as on the source branch, so it was applied byte for byte
+func (v *Validator) validateBinaryFiles(ctx context.Context, branchNames []string, originalChanges []types.FileChange, sourceBranch string) types.ValidationResult {
+	var paths []string
+	for _, change := range originalChanges {
+		if change.IsChanged && change.IsBinary && change.ChangeType != types.ChangeTypeDelete {
+			paths = append(paths, change.Path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationBinaryFiles,
+			Status:  types.ValidationStatusPass,
+			Message: "Binary file validation passed: no binary files changed",
+		}
+	}
+
+	issues, err := v.unmatchedPaths(ctx, branchNames, paths, sourceBranch)
+	if err != nil {
+		return types.ValidationResult{
+			Type:    types.ValidationBinaryFiles,
+			Status:  types.ValidationStatusWarn,
+			Message: fmt.Sprintf("Binary file validation skipped: %v", err),
+		}
+	}
+
+	if len(issues) > 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationBinaryFiles,
+			Status:  types.ValidationStatusFail,
+			Message: fmt.Sprintf("Binary file validation failed: %s", strings.Join(issues, "; ")),
+			Details: issues,
+		}
+	}
+	return types.ValidationResult{
+		Type:    types.ValidationBinaryFiles,
+		Status:  types.ValidationStatusPass,
+		Message: fmt.Sprintf("Binary file validation passed: %d binary files identical to %s", len(paths), sourceBranch),
+	}
+}
+
+// validateSubmodules checks that every added or updated submodule points, on at least one
+// partition branch, at the same commit as on the source branch
+func (v *Validator) validateSubmodules(ctx context.Context, branchNames []string, originalChanges []types.FileChange, sourceBranch string) types.ValidationResult {
+	var paths []string
+	for _, change := range originalChanges {
+		if change.IsChanged && change.IsSubmodule && change.ChangeType != types.ChangeTypeDelete {
+			paths = append(paths, change.Path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationGitIntegrity,
+			Status:  types.ValidationStatusPass,
+			Message: "Submodule validation passed: no submodules changed",
+		}
+	}
+
+	issues, err := v.unmatchedPaths(ctx, branchNames, paths, sourceBranch)
+	if err != nil {
+		return types.ValidationResult{
+			Type:    types.ValidationGitIntegrity,
+			Status:  types.ValidationStatusWarn,
+			Message: fmt.Sprintf("Submodule validation skipped: %v", err),
+		}
+	}
+
+	if len(issues) > 0 {
+		return types.ValidationResult{
+			Type:    types.ValidationGitIntegrity,
+			Status:  types.ValidationStatusFail,
+			Message: fmt.Sprintf("Submodule validation failed: %s", strings.Join(issues, "; ")),
+			Details: issues,
+		}
+	}
+	return types.ValidationResult{
+		Type:    types.ValidationGitIntegrity,
+		Status:  types.ValidationStatusPass,
+		Message: fmt.Sprintf("Submodule validation passed: %d submodules point at the same commits as %s", len(paths), sourceBranch),
+	}
+}
+
+// unmatchedPaths describes each path that no partition branch holds with the same object (blob
+// or submodule commit) as the source branch
+func (v *Validator) unmatchedPaths(ctx context.Context, branchNames, paths []string, sourceBranch string) ([]string, error) {
+	expected, err := v.treeEntries(ctx, sourceBranch, paths)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", sourceBranch, err)
+	}
+
+	applied := make(map[string]bool)
+	for _, branch := range branchNames {
+		entries, err := v.treeEntries(ctx, branch, paths)
+		if err != nil {
+			continue // Missing branches are reported by branch validation
+		}
+		for path, entry := range entries {
+			if entry.object == expected[path].object {
+				applied[path] = true
+			}
+		}
+	}
+
+	var issues []string
+	for _, path := range paths {
+		if !applied[path] {
+			issues = append(issues, fmt.Sprintf("%s differs from %s on every partition branch", path, sourceBranch))
+		}
+	}
+	return issues, nil
+}
+
+// treeEntry is the mode and object of a path in a tree
+type treeEntry struct {
+	mode   string
+	object string
+}
+
+// treeEntries lists the given paths in the tree of rev
+func (v *Validator) treeEntries(ctx context.Context, rev string, paths []string) (map[string]treeEntry, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"ls-tree", "-r", "--full-tree", rev, "--"}, paths...)...)
+	cmd.Dir = v.workingDir
+	output, err := git.Output(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]treeEntry)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		meta, path, ok := strings.Cut(line, "\t")
+		if fields := strings.Fields(meta); ok && len(fields) == 3 {
+			entries[path] = treeEntry{mode: fields[0], object: fields[2]}
+		}
+	}
+	return entries, nil
+}
+
+// containsMode reports whether modes includes mode
+func containsMode(modes []string, mode string) bool {
+	for _, m := range modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// applySeverity promotes warnings to failures in strict mode and applies the per-type
+// overrides, noting the change in the message
+func (v *Validator) applySeverity(results []types.ValidationResult) []types.ValidationResult {
+	for i, result := range results {
+		if result.Status == types.ValidationStatusPass {
+			continue
+		}
+		status, ok := v.severities[result.Type]
+		if !ok {
+			if !v.strict || result.Status != types.ValidationStatusWarn {
+				continue
+			}
+			status = types.ValidationStatusFail
+		}
+		if status == result.Status {
+			continue
+		}
+		change := "raised"
+		if severityRank(status) < severityRank(result.Status) {
+			change = "lowered"
+		}
+		results[i].Message = fmt.Sprintf("%s (%s %s to %s)", result.Message, result.Status, change, status)
+		results[i].Status = status
+	}
+	return results
+}
+
+// severityRank orders validation statuses from passing to failing
+func severityRank(status types.ValidationStatus) int {
+	switch status {
+	case types.ValidationStatusFail:
+		return 2
+	case types.ValidationStatusWarn:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // AllPassed checks if all validation results passed (no failures)
 func (v *Validator) AllPassed(results []types.ValidationResult) bool {
 	for _, result := range results {
@@ -475,8 +879,8 @@ func (v *Validator) AllPassed(results []types.ValidationResult) bool {
 
 // displayValidationSummary shows validation results to the user
 func (v *Validator) displayValidationSummary(results []types.ValidationResult, phase string) {
-	fmt.Printf("\n📋 %s Validation Results:\n", phase)
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(v.out, "\n📋 %s Validation Results:\n", phase)
+	fmt.Fprintln(v.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	passCount := 0
 	warnCount := 0
@@ -496,18 +900,18 @@ func (v *Validator) displayValidationSummary(results []types.ValidationResult, p
 			failCount++
 		}
 
-		fmt.Printf("%s %s: %s\n", status, result.Type, result.Message)
+		fmt.Fprintf(v.out, "%s %s: %s\n", status, result.Type, result.Message)
 	}
 
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("Summary: %d passed, %d warnings, %d failures\n", passCount, warnCount, failCount)
+	fmt.Fprintln(v.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(v.out, "Summary: %d passed, %d warnings, %d failures\n", passCount, warnCount, failCount)
 
 	if failCount > 0 {
-		fmt.Println("❌ Validation failed - please address issues before proceeding")
+		fmt.Fprintln(v.out, "❌ Validation failed - please address issues before proceeding")
 	} else if warnCount > 0 {
-		fmt.Println("⚠️  Validation passed with warnings")
+		fmt.Fprintln(v.out, "⚠️  Validation passed with warnings")
 	} else {
-		fmt.Println("✅ All validations passed")
+		fmt.Fprintln(v.out, "✅ All validations passed")
 	}
-	fmt.Println()
+	fmt.Fprintln(v.out)
 }
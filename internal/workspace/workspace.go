@@ -0,0 +1,238 @@
+package workspace
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"pr-splitter-cli/internal/pathglob"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Package is a package of a monorepo workspace: a directory with its own manifest
+type Package struct {
+	Name     string   // package.json name or go.mod module path, the directory when unnamed
+	Dir      string   // Directory relative to the repository root
+	Requires []string // Names of the other workspace packages it depends on
+}
+
+// ReadFile returns the content of a repository file
+type ReadFile func(filePath string) (string, error)
+
+// Detect finds the packages declared by npm and yarn workspaces (root package.json), pnpm
+// workspaces (pnpm-workspace.yaml) and Go workspaces (go.work), given every file path in the
+// repository. It returns no packages when the repository declares no workspace.
+func Detect(files []string, read ReadFile) []Package {
+	manifestDirs := make(map[string]map[string]bool) // manifest name -> directories holding one
+	for _, file := range files {
+		base := path.Base(file)
+		if base == "package.json" || base == "go.mod" {
+			if manifestDirs[base] == nil {
+				manifestDirs[base] = make(map[string]bool)
+			}
+			manifestDirs[base][path.Dir(file)] = true
+		}
+	}
+
+	dirs := make(map[string]string) // package directory -> manifest name
+	for _, dir := range matchDirs(nodePatterns(read), manifestDirs["package.json"]) {
+		dirs[dir] = "package.json"
+	}
+	for _, dir := range goWorkDirs(read) {
+		if manifestDirs["go.mod"][dir] {
+			dirs[dir] = "go.mod"
+		}
+	}
+
+	var packages []Package
+	for dir, manifest := range dirs {
+		content, err := read(path.Join(dir, manifest))
+		if err != nil {
+			continue
+		}
+		var pkg Package
+		if manifest == "go.mod" {
+			pkg = parseGoMod(content)
+		} else {
+			pkg = parsePackageJSON(content)
+		}
+		pkg.Dir = dir
+		if pkg.Name == "" {
+			pkg.Name = dir
+		}
+		packages = append(packages, pkg)
+	}
+
+	// Keep only dependencies on packages of the workspace
+	names := make(map[string]bool)
+	for _, pkg := range packages {
+		names[pkg.Name] = true
+	}
+	for i := range packages {
+		var requires []string
+		for _, name := range packages[i].Requires {
+			if names[name] && name != packages[i].Name {
+				requires = append(requires, name)
+			}
+		}
+		sort.Strings(requires)
+		packages[i].Requires = requires
+	}
+
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Dir < packages[j].Dir })
+	return packages
+}
+
+// Owner returns the package whose directory holds filePath most closely, or nil for files
+// outside every package
+func Owner(packages []Package, filePath string) *Package {
+	var owner *Package
+	for i := range packages {
+		dir := packages[i].Dir
+		if dir != "." && !strings.HasPrefix(filePath, dir+"/") {
+			continue
+		}
+		if owner == nil || len(dir) > len(owner.Dir) || owner.Dir == "." {
+			owner = &packages[i]
+		}
+	}
+	return owner
+}
+
+// nodePatterns returns the workspace globs of the root package.json and pnpm-workspace.yaml
+func nodePatterns(read ReadFile) []string {
+	var patterns []string
+
+	if content, err := read("package.json"); err == nil {
+		var manifest struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		if json.Unmarshal([]byte(content), &manifest) == nil && manifest.Workspaces != nil {
+			// An array of globs, or yarn's {"packages": [...]} form
+			var list []string
+			var object struct {
+				Packages []string `json:"packages"`
+			}
+			if json.Unmarshal(manifest.Workspaces, &list) == nil {
+				patterns = append(patterns, list...)
+			} else if json.Unmarshal(manifest.Workspaces, &object) == nil {
+				patterns = append(patterns, object.Packages...)
+			}
+		}
+	}
+
+	if content, err := read("pnpm-workspace.yaml"); err == nil {
+		var manifest struct {
+			Packages []string `yaml:"packages"`
+		}
+		if yaml.Unmarshal([]byte(content), &manifest) == nil {
+			patterns = append(patterns, manifest.Packages...)
+		}
+	}
+	return patterns
+}
+
+// matchDirs returns the directories matching the globs, leaving out those matching a negated
+// "!glob"
+func matchDirs(patterns []string, dirs map[string]bool) []string {
+	var include, exclude []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "./"), "/")
+		if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+			exclude = append(exclude, strings.TrimPrefix(negated, "./"))
+		} else if pattern != "" {
+			include = append(include, pattern)
+		}
+	}
+
+	var matched []string
+	for dir := range dirs {
+		if matchesAny(include, dir) && !matchesAny(exclude, dir) {
+			matched = append(matched, dir)
+		}
+	}
+	return matched
+}
+
+// matchesAny reports whether a directory matches one of the globs
+func matchesAny(globs []string, dir string) bool {
+	for _, glob := range globs {
+		if pathglob.Compile(glob).MatchString(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// goWorkDirs returns the module directories of the use directives in go.work
+func goWorkDirs(read ReadFile) []string {
+	content, err := read("go.work")
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.SplitN(line, "//", 2)[0])
+		switch {
+		case line == "use (":
+			inBlock = true
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "":
+			dirs = append(dirs, path.Clean(strings.Trim(line, `"`)))
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, path.Clean(strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "use ")), `"`)))
+		}
+	}
+	return dirs
+}
+
+// parsePackageJSON reads the name and dependencies of a package.json
+func parsePackageJSON(content string) Package {
+	var manifest struct {
+		Name                 string            `json:"name"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if json.Unmarshal([]byte(content), &manifest) != nil {
+		return Package{}
+	}
+
+	pkg := Package{Name: manifest.Name}
+	for _, deps := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.PeerDependencies, manifest.OptionalDependencies} {
+		for name := range deps {
+			pkg.Requires = append(pkg.Requires, name)
+		}
+	}
+	return pkg
+}
+
+// parseGoMod reads the module path and requirements of a go.mod
+func parseGoMod(content string) Package {
+	var pkg Package
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.SplitN(line, "//", 2)[0])
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case fields[0] == "module" && len(fields) > 1:
+			pkg.Name = strings.Trim(fields[1], `"`)
+		case line == "require (":
+			inBlock = true
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			pkg.Requires = append(pkg.Requires, fields[0])
+		case fields[0] == "require" && len(fields) > 1:
+			pkg.Requires = append(pkg.Requires, fields[1])
+		}
+	}
+	return pkg
+}
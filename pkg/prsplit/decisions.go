@@ -0,0 +1,61 @@
+package prsplit
+
+import (
+	"fmt"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/partition"
+	"pr-splitter-cli/internal/types"
+)
+
+// policyDecisions answers the pipeline's questions from SplitOptions instead of prompting
+type policyDecisions struct {
+	opts         SplitOptions
+	sourceBranch string
+	targetBranch string
+
+	plan     *types.PartitionPlan // The plan offered for approval
+	declined bool                 // Approve rejected the plan
+}
+
+// ApprovePlan asks opts.Approve, approving every plan when it is nil
+func (d *policyDecisions) ApprovePlan(plan *types.PartitionPlan) (bool, error) {
+	d.plan = plan
+	if d.opts.Approve == nil || d.opts.Approve(newPlan(plan, d.sourceBranch, d.targetBranch)) {
+		return true, nil
+	}
+	d.declined = true
+	return false, nil
+}
+
+// ApproveOversizedCycle keeps the group together only when opts.AllowOversizedCycles is set
+func (d *policyDecisions) ApproveOversizedCycle(files []string, size, limit int, suggestions []string) (bool, error) {
+	if d.opts.AllowOversizedCycles {
+		return true, nil
+	}
+	return false, fmt.Errorf("%w: circular dependency group of %d files exceeds the limit of %d (set AllowOversizedCycles to keep it together)", partition.ErrUserAborted, size, limit)
+}
+
+// ResolveTargetDrift applies opts.OnTargetDrift
+func (d *policyDecisions) ResolveTargetDrift(drifted []string, targetBranch string) (config.DriftDecision, error) {
+	switch d.opts.OnTargetDrift {
+	case "", DriftPatch:
+		return config.DriftUsePatchMode, nil
+	case DriftOverwrite:
+		return config.DriftOverwrite, nil
+	case DriftAbort:
+		return config.DriftAbort, nil
+	}
+	return config.DriftAbort, fmt.Errorf("unknown drift policy %q", d.opts.OnTargetDrift)
+}
+
+// ResolveCollision applies opts.OnBranchCollision
+func (d *policyDecisions) ResolveCollision(collisions []string, userSuffix string) (config.CollisionDecision, error) {
+	switch d.opts.OnBranchCollision {
+	case "", CollisionSuffix:
+		return config.CollisionUseSuffix, nil
+	case CollisionAbort:
+		return config.CollisionAbort, nil
+	}
+	return config.CollisionAbort, fmt.Errorf("unknown collision policy %q", d.opts.OnBranchCollision)
+}
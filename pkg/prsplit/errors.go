@@ -0,0 +1,45 @@
+package prsplit
+
+import (
+	"errors"
+	"fmt"
+
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/partition"
+	"pr-splitter-cli/internal/validation"
+)
+
+// Failure causes callers can test for with errors.Is
+var (
+	ErrDirtyWorktree        = git.ErrDirtyWorktree           // The checkout has uncommitted or staged changes
+	ErrBranchExists         = git.ErrBranchExists            // A planned branch already exists, locally or on the remote
+	ErrNoChanges            = git.ErrNoChanges               // The source branch has no changes against the target
+	ErrPartitionCheckFailed = git.ErrPartitionCheckFailed    // A PartitionChecks command failed under CheckPolicyAbort
+	ErrUserAborted          = partition.ErrUserAborted       // A decision in SplitOptions rejected the split
+	ErrValidationFailed     = validation.ErrValidationFailed // The plan or the created branches failed validation; see ValidationError
+)
+
+// ErrNotApproved is returned by Split when SplitOptions.Approve rejects the plan. It
+// matches ErrUserAborted.
+var ErrNotApproved = fmt.Errorf("partition plan not approved: %w", ErrUserAborted)
+
+// ValidationError reports the checks of a failed validation phase. It matches
+// ErrValidationFailed with errors.Is.
+type ValidationError struct {
+	Phase  string  // "partition plan" or "branch"
+	Checks []Check // Every check of the phase, including the ones that passed
+	err    error
+}
+
+func (e *ValidationError) Error() string { return e.err.Error() }
+
+func (e *ValidationError) Unwrap() error { return e.err }
+
+// wrapError converts pipeline errors that carry details into their library form
+func wrapError(err error) error {
+	var failed *validation.ValidationFailedError
+	if errors.As(err, &failed) {
+		return &ValidationError{Phase: failed.Phase, Checks: newChecks(failed.Results), err: err}
+	}
+	return err
+}
@@ -0,0 +1,179 @@
+package prsplit
+
+import (
+	"strings"
+	"time"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/types"
+)
+
+// Strategies control how files within a dependency level are grouped into partitions
+const (
+	StrategyDependencyFirst = types.StrategyDependencyFirst // group by dependency depth only
+	StrategyDirectory       = types.StrategyDirectory       // group by top-level directory
+	StrategyOwnership       = types.StrategyOwnership       // group by predominant recent author or team
+	StrategySemantic        = types.StrategySemantic        // cluster by similarity of paths and contents
+	StrategyMinCut          = types.StrategyMinCut          // minimize dependency edges crossing partitions
+	StrategyWorkspace       = types.StrategyWorkspace       // one partition per monorepo package
+)
+
+// Topologies control which branch each partition branch is created from
+const (
+	TopologyLinear      = types.TopologyLinear      // stack every partition on the previous one
+	TopologyIndependent = types.TopologyIndependent // base every partition on the target
+	TopologyDAG         = types.TopologyDAG         // base on the partitions it depends on
+)
+
+// Apply modes control how partition file changes are written onto a branch
+const (
+	ApplyModeCheckout = types.ApplyModeCheckout // copy the final file state from the source branch
+	ApplyModePatch    = types.ApplyModePatch    // apply only the source-vs-merge-base diff
+)
+
+// Binary file policies decide which partition a changed binary file joins
+const (
+	BinaryFilesDirectory = types.BinaryFilesDirectory // the partition holding most changed files of its directory
+	BinaryFilesSeparate  = types.BinaryFilesSeparate  // a partition of binary files, ahead of the code
+)
+
+// Check policies decide what a failed partition check does
+const (
+	CheckPolicyAbort    = types.CheckPolicyAbort    // stop and roll back the split
+	CheckPolicyContinue = types.CheckPolicyContinue // mark the partition and push it anyway
+)
+
+// DriftPolicy decides what a split does when planned files also changed on the target
+// branch since the merge-base, so copying them from the source would undo target changes
+type DriftPolicy string
+
+const (
+	DriftPatch     DriftPolicy = "patch"     // switch to patch mode and apply only the branch's changes (default)
+	DriftOverwrite DriftPolicy = "overwrite" // keep the configured apply mode
+	DriftAbort     DriftPolicy = "abort"     // fail the split
+)
+
+// CollisionPolicy decides what a split does when planned branch names already exist on the
+// remote from another split
+type CollisionPolicy string
+
+const (
+	CollisionSuffix CollisionPolicy = "suffix" // append the git user's slug to every branch name (default)
+	CollisionAbort  CollisionPolicy = "abort"  // fail the split
+)
+
+// SplitOptions controls how a branch is partitioned and how its branches are created. The
+// zero value splits against "main" with the CLI's defaults.
+type SplitOptions struct {
+	TargetBranch          string   // Branch the partitions merge into, default "main"
+	MaxFilesPerPartition  int      // Default 15
+	MaxPartitions         int      // Default 8
+	MaxLinesPerPartition  int      // Split partitions with more added plus deleted lines, 0 for no limit
+	BranchPrefix          string   // Default "pr-split"
+	BranchTemplate        string   // Default "{prefix}-{id}-{name}"
+	BranchNamespace       string   // Prepended to every branch name, e.g. "split/{user}"
+	Strategy              string   // One of the Strategy constants, default StrategyDependencyFirst
+	Topology              string   // One of the Topology constants, default TopologyLinear
+	ApplyMode             string   // One of the ApplyMode constants, default ApplyModeCheckout
+	IncludePaths          []string // Header search directories for C/C++ analysis
+	SplitHunks            []string // Globs of shared files that may be split across partitions by hunk
+	MinDependencyStrength string   // Ignore dependencies weaker than WEAK, MODERATE, STRONG or CRITICAL
+	GroupByDirectory      bool     // Split large dependency levels by directory instead of truncating them
+	SeparateMechanical    bool     // Put renames, moves and formatting-only changes in their own partitions
+	CoChange              bool     // Group files that historically change together
+	RebasePlan            bool     // Base partitions on the current target tip instead of the merge-base
+	Since                 string   // Only split the files changed by the source branch's commits after this commit
+	CommitPattern         string   // Only split the files changed by commits whose message matches this regular expression
+	UpdateExisting        bool     // Reset branches of an earlier split instead of failing
+	KeepProgress          bool     // On failure keep pushed branches so the run can be resumed
+	Parallelism           int      // Partition branches built and pushed at once, default 4
+	PushRemote            string   // Remote partition branches are pushed to, default "origin"
+	UpstreamRemote        string   // Remote whose target branch partitions are based on, e.g. "upstream" when pushing to a fork
+	PushOptions           []string // Sent with every partition push as --push-option, e.g. "ci.skip"
+	NoPush                bool     // Create and validate branches locally without pushing them
+	NoVerify              bool     // Skip git hooks on the commits and pushes Split makes
+	SignCommits           bool     // Sign partition commits even when commit.gpgSign is off
+	SignOff               bool     // Add the operator's Signed-off-by trailer to partition commits
+	CoAuthorTrailers      bool     // Credit the source commits' authors with Co-authored-by trailers
+	OperatorAuthor        bool     // Author partition commits as the operator instead of the dominant source author
+	ProtectedBranches     []string // Branch names or globs a rollback never deletes, on top of git.DefaultProtectedBranches
+	PartitionChecks       []string // Shell commands run in a worktree of each partition branch before it is pushed
+	CheckPolicy           string   // One of the CheckPolicy constants, default CheckPolicyAbort
+	StrictValidation      bool     // Treat validation warnings as failures
+	BinaryFiles           string   // One of the BinaryFiles constants, default BinaryFilesDirectory
+	ExcludeTests          bool     // Leave changed test files out of the split
+	SkipProjectGraph      bool     // Ignore the Nx or Turborepo project graph of the repository
+	ContextDepth          int      // Levels of imports followed for plugin project context, default 3, negative for every file
+
+	// ContextCacheTTL is how long the project context collected for a source commit is reused
+	// by later splits of the same commit, default 15m; negative always collects it again
+	ContextCacheTTL time.Duration
+
+	// ValidationSeverity maps a validation type to the status its warnings and failures get,
+	// e.g. {"TYPE_CHECK": "FAIL"}, overriding StrictValidation
+	ValidationSeverity map[string]string
+
+	// Approve is called with the plan before any branch is created; returning false makes
+	// Split return ErrNotApproved. Nil approves every plan.
+	Approve func(plan *Plan) bool
+
+	AllowOversizedCycles bool            // Keep circular groups larger than MaxFilesPerPartition together instead of failing
+	OnTargetDrift        DriftPolicy     // Default DriftPatch
+	OnBranchCollision    CollisionPolicy // Default CollisionSuffix
+}
+
+// toConfig converts the options to a pipeline configuration with defaults filled in
+func (o SplitOptions) toConfig() *types.Config {
+	return &types.Config{
+		MaxFilesPerPartition:  orDefault(o.MaxFilesPerPartition, config.ConfigDefaults.MaxFilesPerPartition),
+		MaxPartitions:         orDefault(o.MaxPartitions, config.ConfigDefaults.MaxPartitions),
+		MaxLinesPerPartition:  o.MaxLinesPerPartition,
+		BranchPrefix:          orDefault(o.BranchPrefix, config.ConfigDefaults.BranchPrefix),
+		BranchTemplate:        orDefault(o.BranchTemplate, config.ConfigDefaults.BranchTemplate),
+		BranchNamespace:       o.BranchNamespace,
+		Strategy:              orDefault(o.Strategy, config.ConfigDefaults.Strategy),
+		TargetBranch:          orDefault(o.TargetBranch, config.ConfigDefaults.TargetBranch),
+		Topology:              o.Topology,
+		ApplyMode:             orDefault(o.ApplyMode, config.ConfigDefaults.ApplyMode),
+		IncludePaths:          o.IncludePaths,
+		SplitHunks:            o.SplitHunks,
+		MinDependencyStrength: types.DependencyStrength(strings.ToUpper(o.MinDependencyStrength)),
+		GroupByDirectory:      o.GroupByDirectory,
+		SeparateMechanical:    o.SeparateMechanical,
+		CoChange:              o.CoChange,
+		RebasePlan:            o.RebasePlan,
+		Since:                 o.Since,
+		CommitPattern:         o.CommitPattern,
+		UpdateExisting:        o.UpdateExisting,
+		KeepProgress:          o.KeepProgress,
+		Parallelism:           o.Parallelism,
+		PushRemote:            o.PushRemote,
+		UpstreamRemote:        o.UpstreamRemote,
+		PushOptions:           o.PushOptions,
+		NoPush:                o.NoPush,
+		NoVerify:              o.NoVerify,
+		SignCommits:           o.SignCommits,
+		SignOff:               o.SignOff,
+		CoAuthorTrailers:      o.CoAuthorTrailers,
+		OperatorAuthor:        o.OperatorAuthor,
+		ProtectedBranches:     o.ProtectedBranches,
+		PartitionChecks:       o.PartitionChecks,
+		CheckPolicy:           orDefault(o.CheckPolicy, types.CheckPolicyAbort),
+		StrictValidation:      o.StrictValidation,
+		ValidationSeverity:    config.ParseSeverities(o.ValidationSeverity),
+		BinaryFiles:           o.BinaryFiles,
+		ExcludeTests:          o.ExcludeTests,
+		SkipProjectGraph:      o.SkipProjectGraph,
+		ContextDepth:          o.ContextDepth,
+		ContextCacheTTL:       o.ContextCacheTTL,
+	}
+}
+
+// orDefault returns value unless it is the zero value
+func orDefault[T comparable](value, fallback T) T {
+	var zero T
+	if value == zero {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,142 @@
+// Package prsplit splits a large branch into smaller, dependency-aware partition branches.
+//
+// It is the engine behind the pr-split command, for programs that want to split branches
+// without shelling out: bots, editor integrations, internal services. A Splitter never
+// prompts and never prints; progress goes to an optional writer, and every question the
+// CLI would ask is answered by SplitOptions.
+//
+//	s := prsplit.New(prsplit.Config{Dir: "/path/to/checkout"})
+//
+//	plan, err := s.Plan(ctx, "feature/large-branch", prsplit.SplitOptions{MaxFilesPerPartition: 10})
+//	...
+//	result, err := s.Split(ctx, "feature/large-branch", prsplit.SplitOptions{
+//		MaxFilesPerPartition: 10,
+//		Approve:              func(plan *prsplit.Plan) bool { return len(plan.Partitions) <= 5 },
+//	})
+//
+// Errors can be tested with errors.Is against ErrDirtyWorktree, ErrBranchExists,
+// ErrNoChanges, ErrPartitionCheckFailed, ErrUserAborted and ErrValidationFailed; errors.As
+// with *ValidationError gives the failed checks.
+//
+// Splits are recorded under .git/pr-split like CLI runs, so 'pr-split status', 'resume'
+// and 'undo' work on them. Cancelling ctx stops running git and plugin processes and rolls
+// back any branches the split created.
+package prsplit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"pr-splitter-cli/internal/config"
+	"pr-splitter-cli/internal/git"
+	"pr-splitter-cli/internal/splitter"
+	"pr-splitter-cli/internal/state"
+	"pr-splitter-cli/internal/types"
+)
+
+// Config selects the checkout a Splitter works in and where its progress log goes
+type Config struct {
+	Dir      string    // Repository checkout, the process working directory when empty
+	Progress io.Writer // Human-readable progress log, discarded when nil
+}
+
+// Splitter plans and creates partition branches in one repository checkout. A Splitter may
+// be reused for several calls but must not be used concurrently.
+type Splitter struct {
+	dir      string
+	progress io.Writer
+}
+
+// New creates a Splitter for the checkout in cfg
+func New(cfg Config) *Splitter {
+	progress := cfg.Progress
+	if progress == nil {
+		progress = io.Discard
+	}
+	return &Splitter{dir: cfg.Dir, progress: progress}
+}
+
+// Plan analyzes sourceBranch against opts.TargetBranch and returns the partitions a split
+// would create. No branch is created or changed.
+func (s *Splitter) Plan(ctx context.Context, sourceBranch string, opts SplitOptions) (*Plan, error) {
+	cfg, err := s.config(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.engine(&policyDecisions{opts: opts}).Plan(ctx, sourceBranch, cfg)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newPlan(plan, sourceBranch, cfg.TargetBranch), nil
+}
+
+// Split plans sourceBranch and, once opts.Approve accepts the plan, creates and pushes one
+// branch per partition. On failure every branch it created is rolled back, unless
+// opts.KeepProgress keeps the pushed ones for 'pr-split resume'.
+func (s *Splitter) Split(ctx context.Context, sourceBranch string, opts SplitOptions) (*Result, error) {
+	cfg, err := s.config(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	gitDir, err := s.gitClient().GitCommonDir()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := state.AcquireLock(gitDir, "prsplit library: split "+sourceBranch)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Release()
+
+	decisions := &policyDecisions{opts: opts, sourceBranch: sourceBranch, targetBranch: cfg.TargetBranch}
+	result, err := s.engine(decisions).SplitWithConfig(ctx, sourceBranch, cfg)
+	if err != nil {
+		if decisions.declined {
+			return nil, ErrNotApproved
+		}
+		return nil, wrapError(err)
+	}
+	return newResult(result, decisions.plan), nil
+}
+
+// engine creates the pipeline that does the work, answering its questions with decisions
+func (s *Splitter) engine(decisions *policyDecisions) *splitter.Splitter {
+	return splitter.NewWithOptions(splitter.Options{
+		Dir:       s.dir,
+		Output:    s.progress,
+		Decisions: decisions,
+	})
+}
+
+// gitClient returns a git client for the Splitter's checkout
+func (s *Splitter) gitClient() *git.Client {
+	if s.dir == "" {
+		return git.NewClient()
+	}
+	return git.NewClientInDir(s.dir)
+}
+
+// config validates opts and fills in the defaults the CLI uses
+func (s *Splitter) config(opts SplitOptions) (*types.Config, error) {
+	cfg := opts.toConfig()
+	if strings.Contains(cfg.BranchNamespace, "{user}") {
+		cfg.BranchNamespace = config.ResolveNamespace(cfg.BranchNamespace, s.gitClient().GetUserSlug())
+	}
+	if err := config.ValidateConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+	if cfg.KeepProgress && cfg.UpdateExisting {
+		return nil, fmt.Errorf("invalid options: KeepProgress cannot be combined with UpdateExisting")
+	}
+	if s.dir != "" {
+		if _, err := os.Stat(s.dir); err != nil {
+			return nil, fmt.Errorf("invalid checkout: %w", err)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,162 @@
+package prsplit
+
+import "pr-splitter-cli/internal/types"
+
+// Change types of a File
+const (
+	ChangeAdded    = "added"
+	ChangeModified = "modified"
+	ChangeDeleted  = "deleted"
+	ChangeRenamed  = "renamed"
+)
+
+// Check statuses of a validation Check
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// Plan is the set of partitions a branch is split into
+type Plan struct {
+	SourceBranch    string
+	TargetBranch    string
+	MergeBase       string // Commit all partition diffs are computed against
+	BaseCommit      string // Commit the first partition branches are created from
+	Strategy        string
+	Topology        string
+	Partitions      []Partition
+	DependencyEdges int // Dependency edges between changed files
+	CutEdges        int // Dependency edges between files in different partitions
+}
+
+// Partition is one reviewable slice of the branch, created as its own branch
+type Partition struct {
+	ID           int    // Position in creation order, starting at 1
+	Slug         string // Stable identifier, e.g. "auth" or "api-2"
+	Name         string
+	Description  string
+	Branch       string
+	Files        []File
+	DependsOn    []int    // IDs of partitions this one builds on
+	Owner        string   // Predominant recent author or team of the files, if known
+	Checklist    []string // Review checklist items triggered by the files
+	FailedChecks []string // PartitionChecks commands that failed on the branch, under CheckPolicyContinue
+}
+
+// File is a changed file in a partition
+type File struct {
+	Path         string
+	OldPath      string // Previous path of a renamed file
+	Change       string // One of the Change constants
+	LinesAdded   int
+	LinesDeleted int
+	Binary       bool // Binary content, applied byte for byte
+	Submodule    bool // Submodule pointer, applied as the commit it points at
+}
+
+// Result describes a completed split
+type Result struct {
+	RunID    string // Identifies the run in commit trailers and 'pr-split status'
+	Plan     *Plan
+	Branches []string // Created branches in partition order
+	Checks   []Check  // Pre- and post-creation validation results
+}
+
+// Check is the outcome of one validation of the plan or the created branches
+type Check struct {
+	Type    string
+	Status  string // One of the Check constants
+	Message string
+}
+
+// newPlan converts a pipeline plan
+func newPlan(plan *types.PartitionPlan, sourceBranch, targetBranch string) *Plan {
+	result := &Plan{
+		SourceBranch:    sourceBranch,
+		TargetBranch:    targetBranch,
+		MergeBase:       plan.Metadata.MergeBase,
+		BaseCommit:      plan.Metadata.BaseCommit,
+		Strategy:        plan.Metadata.Strategy,
+		Topology:        plan.Metadata.Topology,
+		DependencyEdges: plan.Metadata.DependencyEdges,
+		CutEdges:        plan.Metadata.CutEdges,
+	}
+	if result.Topology == "" {
+		result.Topology = TopologyLinear
+	}
+	for _, partition := range plan.Partitions {
+		result.Partitions = append(result.Partitions, newPartition(partition))
+	}
+	return result
+}
+
+// newPartition converts a pipeline partition, keeping only the changed files
+func newPartition(partition types.Partition) Partition {
+	result := Partition{
+		ID:           partition.ID,
+		Slug:         partition.Slug,
+		Name:         partition.Name,
+		Description:  partition.Description,
+		Branch:       partition.BranchName,
+		DependsOn:    partition.Dependencies,
+		Owner:        partition.Owner,
+		Checklist:    partition.Checklist,
+		FailedChecks: partition.FailedChecks,
+	}
+	for _, file := range partition.Files {
+		if !file.IsChanged {
+			continue
+		}
+		result.Files = append(result.Files, File{
+			Path:         file.Path,
+			OldPath:      file.OldPath,
+			Change:       changeNames[file.ChangeType],
+			LinesAdded:   file.LinesAdded,
+			LinesDeleted: file.LinesDeleted,
+			Binary:       file.IsBinary,
+			Submodule:    file.IsSubmodule,
+		})
+	}
+	return result
+}
+
+// newResult converts a pipeline result and the plan it was created from
+func newResult(result *types.SplitResult, plan *types.PartitionPlan) *Result {
+	if plan == nil {
+		plan = &types.PartitionPlan{Partitions: result.Partitions}
+	}
+	converted := &Result{
+		RunID:    result.RunID,
+		Plan:     newPlan(plan, result.SourceBranch, result.TargetBranch),
+		Branches: result.CreatedBranches,
+		Checks:   newChecks(result.ValidationResults),
+	}
+	return converted
+}
+
+// newChecks converts pipeline validation results
+func newChecks(results []types.ValidationResult) []Check {
+	var checks []Check
+	for _, result := range results {
+		checks = append(checks, Check{
+			Type:    string(result.Type),
+			Status:  checkNames[result.Status],
+			Message: result.Message,
+		})
+	}
+	return checks
+}
+
+var changeNames = map[types.ChangeType]string{
+	types.ChangeTypeAdd:    ChangeAdded,
+	types.ChangeTypeModify: ChangeModified,
+	types.ChangeTypeDelete: ChangeDeleted,
+	types.ChangeTypeRename: ChangeRenamed,
+}
+
+var checkNames = map[types.ValidationStatus]string{
+	types.ValidationStatusPass: CheckPass,
+	types.ValidationStatusWarn: CheckWarn,
+	types.ValidationStatusFail: CheckFail,
+}